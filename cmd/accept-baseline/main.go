@@ -0,0 +1,44 @@
+// Command accept-baseline records a new version of a stack's stored
+// assertion baseline (a plan snapshot, an inventory snapshot, an add-on
+// config snapshot) along with who accepted it and why, so a fixture
+// update is an explicit, reviewable decision rather than a file quietly
+// overwritten by whoever last ran the test.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/baselinestore"
+)
+
+func main() {
+	root := flag.String("root", "test/baselines", "baseline store root directory")
+	kind := flag.String("kind", "", "baseline kind: plan-snapshot, inventory-snapshot, or addon-snapshot")
+	stack := flag.String("stack", "", "stack name")
+	env := flag.String("env", "", "environment name")
+	file := flag.String("file", "", "path to the new baseline data")
+	by := flag.String("by", "", "who is accepting this change, e.g. your username")
+	reason := flag.String("reason", "", "why this change is expected")
+	flag.Parse()
+
+	if *kind == "" || *stack == "" || *env == "" || *file == "" || *by == "" {
+		log.Fatal("accept-baseline: -kind, -stack, -env, -file and -by are all required")
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		log.Fatalf("accept-baseline: %v", err)
+	}
+
+	store := baselinestore.FileStore{Root: *root}
+	baseline, err := baselinestore.Accept(context.Background(), store, baselinestore.Kind(*kind), *stack, *env, data, *by, *reason, time.Now())
+	if err != nil {
+		log.Fatalf("accept-baseline: %v", err)
+	}
+
+	log.Printf("accepted %s/%s/%s as v%d\n", *kind, *stack, *env, baseline.Version)
+}
@@ -0,0 +1,83 @@
+// Command canary deploys a full ephemeral environment from the latest
+// main, runs the scenario suite, records the result and estimated cost to
+// history, and tears the environment down. It is meant to be invoked by
+// the nightly workflow so Azure-side breakage (image versions, API
+// behavior) is caught before it reaches a real deploy.
+package main
+
+import (
+	"flag"
+	"log"
+	"os/exec"
+	"time"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/canaryhistory"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/runner"
+)
+
+func main() {
+	env := flag.String("env", "canary", "ephemeral Terraspace environment to provision")
+	stack := flag.String("stack", "all", "stack to deploy; \"all\" runs every stack")
+	historyPath := flag.String("history", "test/canary_history.jsonl", "path to the canary history JSON Lines file")
+	runID := flag.String("run-id", "", "unique identifier for this run, e.g. the CI run number")
+	costUSD := flag.Float64("cost-usd", 0, "estimated cost of this run, for the cost-over-time trend")
+	flag.Parse()
+
+	if *runID == "" {
+		log.Fatal("canary: -run-id is required")
+	}
+
+	r := runner.New(*env)
+	entry := canaryhistory.Entry{RunID: *runID, StartedAt: time.Now(), CostUSD: *costUSD}
+
+	if err := runCanary(r, *stack); err != nil {
+		entry.Passed = false
+		entry.FailureMsg = err.Error()
+	} else {
+		entry.Passed = true
+	}
+	entry.Duration = time.Since(entry.StartedAt)
+
+	if err := canaryhistory.Append(*historyPath, entry); err != nil {
+		log.Fatalf("canary: record history: %v", err)
+	}
+	if !entry.Passed {
+		log.Fatalf("canary: run %s failed: %s", *runID, entry.FailureMsg)
+	}
+}
+
+func runCanary(r *runner.Runner, stack string) error {
+	if _, err := r.Up(stack); err != nil {
+		return err
+	}
+	defer func() {
+		if _, err := r.Down(stack); err != nil {
+			log.Printf("canary: teardown failed for %s: %v", stack, err)
+		}
+	}()
+
+	return runScenarios(r.Env)
+}
+
+func runScenarios(env string) error {
+	cmd := exec.Command("go", "test", "./test/...", "-run", "Scenario")
+	cmd.Env = append(cmd.Environ(), "TS_ENV="+env)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return &scenarioError{output: string(out), err: err}
+	}
+	return nil
+}
+
+type scenarioError struct {
+	output string
+	err    error
+}
+
+func (e *scenarioError) Error() string {
+	return e.err.Error() + "\n" + e.output
+}
+
+func (e *scenarioError) Unwrap() error {
+	return e.err
+}
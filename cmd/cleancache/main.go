@@ -0,0 +1,47 @@
+// Command cleancache prunes stale Terraspace/Terraform build artifacts
+// (.terraspace-cache, .terraform) from a directory tree, reporting the
+// space reclaimed. It is safe to run from a cron job on self-hosted
+// runners or ad hoc on a developer machine.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/cachegc"
+)
+
+func main() {
+	root := flag.String("root", ".", "directory tree to scan for stale artifacts")
+	olderThan := flag.Duration("older-than", 7*24*time.Hour, "minimum age before a cache directory is eligible for removal")
+	dryRun := flag.Bool("dry-run", false, "report what would be removed without deleting anything")
+	flag.Parse()
+
+	result, err := cachegc.Prune(cachegc.Options{
+		Root:      *root,
+		OlderThan: *olderThan,
+		DryRun:    *dryRun,
+	})
+	if err != nil {
+		log.Fatalf("cleancache: %v", err)
+	}
+
+	verb := "removed"
+	if *dryRun {
+		verb = "would remove"
+	}
+	for _, dir := range result.Removed {
+		fmt.Fprintf(os.Stdout, "%s %s\n", verb, dir)
+	}
+	fmt.Fprintf(os.Stdout, "%s %d director%s, freed %d bytes\n", verb, len(result.Removed), plural(len(result.Removed)), result.BytesFreed)
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return "y"
+	}
+	return "ies"
+}
@@ -0,0 +1,85 @@
+// Command conformance runs the CIS AKS benchmark subset (see
+// harness/conformance) against a deployed cluster and prints a scored
+// report, exiting non-zero if any High severity check fails.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v5"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/conformance"
+)
+
+func main() {
+	subscriptionID := flag.String("subscription", "", "Azure subscription ID")
+	resourceGroup := flag.String("resource-group", "", "resource group containing the cluster")
+	clusterName := flag.String("cluster", "", "AKS cluster name")
+	flag.Parse()
+
+	if *subscriptionID == "" || *resourceGroup == "" || *clusterName == "" {
+		log.Fatal("conformance: -subscription, -resource-group, and -cluster are required")
+	}
+
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		log.Fatalf("conformance: %v", err)
+	}
+	client, err := armcontainerservice.NewManagedClustersClient(*subscriptionID, cred, nil)
+	if err != nil {
+		log.Fatalf("conformance: %v", err)
+	}
+
+	resp, err := client.Get(context.Background(), *resourceGroup, *clusterName, nil)
+	if err != nil {
+		log.Fatalf("conformance: fetch cluster: %v", err)
+	}
+
+	cfg := fromManagedCluster(resp.ManagedCluster)
+	report := conformance.Run(cfg, conformance.DefaultProfile())
+
+	for _, res := range report.Results {
+		status := "PASS"
+		if !res.Pass {
+			status = "FAIL"
+		}
+		fmt.Printf("[%s] %s: %s\n", status, res.Check.ID, res.Check.Name)
+	}
+	fmt.Printf("score: %.1f\n", report.Score)
+
+	for _, res := range report.Results {
+		if !res.Pass && res.Check.Severity == conformance.High {
+			os.Exit(1)
+		}
+	}
+}
+
+func fromManagedCluster(mc armcontainerservice.ManagedCluster) conformance.ClusterConfig {
+	cfg := conformance.ClusterConfig{}
+	if p := mc.Properties; p != nil {
+		cfg.RBACEnabled = boolValue(p.EnableRBAC)
+		cfg.LocalAccountsDisabled = boolValue(p.DisableLocalAccounts)
+		if apa := p.APIServerAccessProfile; apa != nil {
+			cfg.PrivateClusterEnabled = boolValue(apa.EnablePrivateCluster)
+			for _, ip := range apa.AuthorizedIPRanges {
+				if ip != nil {
+					cfg.APIServerAuthorizedIPs = append(cfg.APIServerAuthorizedIPs, *ip)
+				}
+			}
+		}
+		if addons := p.AddonProfiles; addons != nil {
+			if policy, ok := addons["azurepolicy"]; ok && policy != nil {
+				cfg.AzurePolicyAddonEnabled = boolValue(policy.Enabled)
+			}
+		}
+	}
+	return cfg
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
@@ -0,0 +1,94 @@
+// Command driftwatch polls an Event Grid namespace topic subscription for
+// Resource Manager change events on the managed resource groups and, for
+// each event that looks like an out-of-band change, runs `terraspace
+// plan` against the configured stacks and fails loudly if the plan isn't
+// clean — closing the loop between manual portal edits and this repo's
+// Terraform source of truth.
+//
+// Usage:
+//
+//	driftwatch -endpoint <namespace-endpoint> -topic <topic> -subscription-name <event-sub> -env <e> -stacks <s1,s2,...> [-root <repo-root>] [-poll-interval <d>]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/eventgrid/aznamespaces"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/driftwatch"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/runner"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/tsoutput"
+)
+
+// runnerTrigger runs `terraspace plan` for every configured stack and
+// fails if any comes back with pending changes.
+type runnerTrigger struct {
+	run    *runner.Runner
+	stacks []string
+}
+
+func (t runnerTrigger) RunDriftCheck(ctx context.Context, resourceGroup string) error {
+	for _, stack := range t.stacks {
+		result, err := t.run.Plan(stack)
+		if err != nil {
+			return fmt.Errorf("plan %s for %s: %w", stack, resourceGroup, err)
+		}
+
+		parsed, err := tsoutput.Parse(strings.NewReader(result.Output))
+		if err != nil {
+			return fmt.Errorf("parse plan output for %s: %w", stack, err)
+		}
+		if !parsed.NoChanges {
+			return fmt.Errorf("stack %s has drifted after an out-of-band change to %s: plan shows pending changes", stack, resourceGroup)
+		}
+	}
+	return nil
+}
+
+func main() {
+	endpoint := flag.String("endpoint", "", "Event Grid namespace HTTP endpoint")
+	topic := flag.String("topic", "", "Event Grid topic name")
+	eventSubscriptionName := flag.String("subscription-name", "", "Event Grid event subscription name")
+	env := flag.String("env", "", "Terraspace environment the watched resource groups belong to")
+	stacksFlag := flag.String("stacks", "", "comma-separated stacks to plan when drift is suspected")
+	root := flag.String("root", ".", "repository root")
+	pollInterval := flag.Duration("poll-interval", 30*time.Second, "how often to poll the topic subscription")
+	flag.Parse()
+
+	if *endpoint == "" || *topic == "" || *eventSubscriptionName == "" || *env == "" || *stacksFlag == "" {
+		log.Fatal("driftwatch: -endpoint, -topic, -subscription-name, -env and -stacks are required")
+	}
+
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		log.Fatalf("driftwatch: %v", err)
+	}
+	client, err := aznamespaces.NewReceiverClient(*endpoint, *topic, *eventSubscriptionName, cred, nil)
+	if err != nil {
+		log.Fatalf("driftwatch: %v", err)
+	}
+
+	r := runner.New(*env)
+	r.Dir = *root
+
+	poller := driftwatch.Poller{
+		Receiver:     client,
+		WatchedTypes: driftwatch.DefaultWatchedTypes,
+		PollInterval: *pollInterval,
+	}
+	trigger := runnerTrigger{run: r, stacks: strings.Split(*stacksFlag, ",")}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := poller.Run(ctx, trigger, func(err error) { log.Printf("driftwatch: %v", err) }); err != nil && ctx.Err() == nil {
+		log.Fatalf("driftwatch: %v", err)
+	}
+}
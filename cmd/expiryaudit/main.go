@@ -0,0 +1,62 @@
+// Command expiryaudit enumerates Key Vault certificates and secrets in a
+// vault and reports anything expiring within N days, printing one line per
+// finding and exiting non-zero if any were found. Meant to run nightly so
+// an expiring certificate or secret doesn't turn into a surprise outage.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azcertificates"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/expiryaudit"
+)
+
+func main() {
+	vaultName := flag.String("vault", "", "Key Vault name to audit")
+	within := flag.Duration("within", 30*24*time.Hour, "report anything expiring within this duration")
+	flag.Parse()
+
+	if *vaultName == "" {
+		log.Fatal("expiryaudit: -vault is required")
+	}
+
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		log.Fatalf("expiryaudit: %v", err)
+	}
+
+	vaultURL := fmt.Sprintf("https://%s.vault.azure.net", *vaultName)
+	secretsClient, err := azsecrets.NewClient(vaultURL, cred, nil)
+	if err != nil {
+		log.Fatalf("expiryaudit: %v", err)
+	}
+	certsClient, err := azcertificates.NewClient(vaultURL, cred, nil)
+	if err != nil {
+		log.Fatalf("expiryaudit: %v", err)
+	}
+
+	listers := []expiryaudit.Lister{
+		expiryaudit.KeyVaultSecretLister{VaultName: *vaultName, Client: secretsClient},
+		expiryaudit.KeyVaultCertificateLister{VaultName: *vaultName, Client: certsClient},
+	}
+
+	report := expiryaudit.Audit(context.Background(), listers, *within, time.Now)
+
+	for _, e := range report.Errors {
+		fmt.Fprintf(os.Stderr, "expiryaudit: %v\n", e.Err)
+	}
+	for _, f := range report.Findings {
+		fmt.Printf("%s %s/%s expires in %d day(s) (%s)\n", f.Item.Kind, f.Item.Scope, f.Item.Name, f.DaysRemaining, f.Item.ExpiresAt.Format(time.RFC3339))
+	}
+
+	if !report.Clean() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,38 @@
+// Command newstack scaffolds a new Terraspace stack: its Terraform files,
+// per-environment tfvars, an expectations fixture, a matching Go test, and
+// a dependency graph entry, in one shot.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/scaffold"
+)
+
+func main() {
+	name := flag.String("name", "", "stack name, e.g. aks-cluster")
+	root := flag.String("root", ".", "repository root")
+	envs := flag.String("envs", "", "comma-separated envs to generate tfvars for (default: dev,staging,prod)")
+	flag.Parse()
+
+	if *name == "" {
+		log.Fatal("newstack: -name is required")
+	}
+
+	opts := scaffold.Options{Root: *root, Name: *name}
+	if *envs != "" {
+		opts.Envs = strings.Split(*envs, ",")
+	}
+
+	created, err := scaffold.New(opts)
+	if err != nil {
+		log.Fatalf("newstack: %v", err)
+	}
+	for _, path := range created {
+		fmt.Fprintln(os.Stdout, path)
+	}
+}
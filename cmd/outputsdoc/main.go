@@ -0,0 +1,112 @@
+// Command outputsdoc renders a stack's output contract plus its live
+// `terraform output -json` values into a consumer-facing reference,
+// published by CI after a successful deploy so platform consumers have
+// one place to find endpoint FQDNs, resource IDs and identity client IDs
+// without reading Terraform source.
+//
+// Usage:
+//
+//	outputsdoc -stack <s> -env <e> -contracts <contracts.json> -outputs <outputs.json> [-format md|json] [-out <path>]
+//
+// contracts.json is an array of {"name", "type", "because", "format"}
+// objects (format is an optional regexp, checked against TypeString
+// values only), matching harness/outputcontract.Contract.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/outputcontract"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/outputsdoc"
+)
+
+// contractSpec mirrors outputcontract.Contract in a JSON-serializable
+// shape, since Contract.Format is a compiled *regexp.Regexp.
+type contractSpec struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Because string `json:"because"`
+	Format  string `json:"format"`
+}
+
+func main() {
+	stack := flag.String("stack", "", "Terraspace stack name")
+	env := flag.String("env", "", "environment (dev, staging, prod, ...)")
+	contractsPath := flag.String("contracts", "", "path to a contracts JSON file (see package doc)")
+	outputsPath := flag.String("outputs", "", "path to a `terraform output -json` file")
+	format := flag.String("format", "md", "output format: md or json")
+	outPath := flag.String("out", "", "file to write the rendered doc to (default: stdout)")
+	flag.Parse()
+
+	if *stack == "" || *env == "" || *contractsPath == "" || *outputsPath == "" {
+		log.Fatal("outputsdoc: -stack, -env, -contracts and -outputs are required")
+	}
+
+	contracts, err := loadContracts(*contractsPath)
+	if err != nil {
+		log.Fatalf("outputsdoc: %v", err)
+	}
+
+	outputsData, err := os.ReadFile(*outputsPath)
+	if err != nil {
+		log.Fatalf("outputsdoc: read %s: %v", *outputsPath, err)
+	}
+	outputs, err := outputcontract.Parse(outputsData)
+	if err != nil {
+		log.Fatalf("outputsdoc: %v", err)
+	}
+
+	doc := outputsdoc.Build(*stack, *env, contracts, outputs)
+
+	var rendered []byte
+	switch *format {
+	case "md":
+		rendered = []byte(outputsdoc.RenderMarkdown(doc))
+	case "json":
+		rendered, err = outputsdoc.RenderJSON(doc)
+		if err != nil {
+			log.Fatalf("outputsdoc: %v", err)
+		}
+	default:
+		log.Fatalf("outputsdoc: unknown -format %q, want md or json", *format)
+	}
+
+	if *outPath == "" {
+		fmt.Print(string(rendered))
+		return
+	}
+	if err := os.WriteFile(*outPath, rendered, 0o644); err != nil {
+		log.Fatalf("outputsdoc: write %s: %v", *outPath, err)
+	}
+}
+
+func loadContracts(path string) ([]outputcontract.Contract, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	var specs []contractSpec
+	if err := json.Unmarshal(data, &specs); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	contracts := make([]outputcontract.Contract, 0, len(specs))
+	for _, s := range specs {
+		c := outputcontract.Contract{Name: s.Name, Type: outputcontract.Type(s.Type), Because: s.Because}
+		if s.Format != "" {
+			re, err := regexp.Compile(s.Format)
+			if err != nil {
+				return nil, fmt.Errorf("contract %q: compile format %q: %w", s.Name, s.Format, err)
+			}
+			c.Format = re
+		}
+		contracts = append(contracts, c)
+	}
+	return contracts, nil
+}
@@ -0,0 +1,104 @@
+// Command sandbox gives an engineer a one-command personal test cluster: a
+// cost-capped, TTL-tagged copy of the core+aks stacks under a
+// developer-specific name prefix. It does not verify the sandbox itself:
+// `up` prints the `go test ./test/...` command that runs the repo's test
+// suite against it.
+//
+// Usage:
+//
+//	sandbox up [-owner <alias>] [-ttl <duration>]
+//	sandbox down -owner <alias>
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/runner"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/sandbox"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal("sandbox: expected a subcommand: up, down")
+	}
+
+	switch os.Args[1] {
+	case "up":
+		runUp(os.Args[2:])
+	case "down":
+		runDown(os.Args[2:])
+	default:
+		log.Fatalf("sandbox: unknown subcommand %q", os.Args[1])
+	}
+}
+
+func runUp(args []string) {
+	fs := flag.NewFlagSet("up", flag.ExitOnError)
+	owner := fs.String("owner", currentUser(), "developer alias the sandbox is scoped to")
+	ttl := fs.Duration("ttl", sandbox.DefaultTTL, "how long before the sandbox should be torn down")
+	root := fs.String("root", ".", "repository root")
+	fs.Parse(args)
+
+	cfg, err := sandbox.Resolve(sandbox.Options{Owner: *owner, TTL: *ttl})
+	if err != nil {
+		log.Fatalf("sandbox: %v", err)
+	}
+
+	if _, err := sandbox.WriteTFVars(*root, cfg); err != nil {
+		log.Fatalf("sandbox: %v", err)
+	}
+
+	r := runner.New(cfg.Env)
+	r.Dir = *root
+	for _, stack := range sandbox.CoreStacks {
+		result, err := r.Up(stack)
+		if err != nil {
+			log.Fatalf("sandbox: up %s: %v\n%s", stack, err, result.Output)
+		}
+		fmt.Printf("up %s (env=%s) in %s\n", stack, cfg.Env, result.Duration)
+	}
+
+	fmt.Printf("sandbox %s ready, expires at %s\n", cfg.Env, cfg.ExpiresAt.Format(time.RFC3339))
+	fmt.Printf("run the verification suite with: TS_ENV=%s go test ./test/...\n", cfg.Env)
+	fmt.Printf("tear it down with: sandbox down -owner %s\n", *owner)
+}
+
+func runDown(args []string) {
+	fs := flag.NewFlagSet("down", flag.ExitOnError)
+	owner := fs.String("owner", currentUser(), "developer alias the sandbox is scoped to")
+	root := fs.String("root", ".", "repository root")
+	fs.Parse(args)
+
+	cfg, err := sandbox.Resolve(sandbox.Options{Owner: *owner})
+	if err != nil {
+		log.Fatalf("sandbox: %v", err)
+	}
+
+	r := runner.New(cfg.Env)
+	r.Dir = *root
+	for i := len(sandbox.CoreStacks) - 1; i >= 0; i-- {
+		stack := sandbox.CoreStacks[i]
+		result, err := r.Down(stack)
+		if err != nil {
+			log.Fatalf("sandbox: down %s: %v\n%s", stack, err, result.Output)
+		}
+		fmt.Printf("down %s (env=%s) in %s\n", stack, cfg.Env, result.Duration)
+	}
+
+	if err := sandbox.Teardown(*root, cfg); err != nil {
+		log.Fatalf("sandbox: %v", err)
+	}
+	fmt.Printf("sandbox %s removed\n", cfg.Env)
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return "dev"
+}
@@ -0,0 +1,75 @@
+// Package addonsnapshot captures the enabled state and configuration of
+// an AKS cluster's add-ons (Key Vault CSI, Azure Policy, the monitoring
+// agent, the workload identity webhook) and diffs it against a stored
+// baseline. AKS auto-upgrades add-ons on its own schedule, independent of
+// Terraform's applied version — this catches the case where an add-on's
+// behavior changed under us with no Terraform diff to explain it.
+//
+// This follows the same normalize-then-Diff shape as harness/goldenimage:
+// callers own persisting Snapshot as the stored baseline; this package
+// only owns building one from a live cluster and comparing two.
+package addonsnapshot
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Addon is one add-on's captured state.
+type Addon struct {
+	Enabled bool
+	Config  map[string]string
+}
+
+// Snapshot is every add-on's captured state, keyed by add-on name (e.g.
+// "azurepolicy", "omsagent", "azureKeyvaultSecretsProvider",
+// "workloadIdentity").
+type Snapshot map[string]Addon
+
+// ChangeKind classifies how an add-on diverged from the baseline.
+type ChangeKind string
+
+const (
+	ChangeAdded          ChangeKind = "added"
+	ChangeRemoved        ChangeKind = "removed"
+	ChangeEnabledToggled ChangeKind = "enabled-toggled"
+	ChangeConfigChanged  ChangeKind = "config-changed"
+)
+
+// Change is a single add-on that diverged between baseline and current.
+type Change struct {
+	Addon  string
+	Kind   ChangeKind
+	Detail string
+}
+
+// Diff compares a stored baseline against a freshly captured Snapshot and
+// returns every add-on that was added, removed, or changed, sorted by
+// add-on name for stable output.
+func Diff(baseline, current Snapshot) []Change {
+	var changes []Change
+
+	for name, base := range baseline {
+		cur, ok := current[name]
+		if !ok {
+			changes = append(changes, Change{Addon: name, Kind: ChangeRemoved, Detail: fmt.Sprintf("%s is in the baseline but no longer present", name)})
+			continue
+		}
+		if base.Enabled != cur.Enabled {
+			changes = append(changes, Change{Addon: name, Kind: ChangeEnabledToggled, Detail: fmt.Sprintf("%s enabled changed from %t to %t", name, base.Enabled, cur.Enabled)})
+			continue
+		}
+		if !reflect.DeepEqual(base.Config, cur.Config) {
+			changes = append(changes, Change{Addon: name, Kind: ChangeConfigChanged, Detail: fmt.Sprintf("%s config differs from the baseline", name)})
+		}
+	}
+	for name := range current {
+		if _, ok := baseline[name]; !ok {
+			changes = append(changes, Change{Addon: name, Kind: ChangeAdded, Detail: fmt.Sprintf("%s is present but not in the baseline", name)})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Addon < changes[j].Addon })
+	return changes
+}
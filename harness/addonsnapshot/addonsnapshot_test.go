@@ -0,0 +1,68 @@
+package addonsnapshot
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffFlagsRemovedAddon(t *testing.T) {
+	baseline := Snapshot{"azurepolicy": {Enabled: true}}
+	changes := Diff(baseline, Snapshot{})
+	require.Len(t, changes, 1)
+	assert.Equal(t, ChangeRemoved, changes[0].Kind)
+	assert.Equal(t, "azurepolicy", changes[0].Addon)
+}
+
+func TestDiffFlagsAddedAddon(t *testing.T) {
+	current := Snapshot{"omsagent": {Enabled: true}}
+	changes := Diff(Snapshot{}, current)
+	require.Len(t, changes, 1)
+	assert.Equal(t, ChangeAdded, changes[0].Kind)
+}
+
+func TestDiffFlagsEnabledToggled(t *testing.T) {
+	baseline := Snapshot{"azurepolicy": {Enabled: true}}
+	current := Snapshot{"azurepolicy": {Enabled: false}}
+	changes := Diff(baseline, current)
+	require.Len(t, changes, 1)
+	assert.Equal(t, ChangeEnabledToggled, changes[0].Kind)
+}
+
+func TestDiffFlagsConfigChanged(t *testing.T) {
+	baseline := Snapshot{"omsagent": {Enabled: true, Config: map[string]string{"logAnalyticsWorkspaceResourceID": "/old"}}}
+	current := Snapshot{"omsagent": {Enabled: true, Config: map[string]string{"logAnalyticsWorkspaceResourceID": "/new"}}}
+	changes := Diff(baseline, current)
+	require.Len(t, changes, 1)
+	assert.Equal(t, ChangeConfigChanged, changes[0].Kind)
+}
+
+func TestDiffPassesWhenSnapshotsMatch(t *testing.T) {
+	snapshot := Snapshot{"azurepolicy": {Enabled: true, Config: map[string]string{"version": "v2"}}}
+	assert.Empty(t, Diff(snapshot, snapshot))
+}
+
+func TestFromManagedClusterCapturesAddonProfilesAndWorkloadIdentity(t *testing.T) {
+	enabled := true
+	workspace := "/workspace"
+	mc := armcontainerservice.ManagedCluster{
+		Properties: &armcontainerservice.ManagedClusterProperties{
+			AddonProfiles: map[string]*armcontainerservice.ManagedClusterAddonProfile{
+				"omsagent": {Enabled: &enabled, Config: map[string]*string{"logAnalyticsWorkspaceResourceID": &workspace}},
+			},
+			SecurityProfile: &armcontainerservice.ManagedClusterSecurityProfile{
+				WorkloadIdentity: &armcontainerservice.ManagedClusterSecurityProfileWorkloadIdentity{Enabled: &enabled},
+			},
+		},
+	}
+
+	snapshot := FromManagedCluster(mc)
+	assert.Equal(t, Addon{Enabled: true, Config: map[string]string{"logAnalyticsWorkspaceResourceID": "/workspace"}}, snapshot["omsagent"])
+	assert.Equal(t, Addon{Enabled: true}, snapshot["workloadIdentity"])
+}
+
+func TestFromManagedClusterHandlesNilProperties(t *testing.T) {
+	assert.Empty(t, FromManagedCluster(armcontainerservice.ManagedCluster{}))
+}
@@ -0,0 +1,50 @@
+package addonsnapshot
+
+import "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v5"
+
+// FromManagedCluster captures a Snapshot from a live ManagedCluster: every
+// entry in AddonProfiles, plus the workload identity webhook, which AKS
+// exposes as a security profile feature rather than an add-on profile.
+//
+// AKS's control plane API doesn't expose a per-add-on image/version
+// string the way it does the cluster's KubernetesVersion, so Config is
+// each add-on's own key-value settings rather than a version number —
+// still enough to catch an auto-upgrade that flips a setting or an addon
+// that silently changed its default config.
+func FromManagedCluster(mc armcontainerservice.ManagedCluster) Snapshot {
+	snapshot := Snapshot{}
+	p := mc.Properties
+	if p == nil {
+		return snapshot
+	}
+
+	for name, profile := range p.AddonProfiles {
+		if profile == nil {
+			continue
+		}
+		snapshot[name] = Addon{Enabled: boolValue(profile.Enabled), Config: derefConfig(profile.Config)}
+	}
+
+	if sp := p.SecurityProfile; sp != nil && sp.WorkloadIdentity != nil {
+		snapshot["workloadIdentity"] = Addon{Enabled: boolValue(sp.WorkloadIdentity.Enabled)}
+	}
+
+	return snapshot
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
+
+func derefConfig(config map[string]*string) map[string]string {
+	if config == nil {
+		return nil
+	}
+	out := make(map[string]string, len(config))
+	for k, v := range config {
+		if v != nil {
+			out[k] = *v
+		}
+	}
+	return out
+}
@@ -0,0 +1,93 @@
+// Package aksdeploy asserts a deployed AKS cluster matches what the aks
+// stack was configured to produce: private cluster mode, node pool
+// count/size, Kubernetes version, and the subnet it's attached to. It's
+// the assertion half of an end-to-end AKS stack test — deploy via
+// Terraspace, extract a ClusterConfig with FromManagedCluster, call
+// Verify — the way harness/verifyexisting already composes
+// harness/conformance for an existing cluster.
+//
+// This snapshot of the repo has no app/stacks/aks/aks_test.go yet, so
+// nothing calls this package today; it's the reusable helper that test
+// would call once the aks stack itself exists here.
+package aksdeploy
+
+import "fmt"
+
+// NodePool is one agent pool's observed shape.
+type NodePool struct {
+	Name     string
+	Count    int32
+	VMSize   string
+	SubnetID string
+}
+
+// ClusterConfig is the subset of a deployed AKS cluster's shape this
+// package checks against. Callers populate it from the
+// armcontainerservice ManagedCluster returned after apply.
+type ClusterConfig struct {
+	PrivateClusterEnabled bool
+	KubernetesVersion     string
+	NodePools             []NodePool
+}
+
+// Expected is what the aks stack was configured to produce.
+type Expected struct {
+	KubernetesVersion string
+	SubnetID          string
+	NodePools         []ExpectedNodePool
+}
+
+// ExpectedNodePool is one node pool's expected shape, matched to the
+// deployed cluster's node pools by Name.
+type ExpectedNodePool struct {
+	Name   string
+	Count  int32
+	VMSize string
+}
+
+// Violation is a single mismatch between the deployed cluster and what
+// the stack was configured to produce.
+type Violation struct {
+	Message string
+}
+
+// Verify checks cluster is a private cluster, running expected's
+// Kubernetes version, with every expected node pool present at its
+// expected count and size, attached to expected's subnet.
+func Verify(cluster ClusterConfig, expected Expected) []Violation {
+	var violations []Violation
+
+	if !cluster.PrivateClusterEnabled {
+		violations = append(violations, Violation{Message: "cluster is not running in private cluster mode"})
+	}
+	if expected.KubernetesVersion != "" && cluster.KubernetesVersion != expected.KubernetesVersion {
+		violations = append(violations, Violation{Message: fmt.Sprintf(
+			"cluster is running Kubernetes %s, want %s", cluster.KubernetesVersion, expected.KubernetesVersion)})
+	}
+
+	byName := make(map[string]NodePool, len(cluster.NodePools))
+	for _, np := range cluster.NodePools {
+		byName[np.Name] = np
+	}
+	for _, exp := range expected.NodePools {
+		np, ok := byName[exp.Name]
+		if !ok {
+			violations = append(violations, Violation{Message: fmt.Sprintf("node pool %q not found on cluster", exp.Name)})
+			continue
+		}
+		if np.Count != exp.Count {
+			violations = append(violations, Violation{Message: fmt.Sprintf(
+				"node pool %q has %d node(s), want %d", exp.Name, np.Count, exp.Count)})
+		}
+		if np.VMSize != exp.VMSize {
+			violations = append(violations, Violation{Message: fmt.Sprintf(
+				"node pool %q is size %s, want %s", exp.Name, np.VMSize, exp.VMSize)})
+		}
+		if expected.SubnetID != "" && np.SubnetID != expected.SubnetID {
+			violations = append(violations, Violation{Message: fmt.Sprintf(
+				"node pool %q is attached to subnet %s, want %s", exp.Name, np.SubnetID, expected.SubnetID)})
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,78 @@
+package aksdeploy
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func expectedCluster() Expected {
+	return Expected{
+		KubernetesVersion: "1.30.2",
+		SubnetID:          "/subnets/aks",
+		NodePools:         []ExpectedNodePool{{Name: "system", Count: 3, VMSize: "Standard_D4s_v5"}},
+	}
+}
+
+func TestVerifyPassesWhenClusterMatchesExpected(t *testing.T) {
+	cluster := ClusterConfig{
+		PrivateClusterEnabled: true,
+		KubernetesVersion:     "1.30.2",
+		NodePools:             []NodePool{{Name: "system", Count: 3, VMSize: "Standard_D4s_v5", SubnetID: "/subnets/aks"}},
+	}
+	assert.Empty(t, Verify(cluster, expectedCluster()))
+}
+
+func TestVerifyFlagsPublicCluster(t *testing.T) {
+	cluster := ClusterConfig{KubernetesVersion: "1.30.2", NodePools: []NodePool{{Name: "system", Count: 3, VMSize: "Standard_D4s_v5", SubnetID: "/subnets/aks"}}}
+	violations := Verify(cluster, expectedCluster())
+	require.NotEmpty(t, violations)
+	assert.Contains(t, violations[0].Message, "private cluster mode")
+}
+
+func TestVerifyFlagsMissingNodePool(t *testing.T) {
+	cluster := ClusterConfig{PrivateClusterEnabled: true, KubernetesVersion: "1.30.2"}
+	violations := Verify(cluster, expectedCluster())
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "system")
+}
+
+func TestVerifyFlagsNodePoolSizeMismatch(t *testing.T) {
+	cluster := ClusterConfig{
+		PrivateClusterEnabled: true,
+		KubernetesVersion:     "1.30.2",
+		NodePools:             []NodePool{{Name: "system", Count: 2, VMSize: "Standard_D2s_v5", SubnetID: "/subnets/aks"}},
+	}
+	violations := Verify(cluster, expectedCluster())
+	assert.Len(t, violations, 2)
+}
+
+func TestFromManagedClusterExtractsShape(t *testing.T) {
+	enabled := true
+	version := "1.30.2"
+	name := "system"
+	count := int32(3)
+	size := "Standard_D4s_v5"
+	subnet := "/subnets/aks"
+	mc := armcontainerservice.ManagedCluster{
+		Properties: &armcontainerservice.ManagedClusterProperties{
+			APIServerAccessProfile: &armcontainerservice.ManagedClusterAPIServerAccessProfile{EnablePrivateCluster: &enabled},
+			KubernetesVersion:      &version,
+			AgentPoolProfiles: []*armcontainerservice.ManagedClusterAgentPoolProfile{
+				{Name: &name, Count: &count, VMSize: &size, VnetSubnetID: &subnet},
+			},
+		},
+	}
+
+	cfg := FromManagedCluster(mc)
+	assert.True(t, cfg.PrivateClusterEnabled)
+	assert.Equal(t, "1.30.2", cfg.KubernetesVersion)
+	require.Len(t, cfg.NodePools, 1)
+	assert.Equal(t, NodePool{Name: "system", Count: 3, VMSize: "Standard_D4s_v5", SubnetID: "/subnets/aks"}, cfg.NodePools[0])
+}
+
+func TestFromManagedClusterHandlesNilProperties(t *testing.T) {
+	assert.Empty(t, FromManagedCluster(armcontainerservice.ManagedCluster{}).NodePools)
+}
@@ -0,0 +1,49 @@
+package aksdeploy
+
+import "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v5"
+
+// FromManagedCluster extracts a ClusterConfig from a live ManagedCluster.
+func FromManagedCluster(mc armcontainerservice.ManagedCluster) ClusterConfig {
+	cfg := ClusterConfig{}
+	p := mc.Properties
+	if p == nil {
+		return cfg
+	}
+
+	if p.APIServerAccessProfile != nil {
+		cfg.PrivateClusterEnabled = boolValue(p.APIServerAccessProfile.EnablePrivateCluster)
+	}
+	cfg.KubernetesVersion = derefStr(p.KubernetesVersion)
+
+	for _, agentPool := range p.AgentPoolProfiles {
+		if agentPool == nil {
+			continue
+		}
+		cfg.NodePools = append(cfg.NodePools, NodePool{
+			Name:     derefStr(agentPool.Name),
+			Count:    int32Value(agentPool.Count),
+			VMSize:   derefStr(agentPool.VMSize),
+			SubnetID: derefStr(agentPool.VnetSubnetID),
+		})
+	}
+
+	return cfg
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
+
+func int32Value(v *int32) int32 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func derefStr(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
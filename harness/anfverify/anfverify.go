@@ -0,0 +1,127 @@
+// Package anfverify checks Azure NetApp Files (or premium file share)
+// stacks for workloads that need throughput this AKS cluster's default
+// storage can't provide. It asserts the delegated subnet, capacity pool
+// and volume provisioning, and export policies restricted to the AKS
+// subnet, then runs an in-cluster mount-and-write probe. Every check here
+// is gated behind FeatureFlagEnv: most environments don't provision ANF at
+// all, and running these assertions unconditionally would fail every
+// stack that has no volume to check.
+package anfverify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/netapp/armnetapp"
+)
+
+// FeatureFlagEnv gates this package's checks behind an explicit opt-in,
+// since ANF is provisioned for a minority of workloads.
+const FeatureFlagEnv = "ENABLE_ANF_VERIFICATION"
+
+// netAppDelegation is the required subnet delegation for an ANF volume.
+const netAppDelegation = "Microsoft.NetApp/volumes"
+
+// Enabled reports whether ANF verification should run, based on getenv
+// (pass os.Getenv in production).
+func Enabled(getenv func(string) string) bool {
+	return getenv(FeatureFlagEnv) == "true"
+}
+
+// Violation is a single ANF configuration mismatch.
+type Violation struct {
+	Message string
+}
+
+// DelegatedSubnet is the subset of a subnet's config this package checks.
+type DelegatedSubnet struct {
+	Name        string
+	Delegations []string // service names, e.g. "Microsoft.NetApp/volumes"
+}
+
+// VerifyDelegatedSubnet fails unless subnet is delegated to
+// Microsoft.NetApp/volumes.
+func VerifyDelegatedSubnet(subnet DelegatedSubnet) []Violation {
+	for _, d := range subnet.Delegations {
+		if d == netAppDelegation {
+			return nil
+		}
+	}
+	return []Violation{{Message: fmt.Sprintf("subnet %s is not delegated to %s", subnet.Name, netAppDelegation)}}
+}
+
+// VerifyCapacityPool fails unless pool has provisioned successfully.
+func VerifyCapacityPool(pool armnetapp.CapacityPool) []Violation {
+	if pool.Properties == nil || derefStr(pool.Properties.ProvisioningState) != "Succeeded" {
+		return []Violation{{Message: fmt.Sprintf("capacity pool %s provisioning state is %q, want Succeeded", derefStr(pool.Name), provisioningState(pool))}}
+	}
+	return nil
+}
+
+func provisioningState(pool armnetapp.CapacityPool) string {
+	if pool.Properties == nil {
+		return ""
+	}
+	return derefStr(pool.Properties.ProvisioningState)
+}
+
+// VerifyVolume fails unless volume has provisioned successfully, sits on
+// aksSubnetID, and its export policy allows only aksSubnetCIDR.
+func VerifyVolume(volume armnetapp.Volume, aksSubnetID, aksSubnetCIDR string) []Violation {
+	var violations []Violation
+	name := derefStr(volume.Name)
+
+	if volume.Properties == nil {
+		return []Violation{{Message: fmt.Sprintf("volume %s has no properties", name)}}
+	}
+
+	if derefStr(volume.Properties.ProvisioningState) != "Succeeded" {
+		violations = append(violations, Violation{Message: fmt.Sprintf("volume %s provisioning state is %q, want Succeeded", name, derefStr(volume.Properties.ProvisioningState))})
+	}
+
+	if derefStr(volume.Properties.SubnetID) != aksSubnetID {
+		violations = append(violations, Violation{Message: fmt.Sprintf("volume %s is on subnet %s, want the AKS subnet %s", name, derefStr(volume.Properties.SubnetID), aksSubnetID)})
+	}
+
+	violations = append(violations, verifyExportPolicy(name, volume.Properties.ExportPolicy, aksSubnetCIDR)...)
+	return violations
+}
+
+func verifyExportPolicy(volumeName string, policy *armnetapp.VolumePropertiesExportPolicy, aksSubnetCIDR string) []Violation {
+	if policy == nil || len(policy.Rules) == 0 {
+		return []Violation{{Message: fmt.Sprintf("volume %s has no export policy rules", volumeName)}}
+	}
+	for _, rule := range policy.Rules {
+		if rule == nil {
+			continue
+		}
+		if derefStr(rule.AllowedClients) != aksSubnetCIDR {
+			return []Violation{{Message: fmt.Sprintf("volume %s export policy allows %q, want only the AKS subnet %s", volumeName, derefStr(rule.AllowedClients), aksSubnetCIDR)}}
+		}
+	}
+	return nil
+}
+
+// MountWriteProber exercises a real mount-and-write from inside the
+// cluster, typically via a short-lived pod mounting the volume through
+// the CSI driver.
+type MountWriteProber interface {
+	MountAndWrite(ctx context.Context, volumeName string) error
+}
+
+// VerifyMountAndWrite fails if the volume can't be mounted and written to
+// from inside the cluster, catching CSI/RBAC misconfiguration that the
+// ARM-level checks above can't see.
+func VerifyMountAndWrite(ctx context.Context, prober MountWriteProber, volumeName string) []Violation {
+	if err := prober.MountAndWrite(ctx, volumeName); err != nil {
+		return []Violation{{Message: fmt.Sprintf("mount-and-write probe against volume %s failed: %v", volumeName, err)}}
+	}
+	return nil
+}
+
+func derefStr(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
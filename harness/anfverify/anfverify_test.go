@@ -0,0 +1,96 @@
+package anfverify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/netapp/armnetapp"
+	"github.com/stretchr/testify/assert"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestEnabledReadsFeatureFlag(t *testing.T) {
+	assert.True(t, Enabled(func(string) string { return "true" }))
+	assert.False(t, Enabled(func(string) string { return "" }))
+}
+
+func TestVerifyDelegatedSubnetPassesWithNetAppDelegation(t *testing.T) {
+	violations := VerifyDelegatedSubnet(DelegatedSubnet{Name: "anf-subnet", Delegations: []string{netAppDelegation}})
+	assert.Empty(t, violations)
+}
+
+func TestVerifyDelegatedSubnetFailsWithoutDelegation(t *testing.T) {
+	violations := VerifyDelegatedSubnet(DelegatedSubnet{Name: "anf-subnet"})
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyCapacityPoolFailsWhenNotSucceeded(t *testing.T) {
+	pool := armnetapp.CapacityPool{Name: strPtr("pool1"), Properties: &armnetapp.PoolProperties{ProvisioningState: strPtr("Creating")}}
+	violations := VerifyCapacityPool(pool)
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyCapacityPoolPassesWhenSucceeded(t *testing.T) {
+	pool := armnetapp.CapacityPool{Name: strPtr("pool1"), Properties: &armnetapp.PoolProperties{ProvisioningState: strPtr("Succeeded")}}
+	assert.Empty(t, VerifyCapacityPool(pool))
+}
+
+func TestVerifyVolumeFlagsWrongSubnetAndOpenExportPolicy(t *testing.T) {
+	volume := armnetapp.Volume{
+		Name: strPtr("vol1"),
+		Properties: &armnetapp.VolumeProperties{
+			ProvisioningState: strPtr("Succeeded"),
+			SubnetID:          strPtr("/subscriptions/x/subnets/other"),
+			ExportPolicy: &armnetapp.VolumePropertiesExportPolicy{
+				Rules: []*armnetapp.ExportPolicyRule{{AllowedClients: strPtr("0.0.0.0/0")}},
+			},
+		},
+	}
+
+	violations := VerifyVolume(volume, "/subscriptions/x/subnets/aks", "10.0.1.0/24")
+	assert.Len(t, violations, 2)
+}
+
+func TestVerifyVolumePassesWhenFullyCompliant(t *testing.T) {
+	volume := armnetapp.Volume{
+		Name: strPtr("vol1"),
+		Properties: &armnetapp.VolumeProperties{
+			ProvisioningState: strPtr("Succeeded"),
+			SubnetID:          strPtr("/subscriptions/x/subnets/aks"),
+			ExportPolicy: &armnetapp.VolumePropertiesExportPolicy{
+				Rules: []*armnetapp.ExportPolicyRule{{AllowedClients: strPtr("10.0.1.0/24")}},
+			},
+		},
+	}
+
+	assert.Empty(t, VerifyVolume(volume, "/subscriptions/x/subnets/aks", "10.0.1.0/24"))
+}
+
+func TestVerifyVolumeFailsWithNoExportPolicy(t *testing.T) {
+	volume := armnetapp.Volume{
+		Name: strPtr("vol1"),
+		Properties: &armnetapp.VolumeProperties{
+			ProvisioningState: strPtr("Succeeded"),
+			SubnetID:          strPtr("/subscriptions/x/subnets/aks"),
+		},
+	}
+
+	violations := VerifyVolume(volume, "/subscriptions/x/subnets/aks", "10.0.1.0/24")
+	assert.Len(t, violations, 1)
+}
+
+type fakeProber struct{ err error }
+
+func (f *fakeProber) MountAndWrite(ctx context.Context, volumeName string) error { return f.err }
+
+func TestVerifyMountAndWriteFailsOnProbeError(t *testing.T) {
+	violations := VerifyMountAndWrite(context.Background(), &fakeProber{err: errors.New("permission denied")}, "vol1")
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyMountAndWritePassesWhenProbeSucceeds(t *testing.T) {
+	violations := VerifyMountAndWrite(context.Background(), &fakeProber{}, "vol1")
+	assert.Empty(t, violations)
+}
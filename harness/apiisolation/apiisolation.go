@@ -0,0 +1,62 @@
+// Package apiisolation asserts the core promise of a private AKS
+// cluster: its API server is not reachable from the public internet, and
+// its FQDN doesn't resolve to a routable address for a resolver outside
+// the VNet. Every other assertion in this harness assumes that promise
+// holds; this is what actually tests it, by dialing and resolving from
+// where a public attacker would sit and expecting both to fail.
+package apiisolation
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// Dialer matches net.Dialer's DialContext signature, run from outside
+// the VNet (a public CI runner, not a self-hosted one), so a fake can
+// stand in for it in tests without opening a real socket.
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// VerifyNotPubliclyReachable dials host:443 via dialer and returns an
+// error if the dial succeeds — a private cluster's API server must
+// refuse or time out connections from outside the VNet, not accept them.
+func VerifyNotPubliclyReachable(ctx context.Context, dialer Dialer, host string, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	dialCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := dialer.DialContext(dialCtx, "tcp", net.JoinHostPort(host, "443"))
+	if err == nil {
+		conn.Close()
+		return fmt.Errorf("apiisolation: dialed %s:443 from outside the VNet, expected the connection to be refused or time out", host)
+	}
+	return nil
+}
+
+// PublicResolver looks up fqdn using DNS resolution available to the
+// public internet (not the private DNS zone linked into the VNet).
+type PublicResolver interface {
+	LookupHost(ctx context.Context, fqdn string) ([]string, error)
+}
+
+// VerifyNotPubliclyResolvable resolves fqdn via resolver and returns an
+// error if it resolves to privateIP — that would mean the private DNS
+// zone leaked into public DNS, or the FQDN also has a public A record
+// pointing at the cluster's private address.
+func VerifyNotPubliclyResolvable(ctx context.Context, resolver PublicResolver, fqdn, privateIP string) error {
+	ips, err := resolver.LookupHost(ctx, fqdn)
+	if err != nil {
+		return nil // NXDOMAIN/lookup failure from the public internet is the expected outcome
+	}
+	for _, ip := range ips {
+		if ip == privateIP {
+			return fmt.Errorf("apiisolation: public resolution of %s returned private IP %s", fqdn, privateIP)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package apiisolation
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDialer struct {
+	conn net.Conn
+	err  error
+}
+
+func (f fakeDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return f.conn, f.err
+}
+
+type fakeConn struct{ net.Conn }
+
+func (fakeConn) Close() error { return nil }
+
+func TestVerifyNotPubliclyReachablePassesWhenDialFails(t *testing.T) {
+	dialer := fakeDialer{err: errors.New("connection timed out")}
+	assert.NoError(t, VerifyNotPubliclyReachable(context.Background(), dialer, "aks-priv.example.com", time.Second))
+}
+
+func TestVerifyNotPubliclyReachableFailsWhenDialSucceeds(t *testing.T) {
+	dialer := fakeDialer{conn: fakeConn{}}
+	err := VerifyNotPubliclyReachable(context.Background(), dialer, "aks-priv.example.com", time.Second)
+	assert.ErrorContains(t, err, "aks-priv.example.com")
+}
+
+type fakeResolver struct {
+	ips []string
+	err error
+}
+
+func (f fakeResolver) LookupHost(ctx context.Context, fqdn string) ([]string, error) {
+	return f.ips, f.err
+}
+
+func TestVerifyNotPubliclyResolvablePassesOnLookupFailure(t *testing.T) {
+	resolver := fakeResolver{err: errors.New("NXDOMAIN")}
+	assert.NoError(t, VerifyNotPubliclyResolvable(context.Background(), resolver, "aks-priv.example.com", "10.2.0.4"))
+}
+
+func TestVerifyNotPubliclyResolvablePassesOnUnrelatedIP(t *testing.T) {
+	resolver := fakeResolver{ips: []string{"20.1.2.3"}}
+	assert.NoError(t, VerifyNotPubliclyResolvable(context.Background(), resolver, "aks-priv.example.com", "10.2.0.4"))
+}
+
+func TestVerifyNotPubliclyResolvableFailsWhenPrivateIPLeaks(t *testing.T) {
+	resolver := fakeResolver{ips: []string{"10.2.0.4"}}
+	err := VerifyNotPubliclyResolvable(context.Background(), resolver, "aks-priv.example.com", "10.2.0.4")
+	assert.ErrorContains(t, err, "10.2.0.4")
+}
@@ -0,0 +1,71 @@
+// Package applytuning benchmarks runner.Tuning settings against a real
+// stack to find the parallelism sweet spot for a subscription: too low
+// and applies are slow for no reason, too high and Azure Resource
+// Manager starts throttling, which Terraform retries into an even slower
+// apply than a lower parallelism would have produced.
+package applytuning
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/runner"
+)
+
+// Applier applies stack under a given Tuning, e.g. a runner.Runner with
+// Tuning set per call.
+type Applier interface {
+	ApplyWithTuning(stack string, tuning runner.Tuning) (runner.Result, error)
+}
+
+// Trial is one parallelism setting's measured outcome.
+type Trial struct {
+	Tuning   runner.Tuning
+	Duration time.Duration
+	Err      error
+}
+
+// Sweep applies stack once per candidate parallelism value via applier,
+// in order, and returns every Trial. It doesn't stop at the first error —
+// a throttling failure at one parallelism doesn't mean higher or lower
+// values would also fail — so callers see the full curve.
+func Sweep(applier Applier, stack string, parallelisms []int) []Trial {
+	trials := make([]Trial, 0, len(parallelisms))
+	for _, p := range parallelisms {
+		tuning := runner.Tuning{Parallelism: p}
+		result, err := applier.ApplyWithTuning(stack, tuning)
+		trials = append(trials, Trial{Tuning: tuning, Duration: result.Duration, Err: err})
+	}
+	return trials
+}
+
+// Best returns the fastest trial that didn't error, and false if every
+// trial errored.
+func Best(trials []Trial) (Trial, bool) {
+	var best Trial
+	found := false
+	for _, t := range trials {
+		if t.Err != nil {
+			continue
+		}
+		if !found || t.Duration < best.Duration {
+			best = t
+			found = true
+		}
+	}
+	return best, found
+}
+
+// Report renders trials as a human-readable summary suitable for a PR
+// comment or CI log, one line per parallelism value tried.
+func Report(trials []Trial) string {
+	var out string
+	for _, t := range trials {
+		if t.Err != nil {
+			out += fmt.Sprintf("parallelism=%d: failed: %v\n", t.Tuning.Parallelism, t.Err)
+			continue
+		}
+		out += fmt.Sprintf("parallelism=%d: %s\n", t.Tuning.Parallelism, t.Duration)
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package applytuning
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/runner"
+)
+
+type fakeApplier struct {
+	durations map[int]time.Duration
+	errs      map[int]error
+}
+
+func (f fakeApplier) ApplyWithTuning(stack string, tuning runner.Tuning) (runner.Result, error) {
+	return runner.Result{Stack: stack, Duration: f.durations[tuning.Parallelism]}, f.errs[tuning.Parallelism]
+}
+
+func TestSweepRunsEveryCandidate(t *testing.T) {
+	applier := fakeApplier{durations: map[int]time.Duration{5: 10 * time.Minute, 10: 6 * time.Minute, 20: 8 * time.Minute}}
+
+	trials := Sweep(applier, "aks", []int{5, 10, 20})
+	require.Len(t, trials, 3)
+	assert.Equal(t, 6*time.Minute, trials[1].Duration)
+}
+
+func TestBestPicksFastestNonErroredTrial(t *testing.T) {
+	applier := fakeApplier{
+		durations: map[int]time.Duration{5: 10 * time.Minute, 10: 6 * time.Minute, 20: 5 * time.Minute},
+		errs:      map[int]error{20: errors.New("429 throttled")},
+	}
+
+	best, ok := Best(Sweep(applier, "aks", []int{5, 10, 20}))
+	require.True(t, ok)
+	assert.Equal(t, 10, best.Tuning.Parallelism)
+}
+
+func TestBestReportsNoneWhenEveryTrialErrors(t *testing.T) {
+	applier := fakeApplier{errs: map[int]error{5: errors.New("fail"), 10: errors.New("fail")}}
+
+	_, ok := Best(Sweep(applier, "aks", []int{5, 10}))
+	assert.False(t, ok)
+}
+
+func TestReportIncludesFailedTrials(t *testing.T) {
+	applier := fakeApplier{errs: map[int]error{20: errors.New("429 throttled")}}
+
+	report := Report(Sweep(applier, "aks", []int{20}))
+	assert.Contains(t, report, "failed")
+	assert.Contains(t, report, "429 throttled")
+}
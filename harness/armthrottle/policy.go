@@ -0,0 +1,111 @@
+// Package armthrottle captures Azure Resource Manager rate-limit telemetry
+// from SDK responses and uses it to slow down verification polling before
+// the harness starves real deployments sharing the same subscription.
+package armthrottle
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// Snapshot is the most recently observed rate-limit state for a subscription.
+type Snapshot struct {
+	// RemainingReads/RemainingWrites come from the x-ms-ratelimit-remaining-*
+	// response headers ARM returns on every call.
+	RemainingReads  int
+	RemainingWrites int
+	// RetryAfter is the time until which ARM asked the caller to back off,
+	// computed from a 429 response's Retry-After header (in seconds). The
+	// zero time when not throttled.
+	RetryAfter time.Time
+	// ObservedAt is when this snapshot was captured.
+	ObservedAt time.Time
+}
+
+// Tracker accumulates rate-limit telemetry across every ARM call made
+// through its Policy, and exposes an adaptive delay for pollers to respect.
+type Tracker struct {
+	mu       sync.Mutex
+	snapshot Snapshot
+}
+
+// NewTracker returns an empty Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// Policy returns an azcore pipeline policy that records rate-limit headers
+// from every response it observes.
+func (t *Tracker) Policy() policy.Policy {
+	return trackingPolicy{tracker: t}
+}
+
+// Snapshot returns the most recently observed rate-limit state.
+func (t *Tracker) Snapshot() Snapshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.snapshot
+}
+
+// Delay returns how long a caller should wait before its next ARM call,
+// given the last observed telemetry: the full Retry-After window if ARM is
+// actively throttling, otherwise a slowdown proportional to how depleted
+// the remaining write quota is (below 60, ~5% of an assumed 1200/hr budget).
+func (t *Tracker) Delay(now time.Time) time.Duration {
+	snap := t.Snapshot()
+	if snap.ObservedAt.IsZero() {
+		return 0
+	}
+	if snap.RetryAfter.After(now) {
+		return snap.RetryAfter.Sub(now)
+	}
+	const lowWriteThreshold = 60 // ~5% of the default 1200/hr ARM write budget
+	if snap.RemainingWrites < lowWriteThreshold {
+		return time.Second * time.Duration(lowWriteThreshold-snap.RemainingWrites)
+	}
+	return 0
+}
+
+func (t *Tracker) record(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snap := t.snapshot
+	snap.ObservedAt = time.Now()
+	snap.RetryAfter = time.Time{}
+	if v := resp.Header.Get("x-ms-ratelimit-remaining-subscription-reads"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			snap.RemainingReads = n
+		}
+	}
+	if v := resp.Header.Get("x-ms-ratelimit-remaining-subscription-writes"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			snap.RemainingWrites = n
+		}
+	}
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			snap.RetryAfter = time.Now().Add(time.Duration(secs) * time.Second)
+		}
+	}
+	t.snapshot = snap
+}
+
+type trackingPolicy struct {
+	tracker *Tracker
+}
+
+func (p trackingPolicy) Do(req *policy.Request) (*http.Response, error) {
+	resp, err := req.Next()
+	if resp != nil {
+		p.tracker.record(resp)
+	}
+	return resp, err
+}
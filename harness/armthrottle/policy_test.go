@@ -0,0 +1,59 @@
+package armthrottle
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackerRecordsRateLimitHeaders(t *testing.T) {
+	tracker := NewTracker()
+	resp := &http.Response{Header: http.Header{
+		"X-Ms-Ratelimit-Remaining-Subscription-Reads":  []string{"11998"},
+		"X-Ms-Ratelimit-Remaining-Subscription-Writes": []string{"1199"},
+	}}
+
+	tracker.record(resp)
+
+	snap := tracker.Snapshot()
+	require.False(t, snap.ObservedAt.IsZero())
+	assert.Equal(t, 11998, snap.RemainingReads)
+	assert.Equal(t, 1199, snap.RemainingWrites)
+	assert.Zero(t, tracker.Delay(time.Now()))
+}
+
+func TestTrackerDelayHonorsRetryAfter(t *testing.T) {
+	tracker := NewTracker()
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}
+	before := time.Now()
+
+	tracker.record(resp)
+
+	delay := tracker.Delay(before)
+	assert.InDelta(t, 5*time.Second, delay, float64(2*time.Second))
+}
+
+func TestTrackerDelaySlowsDownWhenWritesAreFullyDepleted(t *testing.T) {
+	tracker := NewTracker()
+	resp := &http.Response{Header: http.Header{
+		"X-Ms-Ratelimit-Remaining-Subscription-Writes": []string{"0"},
+	}}
+
+	tracker.record(resp)
+
+	assert.Equal(t, 60*time.Second, tracker.Delay(time.Now()))
+}
+
+func TestTrackerDelaySlowsDownWhenWritesAreLow(t *testing.T) {
+	tracker := NewTracker()
+	resp := &http.Response{Header: http.Header{
+		"X-Ms-Ratelimit-Remaining-Subscription-Writes": []string{"10"},
+	}}
+
+	tracker.record(resp)
+
+	assert.Equal(t, 50*time.Second, tracker.Delay(time.Now()))
+}
@@ -0,0 +1,81 @@
+// Package auditmode runs this repo's read-only verification checks
+// against a live environment — prod included — without ever calling
+// Terraspace apply or destroy, turning the same assertions used to gate a
+// deploy into a standalone compliance/health audit tool. A Check here is
+// any existing harness Violation-returning check wrapped to fit this
+// package's signature; auditmode only owns collecting their results into
+// one report, not the checks themselves.
+package auditmode
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Check is one read-only assertion. Run must not mutate the environment;
+// it only reads state and reports violations found.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) ([]string, error)
+}
+
+// Result is the outcome of running a single Check.
+type Result struct {
+	Name       string
+	Violations []string
+	Err        error
+}
+
+// Passed reports whether this check found no violations and didn't error.
+func (r Result) Passed() bool {
+	return r.Err == nil && len(r.Violations) == 0
+}
+
+// Report is the outcome of running every Check against one environment.
+type Report struct {
+	Env         string
+	GeneratedAt time.Time
+	Results     []Result
+}
+
+// Healthy reports whether every check in the report passed.
+func (r Report) Healthy() bool {
+	for _, res := range r.Results {
+		if !res.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Failures returns every Result that didn't pass.
+func (r Report) Failures() []Result {
+	var failures []Result
+	for _, res := range r.Results {
+		if !res.Passed() {
+			failures = append(failures, res)
+		}
+	}
+	return failures
+}
+
+// Run executes every check against env and collects the results into a
+// Report. A check that returns an error is recorded as a failed Result
+// rather than aborting the remaining checks, so one broken query doesn't
+// hide every other finding in the same audit.
+func Run(ctx context.Context, env string, now func() time.Time, checks []Check) Report {
+	if now == nil {
+		now = time.Now
+	}
+
+	report := Report{Env: env, GeneratedAt: now()}
+	for _, c := range checks {
+		violations, err := c.Run(ctx)
+		if err != nil {
+			err = fmt.Errorf("auditmode: check %q: %w", c.Name, err)
+		}
+		report.Results = append(report.Results, Result{Name: c.Name, Violations: violations, Err: err})
+	}
+	return report
+}
@@ -0,0 +1,64 @@
+package auditmode
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCollectsResultsForEveryCheck(t *testing.T) {
+	checks := []Check{
+		{Name: "kv-network-acl", Run: func(ctx context.Context) ([]string, error) { return nil, nil }},
+		{Name: "nsg-rules", Run: func(ctx context.Context) ([]string, error) { return []string{"rule X too permissive"}, nil }},
+	}
+	fixed := time.Unix(1000, 0)
+
+	report := Run(context.Background(), "prod", func() time.Time { return fixed }, checks)
+
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, "prod", report.Env)
+	assert.Equal(t, fixed, report.GeneratedAt)
+}
+
+func TestReportHealthyWhenAllChecksPass(t *testing.T) {
+	checks := []Check{{Name: "a", Run: func(ctx context.Context) ([]string, error) { return nil, nil }}}
+	report := Run(context.Background(), "prod", nil, checks)
+	assert.True(t, report.Healthy())
+}
+
+func TestReportUnhealthyWhenACheckHasViolations(t *testing.T) {
+	checks := []Check{{Name: "a", Run: func(ctx context.Context) ([]string, error) { return []string{"bad"}, nil }}}
+	report := Run(context.Background(), "prod", nil, checks)
+	assert.False(t, report.Healthy())
+	assert.Len(t, report.Failures(), 1)
+}
+
+func TestRunRecordsCheckErrorWithoutAbortingRemainingChecks(t *testing.T) {
+	checks := []Check{
+		{Name: "broken-query", Run: func(ctx context.Context) ([]string, error) { return nil, errors.New("query timeout") }},
+		{Name: "still-runs", Run: func(ctx context.Context) ([]string, error) { return nil, nil }},
+	}
+
+	report := Run(context.Background(), "prod", nil, checks)
+
+	require.Len(t, report.Results, 2)
+	assert.Error(t, report.Results[0].Err)
+	assert.True(t, report.Results[1].Passed())
+	assert.False(t, report.Healthy())
+}
+
+func TestFailuresOnlyIncludesFailedResults(t *testing.T) {
+	checks := []Check{
+		{Name: "good", Run: func(ctx context.Context) ([]string, error) { return nil, nil }},
+		{Name: "bad", Run: func(ctx context.Context) ([]string, error) { return []string{"x"}, nil }},
+	}
+	report := Run(context.Background(), "prod", nil, checks)
+
+	failures := report.Failures()
+	require.Len(t, failures, 1)
+	assert.Equal(t, "bad", failures[0].Name)
+}
@@ -0,0 +1,84 @@
+package auditverify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeActor struct {
+	token string
+	err   error
+}
+
+func (f *fakeActor) PerformTestAction(ctx context.Context) (string, error) {
+	return f.token, f.err
+}
+
+type fakeQuerier struct {
+	entriesAt time.Duration
+	elapsed   time.Duration
+	entry     Entry
+}
+
+func (f *fakeQuerier) QueryAuditEntries(ctx context.Context, since time.Time) ([]Entry, error) {
+	if f.elapsed >= f.entriesAt {
+		return []Entry{f.entry}, nil
+	}
+	return nil, nil
+}
+
+func TestVerifyReturnsEntryOnceItAppears(t *testing.T) {
+	actor := &fakeActor{token: "test-cm-abc123"}
+	querier := &fakeQuerier{entriesAt: 30 * time.Second, entry: Entry{ResourceName: "test-cm-abc123"}}
+	elapsed := time.Duration(0)
+	fakeNow := func() time.Time { return time.Unix(0, 0).Add(elapsed) }
+	fakeSleep := func(d time.Duration) {
+		elapsed += d
+		querier.elapsed = elapsed
+	}
+
+	entry, err := Verify(context.Background(), actor, querier, Options{
+		Timeout:      time.Minute,
+		PollInterval: 15 * time.Second,
+		Now:          fakeNow,
+		Sleep:        fakeSleep,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "test-cm-abc123", entry.ResourceName)
+}
+
+func TestVerifyTimesOutWithoutMatchingEntry(t *testing.T) {
+	actor := &fakeActor{token: "test-cm-abc123"}
+	querier := &fakeQuerier{entriesAt: time.Hour}
+	elapsed := time.Duration(0)
+	fakeNow := func() time.Time { return time.Unix(0, 0).Add(elapsed) }
+	fakeSleep := func(d time.Duration) { elapsed += d }
+
+	_, err := Verify(context.Background(), actor, querier, Options{
+		Timeout:      30 * time.Second,
+		PollInterval: 15 * time.Second,
+		Now:          fakeNow,
+		Sleep:        fakeSleep,
+	})
+	assert.Error(t, err)
+}
+
+func TestVerifyPropagatesActorError(t *testing.T) {
+	actor := &fakeActor{err: errors.New("run-command failed")}
+	_, err := Verify(context.Background(), actor, &fakeQuerier{}, Options{Timeout: time.Second})
+	assert.Error(t, err)
+}
+
+func TestVerifyMatchesOnRawEventSubstring(t *testing.T) {
+	actor := &fakeActor{token: "test-cm-abc123"}
+	querier := &fakeQuerier{entriesAt: 0, entry: Entry{RawEvent: `{"objectRef":{"name":"test-cm-abc123"}}`}}
+
+	entry, err := Verify(context.Background(), actor, querier, Options{Timeout: time.Second})
+	require.NoError(t, err)
+	assert.Contains(t, entry.RawEvent, "test-cm-abc123")
+}
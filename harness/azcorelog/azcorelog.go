@@ -0,0 +1,89 @@
+// Package azcorelog is an azcore pipeline policy that logs method, a
+// sanitized URL, status, latency, and correlation ID for every
+// verification SDK call, so a correlation ID is on hand to hand Azure
+// support when a verification check disagrees with the portal.
+package azcorelog
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// Entry is one logged verification SDK call.
+type Entry struct {
+	Method        string
+	URL           string
+	Status        int
+	Latency       time.Duration
+	CorrelationID string
+	Err           error
+}
+
+// Sink receives every logged Entry.
+type Sink interface {
+	Log(Entry)
+}
+
+// SinkFunc adapts a plain function to a Sink.
+type SinkFunc func(Entry)
+
+// Log calls f.
+func (f SinkFunc) Log(e Entry) { f(e) }
+
+// sensitiveQueryParams are stripped from logged URLs, e.g. SAS signatures.
+var sensitiveQueryParams = []string{"sig", "sv", "se", "sp"}
+
+// Sanitize returns u with sensitive query parameters redacted.
+func Sanitize(u *url.URL) string {
+	clone := *u
+	q := clone.Query()
+	for _, key := range sensitiveQueryParams {
+		if q.Has(key) {
+			q.Set(key, "REDACTED")
+		}
+	}
+	clone.RawQuery = q.Encode()
+	return clone.String()
+}
+
+// Policy is an azcore pipeline policy that logs each request/response
+// pair it observes to Sink.
+type Policy struct {
+	Sink Sink
+	// Now defaults to time.Now; tests override it for deterministic latency.
+	Now func() time.Time
+}
+
+// New returns a Policy that logs every call to sink.
+func New(sink Sink) *Policy {
+	return &Policy{Sink: sink}
+}
+
+// Do implements policy.Policy.
+func (p *Policy) Do(req *policy.Request) (*http.Response, error) {
+	now := p.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	start := now()
+	resp, err := req.Next()
+	entry := Entry{
+		Method:  req.Raw().Method,
+		URL:     Sanitize(req.Raw().URL),
+		Latency: now().Sub(start),
+		Err:     err,
+	}
+	if resp != nil {
+		entry.Status = resp.StatusCode
+		entry.CorrelationID = resp.Header.Get("x-ms-client-request-id")
+		if entry.CorrelationID == "" {
+			entry.CorrelationID = resp.Header.Get("x-ms-request-id")
+		}
+	}
+	p.Sink.Log(entry)
+	return resp, err
+}
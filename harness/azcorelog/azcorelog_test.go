@@ -0,0 +1,92 @@
+package azcorelog
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeRedactsSASSignature(t *testing.T) {
+	u, err := url.Parse("https://example.blob.core.windows.net/container/blob?sv=2021-01-01&sig=supersecret&se=2026-01-01")
+	require.NoError(t, err)
+
+	sanitized := Sanitize(u)
+	assert.NotContains(t, sanitized, "supersecret")
+	assert.Contains(t, sanitized, "sig=REDACTED")
+}
+
+func TestSanitizeLeavesNonSensitiveParamsAlone(t *testing.T) {
+	u, err := url.Parse("https://management.azure.com/subscriptions/x?api-version=2023-01-01")
+	require.NoError(t, err)
+
+	sanitized := Sanitize(u)
+	assert.Contains(t, sanitized, "api-version=2023-01-01")
+}
+
+type fakeTransport struct {
+	status  int
+	headers http.Header
+}
+
+func (f *fakeTransport) Do(req *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: f.status, Header: f.headers, Body: http.NoBody, Request: req}, nil
+}
+
+func TestPolicyLogsMethodStatusAndCorrelationID(t *testing.T) {
+	var entries []Entry
+	sink := SinkFunc(func(e Entry) { entries = append(entries, e) })
+
+	current := time.Unix(0, 0)
+	logPolicy := &Policy{Sink: sink, Now: func() time.Time {
+		current = current.Add(time.Millisecond)
+		return current
+	}}
+
+	pipeline := runtime.NewPipeline("azcorelog-test", "v1.0.0", runtime.PipelineOptions{
+		PerCall: []policy.Policy{logPolicy},
+	}, &policy.ClientOptions{
+		Transport: &fakeTransport{status: 200, headers: http.Header{"X-Ms-Client-Request-Id": []string{"corr-123"}}},
+	})
+
+	req, err := runtime.NewRequest(context.Background(), http.MethodGet, "https://management.azure.com/subscriptions/x?sig=secret")
+	require.NoError(t, err)
+
+	_, err = pipeline.Do(req)
+	require.NoError(t, err)
+
+	require.Len(t, entries, 1)
+	assert.Equal(t, http.MethodGet, entries[0].Method)
+	assert.Equal(t, 200, entries[0].Status)
+	assert.Equal(t, "corr-123", entries[0].CorrelationID)
+	assert.NotContains(t, entries[0].URL, "secret")
+	assert.Greater(t, entries[0].Latency, time.Duration(0))
+}
+
+func TestPolicyFallsBackToRequestIDHeader(t *testing.T) {
+	var entries []Entry
+	sink := SinkFunc(func(e Entry) { entries = append(entries, e) })
+	logPolicy := New(sink)
+
+	pipeline := runtime.NewPipeline("azcorelog-test", "v1.0.0", runtime.PipelineOptions{
+		PerCall: []policy.Policy{logPolicy},
+	}, &policy.ClientOptions{
+		Transport: &fakeTransport{status: 404, headers: http.Header{"X-Ms-Request-Id": []string{"req-456"}}},
+	})
+
+	req, err := runtime.NewRequest(context.Background(), http.MethodGet, "https://management.azure.com/subscriptions/x")
+	require.NoError(t, err)
+
+	_, err = pipeline.Do(req)
+	require.NoError(t, err)
+
+	require.Len(t, entries, 1)
+	assert.Equal(t, "req-456", entries[0].CorrelationID)
+	assert.Equal(t, 404, entries[0].Status)
+}
@@ -0,0 +1,82 @@
+// Package baselinestore versions the fixtures an "expected change" vs
+// "regression" decision compares against — plan snapshots, inventory
+// snapshots, add-on config snapshots — as an append-only history instead
+// of a file silently overwritten by whoever ran the test locally.
+// Accepting a new baseline is a deliberate act: Accept writes the new
+// Baseline and an AcceptanceEntry recording who accepted it and why, the
+// same trail a code review would leave, so a diff against the stored
+// baseline is either "matches the last accepted version" or "needs
+// review" — never ambiguous.
+package baselinestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Kind identifies which assertion suite a baseline belongs to.
+type Kind string
+
+const (
+	KindPlanSnapshot      Kind = "plan-snapshot"
+	KindInventorySnapshot Kind = "inventory-snapshot"
+	KindAddonSnapshot     Kind = "addon-snapshot"
+)
+
+// Baseline is a single accepted version of a stack's expected fixture.
+type Baseline struct {
+	Kind    Kind
+	Stack   string
+	Env     string
+	Version int
+	Data    []byte
+}
+
+// AcceptanceEntry records a single deliberate baseline update.
+type AcceptanceEntry struct {
+	Kind       Kind
+	Stack      string
+	Env        string
+	Version    int
+	AcceptedBy string
+	Reason     string
+	AcceptedAt time.Time
+}
+
+// Store persists baselines and their acceptance history.
+type Store interface {
+	Latest(ctx context.Context, kind Kind, stack, env string) (Baseline, bool, error)
+	Save(ctx context.Context, baseline Baseline) error
+	RecordAcceptance(ctx context.Context, entry AcceptanceEntry) error
+}
+
+// Accept records data as the next version of kind/stack/env's baseline,
+// stamped with who accepted it and why. Versions increment from whatever
+// Latest returns, starting at 1 if there's no prior baseline.
+func Accept(ctx context.Context, store Store, kind Kind, stack, env string, data []byte, acceptedBy, reason string, now time.Time) (Baseline, error) {
+	if acceptedBy == "" {
+		return Baseline{}, fmt.Errorf("baselinestore: accept %s/%s/%s: acceptedBy is required", kind, stack, env)
+	}
+
+	current, ok, err := store.Latest(ctx, kind, stack, env)
+	if err != nil {
+		return Baseline{}, fmt.Errorf("baselinestore: read current baseline for %s/%s/%s: %w", kind, stack, env, err)
+	}
+	version := 1
+	if ok {
+		version = current.Version + 1
+	}
+
+	baseline := Baseline{Kind: kind, Stack: stack, Env: env, Version: version, Data: data}
+	if err := store.Save(ctx, baseline); err != nil {
+		return Baseline{}, fmt.Errorf("baselinestore: save %s/%s/%s v%d: %w", kind, stack, env, version, err)
+	}
+
+	entry := AcceptanceEntry{Kind: kind, Stack: stack, Env: env, Version: version, AcceptedBy: acceptedBy, Reason: reason, AcceptedAt: now}
+	if err := store.RecordAcceptance(ctx, entry); err != nil {
+		return baseline, fmt.Errorf("baselinestore: record acceptance of %s/%s/%s v%d: %w", kind, stack, env, version, err)
+	}
+
+	return baseline, nil
+}
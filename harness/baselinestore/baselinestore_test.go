@@ -0,0 +1,71 @@
+package baselinestore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	baselines map[string]Baseline
+	entries   []AcceptanceEntry
+	saveErr   error
+}
+
+func key(kind Kind, stack, env string) string { return string(kind) + "/" + stack + "/" + env }
+
+func (f *fakeStore) Latest(ctx context.Context, kind Kind, stack, env string) (Baseline, bool, error) {
+	b, ok := f.baselines[key(kind, stack, env)]
+	return b, ok, nil
+}
+
+func (f *fakeStore) Save(ctx context.Context, baseline Baseline) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	if f.baselines == nil {
+		f.baselines = map[string]Baseline{}
+	}
+	f.baselines[key(baseline.Kind, baseline.Stack, baseline.Env)] = baseline
+	return nil
+}
+
+func (f *fakeStore) RecordAcceptance(ctx context.Context, entry AcceptanceEntry) error {
+	f.entries = append(f.entries, entry)
+	return nil
+}
+
+func TestAcceptStartsAtVersionOne(t *testing.T) {
+	store := &fakeStore{}
+	baseline, err := Accept(context.Background(), store, KindPlanSnapshot, "aks", "dev", []byte("{}"), "jane", "expected node pool add", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, baseline.Version)
+	require.Len(t, store.entries, 1)
+	assert.Equal(t, "jane", store.entries[0].AcceptedBy)
+}
+
+func TestAcceptIncrementsFromLatest(t *testing.T) {
+	store := &fakeStore{}
+	_, err := Accept(context.Background(), store, KindPlanSnapshot, "aks", "dev", []byte("{}"), "jane", "first", time.Now())
+	require.NoError(t, err)
+
+	baseline, err := Accept(context.Background(), store, KindPlanSnapshot, "aks", "dev", []byte("{}"), "jane", "second", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 2, baseline.Version)
+}
+
+func TestAcceptRequiresAcceptedBy(t *testing.T) {
+	store := &fakeStore{}
+	_, err := Accept(context.Background(), store, KindPlanSnapshot, "aks", "dev", []byte("{}"), "", "reason", time.Now())
+	assert.ErrorContains(t, err, "acceptedBy")
+}
+
+func TestAcceptPropagatesSaveError(t *testing.T) {
+	store := &fakeStore{saveErr: errors.New("disk full")}
+	_, err := Accept(context.Background(), store, KindPlanSnapshot, "aks", "dev", []byte("{}"), "jane", "reason", time.Now())
+	assert.ErrorContains(t, err, "disk full")
+}
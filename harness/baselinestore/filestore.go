@@ -0,0 +1,82 @@
+package baselinestore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileStore persists baselines as files under Root, one per
+// kind/stack/env/version, and appends acceptance entries to a single
+// audit log alongside them — a local, checked-in-adjacent counterpart to
+// harness/runner's prod-confirmation audit log, for repos that don't yet
+// have a blob backend wired up for this.
+type FileStore struct {
+	Root string
+}
+
+func (s FileStore) baselinePath(kind Kind, stack, env string, version int) string {
+	return filepath.Join(s.Root, string(kind), stack, env, fmt.Sprintf("v%d.json", version))
+}
+
+func (s FileStore) auditLogPath() string {
+	return filepath.Join(s.Root, "acceptances.log")
+}
+
+// Latest returns the highest-numbered baseline on disk for kind/stack/env.
+func (s FileStore) Latest(ctx context.Context, kind Kind, stack, env string) (Baseline, bool, error) {
+	dir := filepath.Join(s.Root, string(kind), stack, env)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return Baseline{}, false, nil
+	}
+	if err != nil {
+		return Baseline{}, false, fmt.Errorf("baselinestore: list %s: %w", dir, err)
+	}
+
+	best := 0
+	for _, e := range entries {
+		var version int
+		if _, err := fmt.Sscanf(e.Name(), "v%d.json", &version); err == nil && version > best {
+			best = version
+		}
+	}
+	if best == 0 {
+		return Baseline{}, false, nil
+	}
+
+	data, err := os.ReadFile(s.baselinePath(kind, stack, env, best))
+	if err != nil {
+		return Baseline{}, false, fmt.Errorf("baselinestore: read v%d for %s/%s/%s: %w", best, kind, stack, env, err)
+	}
+	return Baseline{Kind: kind, Stack: stack, Env: env, Version: best, Data: data}, true, nil
+}
+
+// Save writes baseline to its version-numbered file, creating parent
+// directories as needed.
+func (s FileStore) Save(ctx context.Context, baseline Baseline) error {
+	path := s.baselinePath(baseline.Kind, baseline.Stack, baseline.Env, baseline.Version)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("baselinestore: create directory for %s: %w", path, err)
+	}
+	return os.WriteFile(path, baseline.Data, 0o644)
+}
+
+// RecordAcceptance appends entry as one line to the store's audit log.
+func (s FileStore) RecordAcceptance(ctx context.Context, entry AcceptanceEntry) error {
+	if err := os.MkdirAll(s.Root, 0o755); err != nil {
+		return fmt.Errorf("baselinestore: create root %s: %w", s.Root, err)
+	}
+	f, err := os.OpenFile(s.auditLogPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	line := fmt.Sprintf("%s kind=%s stack=%s env=%s version=%d by=%s reason=%q\n",
+		entry.AcceptedAt.Format(time.RFC3339), entry.Kind, entry.Stack, entry.Env, entry.Version, entry.AcceptedBy, entry.Reason)
+	_, err = f.WriteString(line)
+	return err
+}
@@ -0,0 +1,52 @@
+package baselinestore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStoreLatestReturnsFalseWhenEmpty(t *testing.T) {
+	store := FileStore{Root: t.TempDir()}
+	_, ok, err := store.Latest(context.Background(), KindPlanSnapshot, "aks", "dev")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestFileStoreAcceptWritesVersionedFileAndAuditLine(t *testing.T) {
+	root := t.TempDir()
+	store := FileStore{Root: root}
+
+	baseline, err := Accept(context.Background(), store, KindAddonSnapshot, "aks", "dev", []byte(`{"omsagent":{"enabled":true}}`), "jane", "auto-upgrade toggled monitoring", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, baseline.Version)
+
+	data, err := os.ReadFile(filepath.Join(root, "addon-snapshot", "aks", "dev", "v1.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "omsagent")
+
+	log, err := os.ReadFile(filepath.Join(root, "acceptances.log"))
+	require.NoError(t, err)
+	assert.Contains(t, string(log), "by=jane")
+}
+
+func TestFileStoreLatestFindsHighestVersion(t *testing.T) {
+	root := t.TempDir()
+	store := FileStore{Root: root}
+
+	_, err := Accept(context.Background(), store, KindPlanSnapshot, "aks", "dev", []byte("v1"), "jane", "first", time.Now())
+	require.NoError(t, err)
+	_, err = Accept(context.Background(), store, KindPlanSnapshot, "aks", "dev", []byte("v2"), "jane", "second", time.Now())
+	require.NoError(t, err)
+
+	latest, ok, err := store.Latest(context.Background(), KindPlanSnapshot, "aks", "dev")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, 2, latest.Version)
+	assert.Equal(t, "v2", string(latest.Data))
+}
@@ -0,0 +1,64 @@
+// Package bastion runs commands against the jumpbox VM from inside the
+// private cluster's VNet, over an SSH session or an "az network bastion
+// tunnel" local port forward, so the AKS stack test can validate the
+// private cluster end-to-end (kubectl, DNS resolution) without needing
+// direct network access to it itself.
+package bastion
+
+import (
+	"context"
+	"fmt"
+)
+
+// Session is an open connection to the jumpbox, opened by a Dialer.
+type Session interface {
+	RunRemote(ctx context.Context, cmd string) (stdout string, err error)
+	Close() error
+}
+
+// Dialer opens a Session to the jumpbox identified by resourceID, e.g. an
+// SSH client or an "az network bastion tunnel" wrapper.
+type Dialer interface {
+	Open(ctx context.Context, jumpboxResourceID string) (Session, error)
+}
+
+// Client runs commands against a single jumpbox over a Session opened
+// once and reused across calls.
+type Client struct {
+	session Session
+}
+
+// Connect opens a Session to jumpboxResourceID via dialer and returns a
+// Client bound to it. Callers must Close the returned Client when done.
+func Connect(ctx context.Context, dialer Dialer, jumpboxResourceID string) (*Client, error) {
+	session, err := dialer.Open(ctx, jumpboxResourceID)
+	if err != nil {
+		return nil, fmt.Errorf("bastion: open session to %s: %w", jumpboxResourceID, err)
+	}
+	return &Client{session: session}, nil
+}
+
+// Close closes the underlying Session.
+func (c *Client) Close() error {
+	return c.session.Close()
+}
+
+// RunRemote runs cmd on the jumpbox and returns its stdout.
+func (c *Client) RunRemote(ctx context.Context, cmd string) (string, error) {
+	out, err := c.session.RunRemote(ctx, cmd)
+	if err != nil {
+		return "", fmt.Errorf("bastion: run %q: %w", cmd, err)
+	}
+	return out, nil
+}
+
+// CopyKubeconfig reads the kubeconfig already staged on the jumpbox at
+// remotePath (typically written there by a startup script running `az
+// aks get-credentials` against the cluster) and returns its contents.
+func (c *Client) CopyKubeconfig(ctx context.Context, remotePath string) ([]byte, error) {
+	out, err := c.RunRemote(ctx, "cat "+remotePath)
+	if err != nil {
+		return nil, fmt.Errorf("bastion: copy kubeconfig from %s: %w", remotePath, err)
+	}
+	return []byte(out), nil
+}
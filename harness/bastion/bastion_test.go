@@ -0,0 +1,68 @@
+package bastion
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSession struct {
+	outputs map[string]string
+	errs    map[string]error
+	closed  bool
+}
+
+func (f *fakeSession) RunRemote(ctx context.Context, cmd string) (string, error) {
+	return f.outputs[cmd], f.errs[cmd]
+}
+
+func (f *fakeSession) Close() error {
+	f.closed = true
+	return nil
+}
+
+type fakeDialer struct {
+	session *fakeSession
+	err     error
+}
+
+func (f fakeDialer) Open(ctx context.Context, jumpboxResourceID string) (Session, error) {
+	return f.session, f.err
+}
+
+func TestConnectPropagatesOpenError(t *testing.T) {
+	_, err := Connect(context.Background(), fakeDialer{err: errors.New("no tunnel")}, "/vms/jumpbox")
+	assert.ErrorContains(t, err, "/vms/jumpbox")
+}
+
+func TestRunRemoteReturnsSessionOutput(t *testing.T) {
+	session := &fakeSession{outputs: map[string]string{"kubectl get nodes": "node-0 Ready"}}
+	client, err := Connect(context.Background(), fakeDialer{session: session}, "/vms/jumpbox")
+	require.NoError(t, err)
+
+	out, err := client.RunRemote(context.Background(), "kubectl get nodes")
+	require.NoError(t, err)
+	assert.Equal(t, "node-0 Ready", out)
+}
+
+func TestCopyKubeconfigCatsRemotePath(t *testing.T) {
+	session := &fakeSession{outputs: map[string]string{"cat /home/jumpbox/.kube/config": "apiVersion: v1"}}
+	client, err := Connect(context.Background(), fakeDialer{session: session}, "/vms/jumpbox")
+	require.NoError(t, err)
+
+	data, err := client.CopyKubeconfig(context.Background(), "/home/jumpbox/.kube/config")
+	require.NoError(t, err)
+	assert.Equal(t, "apiVersion: v1", string(data))
+}
+
+func TestCloseClosesUnderlyingSession(t *testing.T) {
+	session := &fakeSession{}
+	client, err := Connect(context.Background(), fakeDialer{session: session}, "/vms/jumpbox")
+	require.NoError(t, err)
+
+	require.NoError(t, client.Close())
+	assert.True(t, session.closed)
+}
@@ -0,0 +1,124 @@
+// Package blueprint extends the single-cluster environment model to
+// support N AKS clusters per environment (e.g. a platform cluster plus
+// one or more workload clusters sharing a hub), as the platform moves
+// toward a fleet model. It defines the per-environment cluster topology,
+// which stack provisions each cluster, and the connectivity every
+// workload cluster is expected to have back to the platform cluster.
+package blueprint
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Role is a cluster's function within a blueprint.
+type Role string
+
+const (
+	// RolePlatform is the hub cluster hosting shared services (ingress,
+	// mesh control plane, observability) that workload clusters depend on.
+	RolePlatform Role = "platform"
+	// RoleWorkload runs application workloads and connects back to the
+	// platform cluster.
+	RoleWorkload Role = "workload"
+)
+
+// ClusterSpec is one AKS cluster in a blueprint.
+type ClusterSpec struct {
+	Name  string `yaml:"name"`
+	Role  Role   `yaml:"role"`
+	Stack string `yaml:"stack"`
+}
+
+// Blueprint is the full cluster topology for one environment.
+type Blueprint struct {
+	Env      string        `yaml:"env"`
+	Clusters []ClusterSpec `yaml:"clusters"`
+}
+
+// Load parses a blueprint file (config/envs/<env>/blueprint.yml).
+func Load(path string) (Blueprint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Blueprint{}, fmt.Errorf("blueprint: read %s: %w", path, err)
+	}
+	var bp Blueprint
+	if err := yaml.Unmarshal(data, &bp); err != nil {
+		return Blueprint{}, fmt.Errorf("blueprint: parse %s: %w", path, err)
+	}
+	return bp, nil
+}
+
+// PlatformCluster returns the blueprint's platform cluster, or false if
+// none is defined.
+func (b Blueprint) PlatformCluster() (ClusterSpec, bool) {
+	for _, c := range b.Clusters {
+		if c.Role == RolePlatform {
+			return c, true
+		}
+	}
+	return ClusterSpec{}, false
+}
+
+// WorkloadClusters returns the blueprint's workload clusters, sorted by
+// name for deterministic iteration order.
+func (b Blueprint) WorkloadClusters() []ClusterSpec {
+	var workloads []ClusterSpec
+	for _, c := range b.Clusters {
+		if c.Role == RoleWorkload {
+			workloads = append(workloads, c)
+		}
+	}
+	sort.Slice(workloads, func(i, j int) bool { return workloads[i].Name < workloads[j].Name })
+	return workloads
+}
+
+// Stacks returns every stack name a blueprint provisions, platform first
+// then workloads in name order, so an orchestrator can bring clusters up
+// in a safe order.
+func (b Blueprint) Stacks() []string {
+	var stacks []string
+	if platform, ok := b.PlatformCluster(); ok {
+		stacks = append(stacks, platform.Stack)
+	}
+	for _, w := range b.WorkloadClusters() {
+		stacks = append(stacks, w.Stack)
+	}
+	return stacks
+}
+
+// ConnectivityProbe reports whether a workload cluster can reach the
+// platform cluster (e.g. via a connectivity test pod hitting the
+// platform's internal ingress).
+type ConnectivityProbe interface {
+	CanReach(from, to ClusterSpec) (bool, error)
+}
+
+// Violation is a single blueprint validation failure.
+type Violation struct {
+	Message string
+}
+
+// VerifyConnectivity checks every workload cluster in b can reach the
+// platform cluster.
+func VerifyConnectivity(probe ConnectivityProbe, b Blueprint) ([]Violation, error) {
+	platform, ok := b.PlatformCluster()
+	if !ok {
+		return []Violation{{Message: "blueprint has no platform cluster defined"}}, nil
+	}
+
+	var violations []Violation
+	for _, workload := range b.WorkloadClusters() {
+		ok, err := probe.CanReach(workload, platform)
+		if err != nil {
+			return nil, fmt.Errorf("blueprint: probe connectivity from %s to %s: %w", workload.Name, platform.Name, err)
+		}
+		if !ok {
+			violations = append(violations, Violation{Message: fmt.Sprintf("workload cluster %q cannot reach platform cluster %q", workload.Name, platform.Name)})
+		}
+	}
+	return violations, nil
+}
@@ -0,0 +1,82 @@
+package blueprint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeBlueprint(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "blueprint.yml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+	return path
+}
+
+const sampleBlueprint = `env: prod
+clusters:
+  - name: hub
+    role: platform
+    stack: aks-cluster-hub
+  - name: payments
+    role: workload
+    stack: aks-cluster-payments
+  - name: checkout
+    role: workload
+    stack: aks-cluster-checkout
+`
+
+func TestLoadParsesClusters(t *testing.T) {
+	bp, err := Load(writeBlueprint(t, sampleBlueprint))
+	require.NoError(t, err)
+
+	platform, ok := bp.PlatformCluster()
+	require.True(t, ok)
+	assert.Equal(t, "hub", platform.Name)
+	assert.Len(t, bp.WorkloadClusters(), 2)
+}
+
+func TestStacksOrdersPlatformFirst(t *testing.T) {
+	bp, err := Load(writeBlueprint(t, sampleBlueprint))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"aks-cluster-hub", "aks-cluster-checkout", "aks-cluster-payments"}, bp.Stacks())
+}
+
+type fakeProbe struct {
+	unreachable map[string]bool
+}
+
+func (f *fakeProbe) CanReach(from, to ClusterSpec) (bool, error) {
+	return !f.unreachable[from.Name], nil
+}
+
+func TestVerifyConnectivityFlagsUnreachableWorkload(t *testing.T) {
+	bp, err := Load(writeBlueprint(t, sampleBlueprint))
+	require.NoError(t, err)
+
+	probe := &fakeProbe{unreachable: map[string]bool{"checkout": true}}
+	violations, err := VerifyConnectivity(probe, bp)
+	require.NoError(t, err)
+	assert.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "checkout")
+}
+
+func TestVerifyConnectivityPassesWhenAllReachable(t *testing.T) {
+	bp, err := Load(writeBlueprint(t, sampleBlueprint))
+	require.NoError(t, err)
+
+	violations, err := VerifyConnectivity(&fakeProbe{unreachable: map[string]bool{}}, bp)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestVerifyConnectivityFlagsMissingPlatformCluster(t *testing.T) {
+	bp := Blueprint{Env: "prod", Clusters: []ClusterSpec{{Name: "checkout", Role: RoleWorkload, Stack: "aks-cluster-checkout"}}}
+	violations, err := VerifyConnectivity(&fakeProbe{}, bp)
+	require.NoError(t, err)
+	assert.Len(t, violations, 1)
+}
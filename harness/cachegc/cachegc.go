@@ -0,0 +1,113 @@
+// Package cachegc prunes the local Terraspace build artifacts
+// (.terraspace-cache, .terraform) that accumulate on developer machines and
+// self-hosted runners across repeated local runs.
+package cachegc
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DirNames are the artifact directories considered for pruning, matched by
+// base name anywhere under Root.
+var DirNames = []string{".terraspace-cache", ".terraform"}
+
+// Options controls a single Prune run.
+type Options struct {
+	// Root is the directory tree to scan. Typically the repo root.
+	Root string
+	// OlderThan prunes only directories whose modification time is older
+	// than this duration. Zero means prune regardless of age.
+	OlderThan time.Duration
+	// DryRun reports what would be removed without deleting anything.
+	DryRun bool
+	// Now is used for age comparisons; defaults to time.Now when zero.
+	Now time.Time
+}
+
+// Result summarizes a Prune run.
+type Result struct {
+	// Removed is the list of directories removed (or that would be
+	// removed, in DryRun mode).
+	Removed []string
+	// BytesFreed is the total size of the removed directories.
+	BytesFreed int64
+}
+
+// Prune walks opts.Root looking for stale Terraspace/Terraform artifact
+// directories and removes those older than opts.OlderThan, returning a
+// report of what was (or would be) freed.
+func Prune(opts Options) (Result, error) {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	var result Result
+	err := filepath.WalkDir(opts.Root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() || !isArtifactDir(d.Name()) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if opts.OlderThan > 0 && now.Sub(info.ModTime()) < opts.OlderThan {
+			return filepath.SkipDir
+		}
+
+		size, err := dirSize(path)
+		if err != nil {
+			return err
+		}
+
+		if !opts.DryRun {
+			if err := os.RemoveAll(path); err != nil {
+				return fmt.Errorf("cachegc: remove %s: %w", path, err)
+			}
+		}
+
+		result.Removed = append(result.Removed, path)
+		result.BytesFreed += size
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func isArtifactDir(name string) bool {
+	for _, n := range DirNames {
+		if name == n {
+			return true
+		}
+	}
+	return false
+}
+
+func dirSize(root string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		total += info.Size()
+		return nil
+	})
+	return total, err
+}
@@ -0,0 +1,49 @@
+package cachegc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path string, contents string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+}
+
+func TestPruneRemovesStaleArtifactDirs(t *testing.T) {
+	root := t.TempDir()
+	stale := filepath.Join(root, "app", "stacks", "demo", ".terraspace-cache")
+	fresh := filepath.Join(root, "app", "stacks", "other", ".terraform")
+
+	writeFile(t, filepath.Join(stale, "plan.tf"), "stale contents")
+	writeFile(t, filepath.Join(fresh, "plan.tf"), "fresh contents")
+
+	old := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, os.Chtimes(stale, old, old))
+
+	result, err := Prune(Options{Root: root, OlderThan: 24 * time.Hour})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{stale}, result.Removed)
+	assert.Positive(t, result.BytesFreed)
+	assert.NoDirExists(t, stale)
+	assert.DirExists(t, fresh)
+}
+
+func TestPruneDryRunLeavesDirsInPlace(t *testing.T) {
+	root := t.TempDir()
+	stale := filepath.Join(root, ".terraspace-cache")
+	writeFile(t, filepath.Join(stale, "plan.tf"), "stale contents")
+
+	result, err := Prune(Options{Root: root, DryRun: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{stale}, result.Removed)
+	assert.DirExists(t, stale)
+}
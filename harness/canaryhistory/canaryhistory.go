@@ -0,0 +1,64 @@
+// Package canaryhistory records the outcome of each scheduled canary run
+// (deploy latest main, run scenarios, destroy) as an append-only JSON Lines
+// file, so a run of Azure-side breakage can be traced back to when it
+// started failing.
+package canaryhistory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Entry is a single canary run record.
+type Entry struct {
+	RunID      string        `json:"run_id"`
+	StartedAt  time.Time     `json:"started_at"`
+	Duration   time.Duration `json:"duration_ns"`
+	Passed     bool          `json:"passed"`
+	FailureMsg string        `json:"failure_msg,omitempty"`
+	CostUSD    float64       `json:"cost_usd"`
+}
+
+// Append writes entry to path, creating the file if needed.
+func Append(path string, entry Entry) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("canaryhistory: open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("canaryhistory: marshal entry: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("canaryhistory: write entry: %w", err)
+	}
+	return nil
+}
+
+// Read loads every recorded entry from path, oldest first. Returns an
+// empty slice if path does not exist yet.
+func Read(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("canaryhistory: read %s: %w", path, err)
+	}
+
+	var entries []Entry
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for dec.More() {
+		var e Entry
+		if err := dec.Decode(&e); err != nil {
+			return entries, fmt.Errorf("canaryhistory: decode entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
@@ -0,0 +1,29 @@
+package canaryhistory
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendAndReadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "canary.jsonl")
+
+	require.NoError(t, Append(path, Entry{RunID: "run-1", StartedAt: time.Now(), Passed: true, CostUSD: 1.5}))
+	require.NoError(t, Append(path, Entry{RunID: "run-2", StartedAt: time.Now(), Passed: false, FailureMsg: "apply failed"}))
+
+	entries, err := Read(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "run-1", entries[0].RunID)
+	assert.False(t, entries[1].Passed)
+}
+
+func TestReadReturnsEmptyForMissingFile(t *testing.T) {
+	entries, err := Read(filepath.Join(t.TempDir(), "missing.jsonl"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
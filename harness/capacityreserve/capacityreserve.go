@@ -0,0 +1,70 @@
+// Package capacityreserve verifies that node pools using capacity
+// reservations to guarantee burst capacity are actually associated with
+// their reservation group, and reports how much of the reserved capacity
+// is being used. A node pool that silently fell back to on-demand
+// capacity still pays for the unused reservation while providing none of
+// the burst guarantee it was bought for — this package exists to catch
+// that misconfiguration.
+package capacityreserve
+
+import "fmt"
+
+// PoolAssociation is one node pool's actual capacity reservation group
+// assignment, read back from the compute SDK.
+type PoolAssociation struct {
+	NodePoolName               string
+	CapacityReservationGroupID string
+}
+
+// Violation is a single node pool that isn't associated with its expected
+// capacity reservation group.
+type Violation struct {
+	Message string
+}
+
+// VerifyAssociation fails for every pool not associated with
+// expectedGroupID.
+func VerifyAssociation(pools []PoolAssociation, expectedGroupID string) []Violation {
+	var violations []Violation
+	for _, p := range pools {
+		if p.CapacityReservationGroupID != expectedGroupID {
+			violations = append(violations, Violation{Message: fmt.Sprintf(
+				"node pool %s has capacity reservation group %q, want %q", p.NodePoolName, p.CapacityReservationGroupID, expectedGroupID)})
+		}
+	}
+	return violations
+}
+
+// Utilization is how much of a capacity reservation group's reserved
+// capacity is actually allocated to running VMs.
+type Utilization struct {
+	GroupID            string
+	ReservedInstances  int
+	AllocatedInstances int
+}
+
+// UnusedInstances is the gap between what's reserved and what's actually
+// running against the reservation — capacity being paid for but not used.
+func (u Utilization) UnusedInstances() int {
+	if u.AllocatedInstances >= u.ReservedInstances {
+		return 0
+	}
+	return u.ReservedInstances - u.AllocatedInstances
+}
+
+// VerifyUtilization flags a reservation group whose allocated instance
+// count doesn't meet minUtilizationPct of its reserved capacity, since a
+// reservation group that's consistently underused is money spent on
+// capacity nobody is bursting into.
+func VerifyUtilization(u Utilization, minUtilizationPct float64) []Violation {
+	if u.ReservedInstances == 0 {
+		return nil
+	}
+	pct := float64(u.AllocatedInstances) / float64(u.ReservedInstances) * 100
+	if pct < minUtilizationPct {
+		return []Violation{{Message: fmt.Sprintf(
+			"capacity reservation group %s is %.0f%% utilized (%d/%d instances), below the %.0f%% minimum",
+			u.GroupID, pct, u.AllocatedInstances, u.ReservedInstances, minUtilizationPct)}}
+	}
+	return nil
+}
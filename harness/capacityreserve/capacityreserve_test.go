@@ -0,0 +1,67 @@
+package capacityreserve
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyAssociationPassesWhenAllPoolsAssociated(t *testing.T) {
+	pools := []PoolAssociation{
+		{NodePoolName: "burstpool", CapacityReservationGroupID: "/crg/burst"},
+	}
+	assert.Empty(t, VerifyAssociation(pools, "/crg/burst"))
+}
+
+func TestVerifyAssociationFlagsPoolMissingAssociation(t *testing.T) {
+	pools := []PoolAssociation{
+		{NodePoolName: "burstpool", CapacityReservationGroupID: ""},
+	}
+	violations := VerifyAssociation(pools, "/crg/burst")
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "burstpool")
+}
+
+func TestUnusedInstancesComputesGap(t *testing.T) {
+	u := Utilization{ReservedInstances: 10, AllocatedInstances: 4}
+	assert.Equal(t, 6, u.UnusedInstances())
+}
+
+func TestUnusedInstancesZeroWhenFullyUtilized(t *testing.T) {
+	u := Utilization{ReservedInstances: 10, AllocatedInstances: 10}
+	assert.Equal(t, 0, u.UnusedInstances())
+}
+
+func TestVerifyUtilizationFlagsUnderusedReservation(t *testing.T) {
+	u := Utilization{GroupID: "/crg/burst", ReservedInstances: 10, AllocatedInstances: 1}
+	violations := VerifyUtilization(u, 50)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "below the 50%")
+}
+
+func TestVerifyUtilizationPassesAboveMinimum(t *testing.T) {
+	u := Utilization{GroupID: "/crg/burst", ReservedInstances: 10, AllocatedInstances: 8}
+	assert.Empty(t, VerifyUtilization(u, 50))
+}
+
+func TestVerifyUtilizationSkipsGroupsWithNoReservedCapacity(t *testing.T) {
+	assert.Empty(t, VerifyUtilization(Utilization{GroupID: "/crg/empty"}, 50))
+}
+
+func TestFromCapacityReservationDerivesUtilization(t *testing.T) {
+	capacity := int64(5)
+	allocated := []*armcompute.SubResourceReadOnly{{}, {}}
+	res := armcompute.CapacityReservation{
+		SKU: &armcompute.SKU{Capacity: &capacity},
+		Properties: &armcompute.CapacityReservationProperties{
+			InstanceView: &armcompute.CapacityReservationInstanceView{
+				UtilizationInfo: &armcompute.CapacityReservationUtilization{VirtualMachinesAllocated: allocated},
+			},
+		},
+	}
+
+	u := FromCapacityReservation("/crg/burst", res)
+	assert.Equal(t, Utilization{GroupID: "/crg/burst", ReservedInstances: 5, AllocatedInstances: 2}, u)
+}
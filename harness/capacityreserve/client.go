@@ -0,0 +1,17 @@
+package capacityreserve
+
+import "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+
+// FromCapacityReservation derives Utilization from a live
+// armcompute.CapacityReservation: the SKU capacity is what's reserved, the
+// instance view's allocated VM list is what's actually running against it.
+func FromCapacityReservation(groupID string, res armcompute.CapacityReservation) Utilization {
+	u := Utilization{GroupID: groupID}
+	if res.SKU != nil && res.SKU.Capacity != nil {
+		u.ReservedInstances = int(*res.SKU.Capacity)
+	}
+	if res.Properties != nil && res.Properties.InstanceView != nil && res.Properties.InstanceView.UtilizationInfo != nil {
+		u.AllocatedInstances = len(res.Properties.InstanceView.UtilizationInfo.VirtualMachinesAllocated)
+	}
+	return u
+}
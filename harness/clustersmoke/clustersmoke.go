@@ -0,0 +1,99 @@
+// Package clustersmoke verifies a freshly deployed AKS cluster is
+// actually usable, not just that Terraform exited 0: it fetches
+// kubeconfig, then asserts every node is Ready, every kube-system pod is
+// healthy, and CoreDNS resolves a test FQDN. A cluster can apply cleanly
+// and still be unusable — a stuck CNI, a crashlooping CoreDNS, a node
+// that never joined — none of which show up in a Terraform plan.
+package clustersmoke
+
+import (
+	"context"
+	"fmt"
+)
+
+// KubeconfigFetcher retrieves admin kubeconfig for a deployed cluster,
+// e.g. via the AKS SDK's ListClusterAdminCredentials or `az aks
+// get-credentials`.
+type KubeconfigFetcher interface {
+	Fetch(ctx context.Context, resourceGroup, clusterName string) ([]byte, error)
+}
+
+// NodeStatus is a single node's observed readiness.
+type NodeStatus struct {
+	Name  string
+	Ready bool
+}
+
+// PodStatus is a single pod's observed health.
+type PodStatus struct {
+	Namespace string
+	Name      string
+	Ready     bool
+	Phase     string
+}
+
+// ClusterProbe is the subset of a client-go-backed clientset this package
+// needs, kept narrow so it can be faked without standing up a real API
+// server in tests.
+type ClusterProbe interface {
+	ListNodes(ctx context.Context) ([]NodeStatus, error)
+	ListPods(ctx context.Context, namespace string) ([]PodStatus, error)
+	ResolveInCluster(ctx context.Context, fqdn string) ([]string, error)
+}
+
+// Result records the outcome of every stage of the smoke test.
+type Result struct {
+	FetchKubeconfigErr error
+	UnreadyNodes       []string
+	UnhealthyPods      []string
+	DNSErr             error
+}
+
+// Passed reports whether kubeconfig fetched, every node was Ready, every
+// kube-system pod was healthy, and in-cluster DNS resolution succeeded.
+func (r Result) Passed() bool {
+	return r.FetchKubeconfigErr == nil && len(r.UnreadyNodes) == 0 && len(r.UnhealthyPods) == 0 && r.DNSErr == nil
+}
+
+// Run fetches kubeconfig for clusterName in resourceGroup via fetcher,
+// then runs probe's node, kube-system pod, and CoreDNS checks. It stops
+// after a failed kubeconfig fetch since none of the later checks can run
+// without it, but a failure in one later check doesn't skip the others —
+// callers want the full picture of what's unhealthy, not just the first
+// thing.
+func Run(ctx context.Context, fetcher KubeconfigFetcher, probe ClusterProbe, resourceGroup, clusterName, kubeSystemNamespace, testFQDN string) Result {
+	var result Result
+
+	if _, err := fetcher.Fetch(ctx, resourceGroup, clusterName); err != nil {
+		result.FetchKubeconfigErr = fmt.Errorf("clustersmoke: fetch kubeconfig for %s/%s: %w", resourceGroup, clusterName, err)
+		return result
+	}
+
+	nodes, err := probe.ListNodes(ctx)
+	if err != nil {
+		result.UnreadyNodes = []string{fmt.Sprintf("list nodes: %v", err)}
+	} else {
+		for _, n := range nodes {
+			if !n.Ready {
+				result.UnreadyNodes = append(result.UnreadyNodes, n.Name)
+			}
+		}
+	}
+
+	pods, err := probe.ListPods(ctx, kubeSystemNamespace)
+	if err != nil {
+		result.UnhealthyPods = []string{fmt.Sprintf("list pods in %s: %v", kubeSystemNamespace, err)}
+	} else {
+		for _, p := range pods {
+			if !p.Ready {
+				result.UnhealthyPods = append(result.UnhealthyPods, fmt.Sprintf("%s/%s (phase=%s)", p.Namespace, p.Name, p.Phase))
+			}
+		}
+	}
+
+	if _, err := probe.ResolveInCluster(ctx, testFQDN); err != nil {
+		result.DNSErr = fmt.Errorf("clustersmoke: in-cluster resolution of %s: %w", testFQDN, err)
+	}
+
+	return result
+}
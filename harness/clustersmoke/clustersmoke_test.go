@@ -0,0 +1,59 @@
+package clustersmoke
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeFetcher struct{ err error }
+
+func (f fakeFetcher) Fetch(ctx context.Context, resourceGroup, clusterName string) ([]byte, error) {
+	return []byte("kubeconfig"), f.err
+}
+
+type fakeProbe struct {
+	nodes  []NodeStatus
+	pods   []PodStatus
+	dnsErr error
+}
+
+func (f fakeProbe) ListNodes(ctx context.Context) ([]NodeStatus, error) { return f.nodes, nil }
+func (f fakeProbe) ListPods(ctx context.Context, namespace string) ([]PodStatus, error) {
+	return f.pods, nil
+}
+func (f fakeProbe) ResolveInCluster(ctx context.Context, fqdn string) ([]string, error) {
+	return []string{"10.0.0.10"}, f.dnsErr
+}
+
+func TestRunPassesWhenClusterIsHealthy(t *testing.T) {
+	probe := fakeProbe{
+		nodes: []NodeStatus{{Name: "node-0", Ready: true}},
+		pods:  []PodStatus{{Namespace: "kube-system", Name: "coredns", Ready: true, Phase: "Running"}},
+	}
+	result := Run(context.Background(), fakeFetcher{}, probe, "rg", "aks", "kube-system", "internal.example.com")
+	assert.True(t, result.Passed())
+}
+
+func TestRunStopsAfterKubeconfigFetchFailure(t *testing.T) {
+	fetchErr := errors.New("credential expired")
+	result := Run(context.Background(), fakeFetcher{err: fetchErr}, fakeProbe{}, "rg", "aks", "kube-system", "internal.example.com")
+	assert.False(t, result.Passed())
+	assert.ErrorIs(t, result.FetchKubeconfigErr, fetchErr)
+	assert.Empty(t, result.UnreadyNodes)
+}
+
+func TestRunReportsAllUnhealthyResourcesNotJustFirst(t *testing.T) {
+	probe := fakeProbe{
+		nodes:  []NodeStatus{{Name: "node-0", Ready: false}},
+		pods:   []PodStatus{{Namespace: "kube-system", Name: "coredns", Ready: false, Phase: "CrashLoopBackOff"}},
+		dnsErr: errors.New("NXDOMAIN"),
+	}
+	result := Run(context.Background(), fakeFetcher{}, probe, "rg", "aks", "kube-system", "internal.example.com")
+	assert.False(t, result.Passed())
+	assert.Len(t, result.UnreadyNodes, 1)
+	assert.Len(t, result.UnhealthyPods, 1)
+	assert.Error(t, result.DNSErr)
+}
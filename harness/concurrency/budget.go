@@ -0,0 +1,90 @@
+// Package concurrency bounds how many stack applies and SDK verifications
+// the harness runs at once, so a fully parallel `t.Parallel()` suite
+// doesn't trip ARM throttling or subscription write limits.
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Budget is a named semaphore with queuing metrics. The zero value is not
+// usable; construct with NewBudget.
+type Budget struct {
+	name string
+	sem  chan struct{}
+
+	mu      sync.Mutex
+	metrics Metrics
+}
+
+// Metrics reports how much a Budget's queue was contended over its
+// lifetime, surfaced in the harness report.
+type Metrics struct {
+	// Acquired is the number of successful Acquire calls.
+	Acquired int
+	// Queued is the number of Acquire calls that had to wait because the
+	// budget was fully in use.
+	Queued int
+	// TotalWait is the cumulative time callers spent waiting to acquire.
+	TotalWait time.Duration
+}
+
+// NewBudget creates a Budget that allows at most max concurrent holders.
+func NewBudget(name string, max int) *Budget {
+	if max < 1 {
+		max = 1
+	}
+	return &Budget{name: name, sem: make(chan struct{}, max)}
+}
+
+// Name returns the budget's name, used to label queuing metrics in the
+// report (e.g. "applies", "verifications").
+func (b *Budget) Name() string { return b.name }
+
+// Acquire blocks until a slot is free or ctx is done, recording queuing
+// metrics either way. The returned release func must be called to free the
+// slot.
+func (b *Budget) Acquire(ctx context.Context) (release func(), err error) {
+	start := time.Now()
+	select {
+	case b.sem <- struct{}{}:
+		b.record(start, false)
+		return b.releaseFunc(), nil
+	default:
+	}
+
+	select {
+	case b.sem <- struct{}{}:
+		b.record(start, true)
+		return b.releaseFunc(), nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.metrics.Queued++
+		b.metrics.TotalWait += time.Since(start)
+		b.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (b *Budget) releaseFunc() func() {
+	return func() { <-b.sem }
+}
+
+func (b *Budget) record(start time.Time, queued bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.metrics.Acquired++
+	if queued {
+		b.metrics.Queued++
+		b.metrics.TotalWait += time.Since(start)
+	}
+}
+
+// Snapshot returns a copy of the current metrics for reporting.
+func (b *Budget) Snapshot() Metrics {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.metrics
+}
@@ -0,0 +1,64 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetLimitsConcurrentHolders(t *testing.T) {
+	budget := NewBudget("applies", 2)
+	var (
+		mu      sync.Mutex
+		current int
+		peak    int
+		wg      sync.WaitGroup
+	)
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := budget.Acquire(context.Background())
+			require.NoError(t, err)
+			defer release()
+
+			mu.Lock()
+			current++
+			if current > peak {
+				peak = current
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			current--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, peak, 2)
+
+	metrics := budget.Snapshot()
+	assert.Equal(t, 5, metrics.Acquired)
+	assert.Positive(t, metrics.Queued)
+}
+
+func TestBudgetAcquireRespectsContextCancellation(t *testing.T) {
+	budget := NewBudget("verifications", 1)
+	release, err := budget.Acquire(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = budget.Acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,151 @@
+// Package conformance runs a curated subset of the CIS Kubernetes/AKS
+// benchmark against a deployed cluster's control-plane configuration and
+// produces a scored report. It is deliberately narrow: full benchmark
+// coverage belongs to kube-bench/kubescape (see harness/nodebench), this
+// package covers what's only visible from the ARM control plane.
+package conformance
+
+import "fmt"
+
+// Severity classifies how much a failed check should count against the
+// score.
+type Severity int
+
+const (
+	Low Severity = iota
+	Medium
+	High
+)
+
+// ClusterConfig is the subset of a deployed AKS cluster's configuration
+// the benchmark checks against. Callers populate it from the
+// armcontainerservice ManagedCluster returned after apply.
+type ClusterConfig struct {
+	RBACEnabled             bool
+	PrivateClusterEnabled   bool
+	LocalAccountsDisabled   bool
+	AuditLogsToLogAnalytics bool
+	APIServerAuthorizedIPs  []string
+	AzurePolicyAddonEnabled bool
+}
+
+// Check is a single benchmark item.
+type Check struct {
+	ID       string
+	Name     string
+	Severity Severity
+	Run      func(ClusterConfig) (pass bool, detail string)
+}
+
+// Result is the outcome of running one Check.
+type Result struct {
+	Check  Check
+	Pass   bool
+	Detail string
+}
+
+// Report is the outcome of running a full profile.
+type Report struct {
+	Results []Result
+	// Score is the fraction (0-100) of weighted checks that passed,
+	// weighted by Severity so a single failed High check depresses the
+	// score more than a failed Low one.
+	Score float64
+}
+
+// DefaultProfile is the curated CIS AKS benchmark subset this harness
+// checks by default.
+func DefaultProfile() []Check {
+	return []Check{
+		{
+			ID: "5.1.1", Name: "RBAC is enabled", Severity: High,
+			Run: func(c ClusterConfig) (bool, string) {
+				if c.RBACEnabled {
+					return true, ""
+				}
+				return false, "RBAC is disabled on the cluster"
+			},
+		},
+		{
+			ID: "5.2.1", Name: "Local accounts are disabled", Severity: Medium,
+			Run: func(c ClusterConfig) (bool, string) {
+				if c.LocalAccountsDisabled {
+					return true, ""
+				}
+				return false, "local Kubernetes accounts are still enabled"
+			},
+		},
+		{
+			ID: "5.3.1", Name: "Cluster API server is private or IP-restricted", Severity: High,
+			Run: func(c ClusterConfig) (bool, string) {
+				if c.PrivateClusterEnabled || len(c.APIServerAuthorizedIPs) > 0 {
+					return true, ""
+				}
+				return false, "API server has no private endpoint and no authorized IP ranges"
+			},
+		},
+		{
+			ID: "5.4.1", Name: "Audit logs flow to Log Analytics", Severity: Medium,
+			Run: func(c ClusterConfig) (bool, string) {
+				if c.AuditLogsToLogAnalytics {
+					return true, ""
+				}
+				return false, "kube-audit logs are not wired to a Log Analytics workspace"
+			},
+		},
+		{
+			ID: "5.5.1", Name: "Azure Policy add-on is enabled", Severity: Low,
+			Run: func(c ClusterConfig) (bool, string) {
+				if c.AzurePolicyAddonEnabled {
+					return true, ""
+				}
+				return false, "azurepolicy add-on is not enabled"
+			},
+		},
+	}
+}
+
+func weight(s Severity) float64 {
+	switch s {
+	case High:
+		return 3
+	case Medium:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// Run executes every check in profile against cfg and returns a scored
+// Report.
+func Run(cfg ClusterConfig, profile []Check) Report {
+	var report Report
+	var totalWeight, passedWeight float64
+
+	for _, check := range profile {
+		pass, detail := check.Run(cfg)
+		report.Results = append(report.Results, Result{Check: check, Pass: pass, Detail: detail})
+		w := weight(check.Severity)
+		totalWeight += w
+		if pass {
+			passedWeight += w
+		}
+	}
+
+	if totalWeight > 0 {
+		report.Score = (passedWeight / totalWeight) * 100
+	}
+	return report
+}
+
+// Failures returns the failed results, formatted for a test failure
+// message or PR comment.
+func (r Report) Failures() []string {
+	var out []string
+	for _, res := range r.Results {
+		if !res.Pass {
+			out = append(out, fmt.Sprintf("[%s] %s: %s", res.Check.ID, res.Check.Name, res.Detail))
+		}
+	}
+	return out
+}
@@ -0,0 +1,42 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunScoresFullyCompliantClusterAt100(t *testing.T) {
+	cfg := ClusterConfig{
+		RBACEnabled:             true,
+		PrivateClusterEnabled:   true,
+		LocalAccountsDisabled:   true,
+		AuditLogsToLogAnalytics: true,
+		AzurePolicyAddonEnabled: true,
+	}
+
+	report := Run(cfg, DefaultProfile())
+
+	assert.Equal(t, 100.0, report.Score)
+	assert.Empty(t, report.Failures())
+}
+
+func TestRunPenalizesHighSeverityFailuresMore(t *testing.T) {
+	failRBAC := Run(ClusterConfig{
+		PrivateClusterEnabled:   true,
+		LocalAccountsDisabled:   true,
+		AuditLogsToLogAnalytics: true,
+		AzurePolicyAddonEnabled: true,
+	}, DefaultProfile())
+
+	failPolicy := Run(ClusterConfig{
+		RBACEnabled:             true,
+		PrivateClusterEnabled:   true,
+		LocalAccountsDisabled:   true,
+		AuditLogsToLogAnalytics: true,
+	}, DefaultProfile())
+
+	assert.Less(t, failRBAC.Score, failPolicy.Score)
+	assert.Len(t, failRBAC.Failures(), 1)
+	assert.Contains(t, failRBAC.Failures()[0], "5.1.1")
+}
@@ -0,0 +1,118 @@
+// Package corednscheck verifies that the cluster's CoreDNS custom
+// configuration (stub domains, forwarders to the private resolver, and
+// autoscaling) matches what the addons stack sets, and probes in-cluster
+// resolution of internal corporate domains that depend on those
+// forwarders. A CoreDNS config that silently drifted from the addons
+// stack's ConfigMap — a stub domain removed, a forwarder pointed at the
+// wrong resolver IP — otherwise only surfaces as pods failing to resolve
+// internal names, long after the stack apply reported success.
+package corednscheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/clustersmoke"
+)
+
+// StubDomain is one CoreDNS stub domain: queries for Domain are forwarded
+// to Nameservers instead of the cluster's default upstream.
+type StubDomain struct {
+	Domain      string
+	Nameservers []string
+}
+
+// Config is CoreDNS's observed custom configuration, read from its
+// ConfigMap in kube-system.
+type Config struct {
+	StubDomains           []StubDomain
+	AutoscalerEnabled     bool
+	AutoscalerMinReplicas int
+}
+
+// Violation is a single item where the observed CoreDNS config, or an
+// in-cluster resolution probe, doesn't match what the addons stack sets.
+type Violation struct {
+	Message string
+}
+
+// VerifyConfig checks that got contains every stub domain expected
+// declares (with the same nameservers) and that autoscaling is enabled
+// with at least expectedMinReplicas.
+func VerifyConfig(got Config, expected Config) []Violation {
+	var violations []Violation
+
+	byDomain := make(map[string]StubDomain, len(got.StubDomains))
+	for _, d := range got.StubDomains {
+		byDomain[d.Domain] = d
+	}
+	for _, want := range expected.StubDomains {
+		have, ok := byDomain[want.Domain]
+		if !ok {
+			violations = append(violations, Violation{Message: fmt.Sprintf("CoreDNS has no stub domain for %s, want forwarders %v", want.Domain, want.Nameservers)})
+			continue
+		}
+		if !sameNameservers(have.Nameservers, want.Nameservers) {
+			violations = append(violations, Violation{Message: fmt.Sprintf("stub domain %s forwards to %v, want %v", want.Domain, have.Nameservers, want.Nameservers)})
+		}
+	}
+
+	if expected.AutoscalerEnabled && !got.AutoscalerEnabled {
+		violations = append(violations, Violation{Message: "CoreDNS autoscaling is disabled, want it enabled"})
+	}
+	if expected.AutoscalerEnabled && got.AutoscalerMinReplicas < expected.AutoscalerMinReplicas {
+		violations = append(violations, Violation{Message: fmt.Sprintf("CoreDNS autoscaler min replicas is %d, want at least %d", got.AutoscalerMinReplicas, expected.AutoscalerMinReplicas)})
+	}
+
+	return violations
+}
+
+func sameNameservers(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	seen := make(map[string]bool, len(got))
+	for _, ns := range got {
+		seen[ns] = true
+	}
+	for _, ns := range want {
+		if !seen[ns] {
+			return false
+		}
+	}
+	return true
+}
+
+// InternalProbe pairs an internal corporate domain with the private IP it
+// must resolve to via CoreDNS's forwarders.
+type InternalProbe struct {
+	FQDN      string
+	PrivateIP string
+}
+
+// VerifyInternalResolution runs each probe's FQDN through the cluster's
+// own DNS resolution (CoreDNS, via probe's ResolveInCluster) and reports a
+// Violation for any FQDN that doesn't resolve to its expected IP.
+func VerifyInternalResolution(ctx context.Context, probe clustersmoke.ClusterProbe, probes []InternalProbe) []Violation {
+	var violations []Violation
+	for _, p := range probes {
+		ips, err := probe.ResolveInCluster(ctx, p.FQDN)
+		if err != nil {
+			violations = append(violations, Violation{Message: fmt.Sprintf("resolve %s in-cluster: %v", p.FQDN, err)})
+			continue
+		}
+		if !containsIP(ips, p.PrivateIP) {
+			violations = append(violations, Violation{Message: fmt.Sprintf("resolved %s to %v in-cluster, want %s (check CoreDNS forwarder for this domain)", p.FQDN, ips, p.PrivateIP)})
+		}
+	}
+	return violations
+}
+
+func containsIP(ips []string, want string) bool {
+	for _, ip := range ips {
+		if ip == want {
+			return true
+		}
+	}
+	return false
+}
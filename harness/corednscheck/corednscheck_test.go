@@ -0,0 +1,94 @@
+package corednscheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/clustersmoke"
+)
+
+func TestVerifyConfigPassesWhenStubDomainsAndAutoscalerMatch(t *testing.T) {
+	got := Config{
+		StubDomains:           []StubDomain{{Domain: "corp.internal.", Nameservers: []string{"10.1.0.4", "10.1.0.5"}}},
+		AutoscalerEnabled:     true,
+		AutoscalerMinReplicas: 2,
+	}
+	expected := Config{
+		StubDomains:           []StubDomain{{Domain: "corp.internal.", Nameservers: []string{"10.1.0.5", "10.1.0.4"}}},
+		AutoscalerEnabled:     true,
+		AutoscalerMinReplicas: 2,
+	}
+	assert.Empty(t, VerifyConfig(got, expected))
+}
+
+func TestVerifyConfigFlagsMissingStubDomain(t *testing.T) {
+	expected := Config{StubDomains: []StubDomain{{Domain: "corp.internal.", Nameservers: []string{"10.1.0.4"}}}}
+	violations := VerifyConfig(Config{}, expected)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "corp.internal.")
+}
+
+func TestVerifyConfigFlagsDriftedForwarders(t *testing.T) {
+	got := Config{StubDomains: []StubDomain{{Domain: "corp.internal.", Nameservers: []string{"10.1.0.9"}}}}
+	expected := Config{StubDomains: []StubDomain{{Domain: "corp.internal.", Nameservers: []string{"10.1.0.4"}}}}
+	violations := VerifyConfig(got, expected)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "10.1.0.9")
+}
+
+func TestVerifyConfigFlagsDisabledAutoscaler(t *testing.T) {
+	expected := Config{AutoscalerEnabled: true, AutoscalerMinReplicas: 2}
+	violations := VerifyConfig(Config{}, expected)
+	require.Len(t, violations, 2)
+	assert.Contains(t, violations[0].Message, "autoscaling is disabled")
+}
+
+func TestVerifyConfigFlagsMinReplicasBelowExpected(t *testing.T) {
+	got := Config{AutoscalerEnabled: true, AutoscalerMinReplicas: 1}
+	expected := Config{AutoscalerEnabled: true, AutoscalerMinReplicas: 2}
+	violations := VerifyConfig(got, expected)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "min replicas")
+}
+
+type fakeProbe struct {
+	ips map[string][]string
+	err map[string]error
+}
+
+func (f fakeProbe) ListNodes(ctx context.Context) ([]clustersmoke.NodeStatus, error) { return nil, nil }
+func (f fakeProbe) ListPods(ctx context.Context, namespace string) ([]clustersmoke.PodStatus, error) {
+	return nil, nil
+}
+func (f fakeProbe) ResolveInCluster(ctx context.Context, fqdn string) ([]string, error) {
+	if err, ok := f.err[fqdn]; ok {
+		return nil, err
+	}
+	return f.ips[fqdn], nil
+}
+
+func TestVerifyInternalResolutionPassesWhenAllResolveCorrectly(t *testing.T) {
+	probe := fakeProbe{ips: map[string][]string{"payroll.corp.internal": {"10.1.2.3"}}}
+	probes := []InternalProbe{{FQDN: "payroll.corp.internal", PrivateIP: "10.1.2.3"}}
+	assert.Empty(t, VerifyInternalResolution(context.Background(), probe, probes))
+}
+
+func TestVerifyInternalResolutionFlagsWrongIP(t *testing.T) {
+	probe := fakeProbe{ips: map[string][]string{"payroll.corp.internal": {"52.1.2.3"}}}
+	probes := []InternalProbe{{FQDN: "payroll.corp.internal", PrivateIP: "10.1.2.3"}}
+	violations := VerifyInternalResolution(context.Background(), probe, probes)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "payroll.corp.internal")
+}
+
+func TestVerifyInternalResolutionFlagsResolveError(t *testing.T) {
+	probe := fakeProbe{err: map[string]error{"payroll.corp.internal": errors.New("no forwarder configured")}}
+	probes := []InternalProbe{{FQDN: "payroll.corp.internal", PrivateIP: "10.1.2.3"}}
+	violations := VerifyInternalResolution(context.Background(), probe, probes)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "no forwarder configured")
+}
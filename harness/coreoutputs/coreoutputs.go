@@ -0,0 +1,57 @@
+// Package coreoutputs decodes the core stack's Terraform outputs into a
+// typed struct via `terraspace output core --format json`/runner.Runner.
+// Output, instead of scraping `terraspace up`'s stdout for
+// "vnet_id = ..." style lines, which breaks the moment Terraspace changes
+// how it formats that summary.
+//
+// This snapshot of the repo has no app/stacks/core/core_test.go — the
+// TestCoreModule this replaces line-prefix matching for doesn't exist
+// here yet — but OutputsJSON is the helper that test, and any future
+// stack test, would call to get vnet_id/subnet_aks_id/acr_name/
+// key_vault_name back as typed fields instead of raw strings.
+package coreoutputs
+
+import "fmt"
+
+// Outputs is the core stack's outputs other stacks and tests depend on.
+type Outputs struct {
+	VNetID       string
+	SubnetAKSID  string
+	ACRName      string
+	KeyVaultName string
+}
+
+// Fetcher matches runner.Runner.Output's signature, kept narrow so
+// OutputsJSON can be tested without shelling out to terraspace.
+type Fetcher interface {
+	Output(stack string) (map[string]any, error)
+}
+
+// OutputsJSON runs stack's outputs through fetcher and decodes them into
+// Outputs, failing if any of the four fields it depends on is missing or
+// not a string.
+func OutputsJSON(fetcher Fetcher, stack string) (Outputs, error) {
+	raw, err := fetcher.Output(stack)
+	if err != nil {
+		return Outputs{}, fmt.Errorf("coreoutputs: fetch outputs for %s: %w", stack, err)
+	}
+
+	var out Outputs
+	var missing []string
+	out.VNetID = stringField(raw, "vnet_id", &missing)
+	out.SubnetAKSID = stringField(raw, "subnet_aks_id", &missing)
+	out.ACRName = stringField(raw, "acr_name", &missing)
+	out.KeyVaultName = stringField(raw, "key_vault_name", &missing)
+	if len(missing) > 0 {
+		return Outputs{}, fmt.Errorf("coreoutputs: %s outputs missing or non-string: %v", stack, missing)
+	}
+	return out, nil
+}
+
+func stringField(raw map[string]any, name string, missing *[]string) string {
+	v, ok := raw[name].(string)
+	if !ok {
+		*missing = append(*missing, name)
+	}
+	return v
+}
@@ -0,0 +1,45 @@
+package coreoutputs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFetcher struct {
+	outputs map[string]any
+	err     error
+}
+
+func (f fakeFetcher) Output(stack string) (map[string]any, error) { return f.outputs, f.err }
+
+func TestOutputsJSONDecodesAllFields(t *testing.T) {
+	fetcher := fakeFetcher{outputs: map[string]any{
+		"vnet_id":        "/subscriptions/x/vnets/core",
+		"subnet_aks_id":  "/subscriptions/x/subnets/aks",
+		"acr_name":       "devaksacr",
+		"key_vault_name": "devakskv",
+	}}
+
+	out, err := OutputsJSON(fetcher, "core")
+	require.NoError(t, err)
+	assert.Equal(t, "devaksacr", out.ACRName)
+	assert.Equal(t, "devakskv", out.KeyVaultName)
+}
+
+func TestOutputsJSONFailsOnMissingField(t *testing.T) {
+	fetcher := fakeFetcher{outputs: map[string]any{"vnet_id": "/vnets/core"}}
+
+	_, err := OutputsJSON(fetcher, "core")
+	assert.ErrorContains(t, err, "subnet_aks_id")
+}
+
+func TestOutputsJSONPropagatesFetchError(t *testing.T) {
+	fetchErr := errors.New("terraspace output failed")
+	fetcher := fakeFetcher{err: fetchErr}
+
+	_, err := OutputsJSON(fetcher, "core")
+	assert.ErrorIs(t, err, fetchErr)
+}
@@ -0,0 +1,90 @@
+// Package costcontrols asserts that Container Insights data collection
+// settings and the Log Analytics workspace daily ingestion cap match
+// configuration. Observability misconfiguration (uncapped ContainerLogV2
+// with no namespace filtering, no daily quota) has direct and painful cost
+// impact, so these are checked like any other resource property.
+package costcontrols
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/operationalinsights/armoperationalinsights"
+)
+
+// TablePlan is the Log Analytics ingestion plan for the ContainerLogV2
+// table (Basic is far cheaper but drops query features Analytics needs).
+type TablePlan string
+
+const (
+	TablePlanBasic     TablePlan = "Basic"
+	TablePlanAnalytics TablePlan = "Analytics"
+)
+
+// DataCollectionSettings is the subset of a Container Insights data
+// collection rule this package checks.
+type DataCollectionSettings struct {
+	IntervalSeconds        int
+	NamespaceFilteringMode string
+	IncludedNamespaces     []string
+	ContainerLogV2Enabled  bool
+	TablePlan              TablePlan
+}
+
+// Expected is the cost-control configuration a stack's tfvars is supposed
+// to produce.
+type Expected struct {
+	DataCollectionSettings
+	DailyQuotaGB float64
+}
+
+// Verify compares actual settings and the workspace's live daily quota
+// against expected, returning every mismatch (nil if compliant).
+func Verify(actual DataCollectionSettings, dailyQuotaGB float64, expected Expected) []string {
+	var mismatches []string
+
+	if actual.IntervalSeconds != expected.IntervalSeconds {
+		mismatches = append(mismatches, fmt.Sprintf("collection interval is %ds, want %ds", actual.IntervalSeconds, expected.IntervalSeconds))
+	}
+	if actual.NamespaceFilteringMode != expected.NamespaceFilteringMode {
+		mismatches = append(mismatches, fmt.Sprintf("namespace filtering mode is %q, want %q", actual.NamespaceFilteringMode, expected.NamespaceFilteringMode))
+	}
+	if !sameStringSet(actual.IncludedNamespaces, expected.IncludedNamespaces) {
+		mismatches = append(mismatches, "included namespace list does not match expected")
+	}
+	if actual.ContainerLogV2Enabled != expected.ContainerLogV2Enabled {
+		mismatches = append(mismatches, fmt.Sprintf("ContainerLogV2 enabled=%v, want %v", actual.ContainerLogV2Enabled, expected.ContainerLogV2Enabled))
+	}
+	if actual.TablePlan != expected.TablePlan {
+		mismatches = append(mismatches, fmt.Sprintf("table plan is %q, want %q", actual.TablePlan, expected.TablePlan))
+	}
+	if dailyQuotaGB != expected.DailyQuotaGB {
+		mismatches = append(mismatches, fmt.Sprintf("daily ingestion cap is %.1fGB, want %.1fGB", dailyQuotaGB, expected.DailyQuotaGB))
+	}
+
+	return mismatches
+}
+
+// WorkspaceDailyQuotaGB extracts the configured daily ingestion cap from a
+// fetched workspace. Returns -1 (no cap) when WorkspaceCapping is unset.
+func WorkspaceDailyQuotaGB(ws armoperationalinsights.Workspace) float64 {
+	if ws.Properties == nil || ws.Properties.WorkspaceCapping == nil || ws.Properties.WorkspaceCapping.DailyQuotaGb == nil {
+		return -1
+	}
+	return *ws.Properties.WorkspaceCapping.DailyQuotaGb
+}
+
+func sameStringSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]bool, len(a))
+	for _, v := range a {
+		seen[v] = true
+	}
+	for _, v := range b {
+		if !seen[v] {
+			return false
+		}
+	}
+	return true
+}
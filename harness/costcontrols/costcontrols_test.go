@@ -0,0 +1,49 @@
+package costcontrols
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/operationalinsights/armoperationalinsights"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyPassesForMatchingConfig(t *testing.T) {
+	settings := DataCollectionSettings{
+		IntervalSeconds:        60,
+		NamespaceFilteringMode: "Include",
+		IncludedNamespaces:     []string{"kube-system", "default"},
+		ContainerLogV2Enabled:  true,
+		TablePlan:              TablePlanBasic,
+	}
+	expected := Expected{DataCollectionSettings: settings, DailyQuotaGB: 5}
+
+	assert.Empty(t, Verify(settings, 5, expected))
+}
+
+func TestVerifyFlagsUncappedAnalyticsPlan(t *testing.T) {
+	actual := DataCollectionSettings{
+		IntervalSeconds:        60,
+		NamespaceFilteringMode: "Include",
+		IncludedNamespaces:     []string{"default"},
+		ContainerLogV2Enabled:  true,
+		TablePlan:              TablePlanAnalytics,
+	}
+	expected := Expected{
+		DataCollectionSettings: DataCollectionSettings{
+			IntervalSeconds:        60,
+			NamespaceFilteringMode: "Include",
+			IncludedNamespaces:     []string{"default"},
+			ContainerLogV2Enabled:  true,
+			TablePlan:              TablePlanBasic,
+		},
+		DailyQuotaGB: 5,
+	}
+
+	mismatches := Verify(actual, -1, expected)
+	assert.Len(t, mismatches, 2)
+}
+
+func TestWorkspaceDailyQuotaGBReturnsNegativeOneWhenUncapped(t *testing.T) {
+	ws := armoperationalinsights.Workspace{Properties: &armoperationalinsights.WorkspaceProperties{}}
+	assert.Equal(t, -1.0, WorkspaceDailyQuotaGB(ws))
+}
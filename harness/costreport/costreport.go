@@ -0,0 +1,139 @@
+// Package costreport turns a Cost Management query grouped by the
+// harness's stack/env tags into a per-stack actual-cost total, feeding the
+// scheduled canary report and doubling as a check that tag-based
+// chargeback actually works with this repo's tagging scheme: a resource
+// missing its stack tag shows up here as an "untagged" bucket instead of
+// silently vanishing from the report.
+package costreport
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/costmanagement/armcostmanagement"
+)
+
+// TagKey is the tag this harness applies to every resource it creates,
+// and the dimension the cost query should be grouped by.
+const TagKey = "stack"
+
+// Untagged is the bucket a row's cost is attributed to when TagKey has no
+// value, surfacing gaps in the tagging scheme instead of dropping the
+// cost silently.
+const Untagged = "untagged"
+
+// StackCost is the total actual cost attributed to a single stack tag
+// value over the queried period.
+type StackCost struct {
+	Stack    string
+	CostUSD  float64
+	Currency string
+}
+
+// FromQueryResult aggregates result, a Cost Management query grouped by
+// TagKey with a Cost column, into one StackCost per distinct tag value.
+// It fails if the query mixes currencies, since summing across them would
+// silently produce a meaningless total.
+func FromQueryResult(result armcostmanagement.QueryResult) ([]StackCost, error) {
+	if result.Properties == nil {
+		return nil, fmt.Errorf("costreport: query result has no properties")
+	}
+
+	tagCol, costCol, currencyCol, err := columnIndexes(result.Properties.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]float64)
+	currencies := make(map[string]string)
+	var order []string
+
+	for i, row := range result.Properties.Rows {
+		cost, ok := toFloat(row, costCol)
+		if !ok {
+			return nil, fmt.Errorf("costreport: row %d: cost column %d is not numeric", i, costCol)
+		}
+
+		stack := Untagged
+		if tagCol >= 0 {
+			if v, ok := toString(row, tagCol); ok && v != "" {
+				stack = v
+			}
+		}
+
+		currency := ""
+		if currencyCol >= 0 {
+			currency, _ = toString(row, currencyCol)
+		}
+		if existing, seen := currencies[stack]; seen && currency != "" && existing != "" && existing != currency {
+			return nil, fmt.Errorf("costreport: stack %q has mixed currencies %q and %q, refusing to sum", stack, existing, currency)
+		}
+		currencies[stack] = currency
+
+		if _, seen := totals[stack]; !seen {
+			order = append(order, stack)
+		}
+		totals[stack] += cost
+	}
+
+	report := make([]StackCost, 0, len(order))
+	for _, stack := range order {
+		report = append(report, StackCost{Stack: stack, CostUSD: totals[stack], Currency: currencies[stack]})
+	}
+	return report, nil
+}
+
+// columnIndexes locates the tag, cost, and currency columns by name.
+// tagCol is -1 if the query wasn't grouped by TagKey. costCol is required.
+func columnIndexes(columns []*armcostmanagement.QueryColumn) (tagCol, costCol, currencyCol int, err error) {
+	tagCol, costCol, currencyCol = -1, -1, -1
+	for i, c := range columns {
+		if c == nil || c.Name == nil {
+			continue
+		}
+		switch {
+		case strings.EqualFold(*c.Name, TagKey), strings.EqualFold(*c.Name, "TagValue"):
+			tagCol = i
+		case strings.EqualFold(*c.Name, "Cost"), strings.EqualFold(*c.Name, "PreTaxCost"):
+			costCol = i
+		case strings.EqualFold(*c.Name, "Currency"):
+			currencyCol = i
+		}
+	}
+	if costCol == -1 {
+		return 0, 0, 0, fmt.Errorf("costreport: query result has no Cost/PreTaxCost column")
+	}
+	return tagCol, costCol, currencyCol, nil
+}
+
+func toFloat(row []any, idx int) (float64, bool) {
+	if idx < 0 || idx >= len(row) {
+		return 0, false
+	}
+	switch v := row[idx].(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+func toString(row []any, idx int) (string, bool) {
+	if idx < 0 || idx >= len(row) {
+		return "", false
+	}
+	v, ok := row[idx].(string)
+	return v, ok
+}
+
+// Render formats a per-stack cost report as lines suitable for appending
+// to the scheduled canary report.
+func Render(report []StackCost) string {
+	var b strings.Builder
+	for _, sc := range report {
+		fmt.Fprintf(&b, "%s: %.2f %s\n", sc.Stack, sc.CostUSD, sc.Currency)
+	}
+	return b.String()
+}
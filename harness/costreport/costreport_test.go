@@ -0,0 +1,108 @@
+package costreport
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/costmanagement/armcostmanagement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+
+func columns(names ...string) []*armcostmanagement.QueryColumn {
+	cols := make([]*armcostmanagement.QueryColumn, len(names))
+	for i, n := range names {
+		cols[i] = &armcostmanagement.QueryColumn{Name: strPtr(n)}
+	}
+	return cols
+}
+
+func TestFromQueryResultAggregatesPerStack(t *testing.T) {
+	result := armcostmanagement.QueryResult{
+		Properties: &armcostmanagement.QueryProperties{
+			Columns: columns("Cost", "Currency", "stack"),
+			Rows: [][]any{
+				{12.5, "USD", "aks-dev"},
+				{7.5, "USD", "aks-dev"},
+				{40.0, "USD", "aks-staging"},
+			},
+		},
+	}
+
+	report, err := FromQueryResult(result)
+	require.NoError(t, err)
+	require.Len(t, report, 2)
+	assert.Equal(t, StackCost{Stack: "aks-dev", CostUSD: 20.0, Currency: "USD"}, report[0])
+	assert.Equal(t, StackCost{Stack: "aks-staging", CostUSD: 40.0, Currency: "USD"}, report[1])
+}
+
+func TestFromQueryResultBucketsMissingTagAsUntagged(t *testing.T) {
+	result := armcostmanagement.QueryResult{
+		Properties: &armcostmanagement.QueryProperties{
+			Columns: columns("Cost", "stack"),
+			Rows: [][]any{
+				{5.0, ""},
+			},
+		},
+	}
+
+	report, err := FromQueryResult(result)
+	require.NoError(t, err)
+	require.Len(t, report, 1)
+	assert.Equal(t, Untagged, report[0].Stack)
+}
+
+func TestFromQueryResultErrorsOnMixedCurrencies(t *testing.T) {
+	result := armcostmanagement.QueryResult{
+		Properties: &armcostmanagement.QueryProperties{
+			Columns: columns("Cost", "Currency", "stack"),
+			Rows: [][]any{
+				{10.0, "USD", "aks-dev"},
+				{5.0, "EUR", "aks-dev"},
+			},
+		},
+	}
+
+	_, err := FromQueryResult(result)
+	assert.Error(t, err)
+}
+
+func TestFromQueryResultErrorsWithoutCostColumn(t *testing.T) {
+	result := armcostmanagement.QueryResult{
+		Properties: &armcostmanagement.QueryProperties{
+			Columns: columns("stack"),
+			Rows:    [][]any{{"aks-dev"}},
+		},
+	}
+
+	_, err := FromQueryResult(result)
+	assert.Error(t, err)
+}
+
+func TestFromQueryResultTreatsAllRowsAsUntaggedWithoutTagColumn(t *testing.T) {
+	result := armcostmanagement.QueryResult{
+		Properties: &armcostmanagement.QueryProperties{
+			Columns: columns("Cost"),
+			Rows: [][]any{
+				{3.0},
+				{4.0},
+			},
+		},
+	}
+
+	report, err := FromQueryResult(result)
+	require.NoError(t, err)
+	require.Len(t, report, 1)
+	assert.Equal(t, Untagged, report[0].Stack)
+	assert.Equal(t, 7.0, report[0].CostUSD)
+}
+
+func TestRenderFormatsEachStackOnItsOwnLine(t *testing.T) {
+	out := Render([]StackCost{
+		{Stack: "aks-dev", CostUSD: 20.5, Currency: "USD"},
+		{Stack: "aks-staging", CostUSD: 40, Currency: "USD"},
+	})
+	assert.Contains(t, out, "aks-dev: 20.50 USD")
+	assert.Contains(t, out, "aks-staging: 40.00 USD")
+}
@@ -0,0 +1,82 @@
+// Package coveragereport diffs the resource types present in a stack's
+// deployed state against the resource types this repo's verification
+// helpers actually assert on, surfacing anything deployed that no check
+// would notice going wrong.
+package coveragereport
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// rawState mirrors the subset of `terraform show -json <statefile>`
+// output this package needs to enumerate resource types.
+type rawState struct {
+	Values struct {
+		RootModule rawModule `json:"root_module"`
+	} `json:"values"`
+}
+
+type rawModule struct {
+	Resources []struct {
+		Type string `json:"type"`
+	} `json:"resources"`
+	ChildModules []rawModule `json:"child_modules"`
+}
+
+// ResourceTypes returns the distinct resource types present in a
+// `terraform show -json <statefile>` document, including nested modules.
+func ResourceTypes(stateJSON []byte) ([]string, error) {
+	var state rawState
+	if err := json.Unmarshal(stateJSON, &state); err != nil {
+		return nil, fmt.Errorf("coveragereport: parse state JSON: %w", err)
+	}
+
+	seen := map[string]bool{}
+	collectTypes(state.Values.RootModule, seen)
+
+	types := make([]string, 0, len(seen))
+	for t := range seen {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types, nil
+}
+
+func collectTypes(m rawModule, seen map[string]bool) {
+	for _, r := range m.Resources {
+		seen[r.Type] = true
+	}
+	for _, child := range m.ChildModules {
+		collectTypes(child, seen)
+	}
+}
+
+// Report is the result of comparing deployed resource types against the
+// types this repo's verification helpers cover.
+type Report struct {
+	Covered   []string
+	Uncovered []string
+}
+
+// Diff returns which of deployedTypes appear in coveredTypes (in any
+// order) and which don't, both sorted.
+func Diff(deployedTypes, coveredTypes []string) Report {
+	covered := make(map[string]bool, len(coveredTypes))
+	for _, t := range coveredTypes {
+		covered[t] = true
+	}
+
+	report := Report{}
+	for _, t := range deployedTypes {
+		if covered[t] {
+			report.Covered = append(report.Covered, t)
+		} else {
+			report.Uncovered = append(report.Uncovered, t)
+		}
+	}
+	sort.Strings(report.Covered)
+	sort.Strings(report.Uncovered)
+	return report
+}
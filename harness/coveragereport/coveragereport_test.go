@@ -0,0 +1,53 @@
+package coveragereport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleState = `{
+	"values": {
+		"root_module": {
+			"resources": [
+				{"type": "azurerm_kubernetes_cluster"},
+				{"type": "azurerm_kubernetes_cluster_node_pool"}
+			],
+			"child_modules": [
+				{
+					"resources": [
+						{"type": "azurerm_log_analytics_workspace"},
+						{"type": "azurerm_kubernetes_cluster"}
+					]
+				}
+			]
+		}
+	}
+}`
+
+func TestResourceTypesDedupesAndSorts(t *testing.T) {
+	types, err := ResourceTypes([]byte(sampleState))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"azurerm_kubernetes_cluster", "azurerm_kubernetes_cluster_node_pool", "azurerm_log_analytics_workspace"}, types)
+}
+
+func TestResourceTypesReturnsErrorForInvalidJSON(t *testing.T) {
+	_, err := ResourceTypes([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestDiffSeparatesCoveredFromUncovered(t *testing.T) {
+	deployed := []string{"azurerm_kubernetes_cluster", "azurerm_log_analytics_workspace", "azurerm_cdn_frontdoor_profile"}
+	covered := []string{"azurerm_log_analytics_workspace", "azurerm_kubernetes_cluster"}
+
+	report := Diff(deployed, covered)
+	assert.Equal(t, []string{"azurerm_kubernetes_cluster", "azurerm_log_analytics_workspace"}, report.Covered)
+	assert.Equal(t, []string{"azurerm_cdn_frontdoor_profile"}, report.Uncovered)
+}
+
+func TestDiffAllUncoveredWhenNothingCovered(t *testing.T) {
+	report := Diff([]string{"azurerm_storage_account"}, nil)
+	assert.Empty(t, report.Covered)
+	assert.Equal(t, []string{"azurerm_storage_account"}, report.Uncovered)
+}
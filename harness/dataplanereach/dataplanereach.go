@@ -0,0 +1,74 @@
+// Package dataplanereach verifies private-endpointed data services
+// (SQL, Cosmos, Storage) are reachable with an authenticated AAD
+// connection through their private endpoint from inside the cluster's
+// VNet, and that their public endpoint is refused, reporting a
+// reachability matrix per service rather than trusting the private
+// endpoint resource exists.
+package dataplanereach
+
+import (
+	"context"
+	"fmt"
+)
+
+// Prober attempts an authenticated (AAD) data-plane connection to a data
+// service, typically from a probe pod already deployed in-cluster.
+type Prober interface {
+	Connect(ctx context.Context, service Service) error
+}
+
+// Service is a private-endpointed data service to probe.
+type Service struct {
+	Name            string // e.g. "billing-sql"
+	PrivateEndpoint string // FQDN or address reachable only via private endpoint
+	PublicEndpoint  string // the service's public FQDN, which must refuse the connection
+}
+
+// Outcome is the reachability result for a single service.
+type Outcome struct {
+	Service          string
+	PrivateReachable bool
+	PublicRefused    bool
+	Pass             bool
+	Detail           string
+}
+
+// VerifyReachability probes every service's private endpoint (must
+// succeed) and public endpoint (must be refused), returning a
+// reachability matrix.
+func VerifyReachability(ctx context.Context, prober Prober, services []Service) []Outcome {
+	outcomes := make([]Outcome, 0, len(services))
+	for _, svc := range services {
+		outcome := Outcome{Service: svc.Name}
+
+		privateErr := prober.Connect(ctx, Service{Name: svc.Name, PrivateEndpoint: svc.PrivateEndpoint})
+		outcome.PrivateReachable = privateErr == nil
+		if privateErr != nil {
+			outcome.Detail = fmt.Sprintf("private endpoint unreachable: %v", privateErr)
+		}
+
+		publicErr := prober.Connect(ctx, Service{Name: svc.Name, PublicEndpoint: svc.PublicEndpoint})
+		outcome.PublicRefused = publicErr != nil
+		if publicErr == nil {
+			if outcome.Detail != "" {
+				outcome.Detail += "; "
+			}
+			outcome.Detail += "public endpoint unexpectedly accepted the connection"
+		}
+
+		outcome.Pass = outcome.PrivateReachable && outcome.PublicRefused
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes
+}
+
+// Failures filters outcomes down to the services that failed either check.
+func Failures(outcomes []Outcome) []Outcome {
+	var failures []Outcome
+	for _, o := range outcomes {
+		if !o.Pass {
+			failures = append(failures, o)
+		}
+	}
+	return failures
+}
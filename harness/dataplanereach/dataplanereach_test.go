@@ -0,0 +1,69 @@
+package dataplanereach
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProber struct {
+	privateFails map[string]bool
+	publicFails  map[string]bool
+}
+
+func (f *fakeProber) Connect(ctx context.Context, service Service) error {
+	if service.PrivateEndpoint != "" {
+		if f.privateFails[service.Name] {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	if f.publicFails[service.Name] {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestVerifyReachabilityPassesWhenPrivateWorksAndPublicRefused(t *testing.T) {
+	prober := &fakeProber{publicFails: map[string]bool{"billing-sql": true}}
+	services := []Service{{Name: "billing-sql", PrivateEndpoint: "billing-sql.privatelink.database.windows.net", PublicEndpoint: "billing-sql.database.windows.net"}}
+
+	outcomes := VerifyReachability(context.Background(), prober, services)
+	require.Len(t, outcomes, 1)
+	assert.True(t, outcomes[0].Pass)
+	assert.True(t, outcomes[0].PrivateReachable)
+	assert.True(t, outcomes[0].PublicRefused)
+}
+
+func TestVerifyReachabilityFlagsUnreachablePrivateEndpoint(t *testing.T) {
+	prober := &fakeProber{privateFails: map[string]bool{"billing-sql": true}, publicFails: map[string]bool{"billing-sql": true}}
+	services := []Service{{Name: "billing-sql", PrivateEndpoint: "billing-sql.privatelink.database.windows.net", PublicEndpoint: "billing-sql.database.windows.net"}}
+
+	outcomes := VerifyReachability(context.Background(), prober, services)
+	require.Len(t, outcomes, 1)
+	assert.False(t, outcomes[0].Pass)
+	assert.False(t, outcomes[0].PrivateReachable)
+}
+
+func TestVerifyReachabilityFlagsPublicEndpointNotRefused(t *testing.T) {
+	prober := &fakeProber{}
+	services := []Service{{Name: "billing-sql", PrivateEndpoint: "billing-sql.privatelink.database.windows.net", PublicEndpoint: "billing-sql.database.windows.net"}}
+
+	outcomes := VerifyReachability(context.Background(), prober, services)
+	require.Len(t, outcomes, 1)
+	assert.False(t, outcomes[0].Pass)
+	assert.False(t, outcomes[0].PublicRefused)
+}
+
+func TestFailuresFiltersToFailedServicesOnly(t *testing.T) {
+	outcomes := []Outcome{
+		{Service: "ok-service", Pass: true},
+		{Service: "bad-service", Pass: false},
+	}
+	failures := Failures(outcomes)
+	require.Len(t, failures, 1)
+	assert.Equal(t, "bad-service", failures[0].Service)
+}
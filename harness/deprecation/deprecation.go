@@ -0,0 +1,115 @@
+// Package deprecation statically scans the module HCL for
+// resources/arguments deprecated in the pinned azurerm provider version and
+// for AKS settings slated for removal, so migrations are planned ahead of
+// the provider forcing them.
+package deprecation
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// Rule flags a deprecated resource type, or a deprecated argument within a
+// resource type when Argument is set.
+type Rule struct {
+	ResourceType string
+	Argument     string // empty means the whole resource type is deprecated
+	Message      string
+}
+
+// DefaultRules is the maintained mapping of azurerm/AKS deprecations this
+// scanner checks for. Update it as the pinned provider version advances.
+var DefaultRules = []Rule{
+	{
+		ResourceType: "azurerm_kubernetes_cluster",
+		Argument:     "enable_pod_security_policy",
+		Message:      "PodSecurityPolicy was removed from Kubernetes 1.25+; enforce Pod Security Standards via an admission policy instead",
+	},
+	{
+		ResourceType: "azurerm_kubernetes_cluster",
+		Argument:     "role_based_access_control",
+		Message:      "role_based_access_control block is deprecated in favor of the top-level role_based_access_control_enabled and azure_active_directory_role_based_access_control blocks",
+	},
+	{
+		ResourceType: "azurerm_kubernetes_cluster_node_pool",
+		Argument:     "enable_node_public_ip",
+		Message:      "enable_node_public_ip is deprecated; use node_public_ip_enabled",
+	},
+}
+
+// Finding is a single detected deprecation.
+type Finding struct {
+	File     string
+	Range    hcl.Range
+	Rule     Rule
+	Resource string // the resource address, e.g. azurerm_kubernetes_cluster.this
+}
+
+// ScanDir walks root for *.tf files and returns every deprecation finding
+// according to rules.
+func ScanDir(root string, rules []Rule) ([]Finding, error) {
+	var findings []Finding
+	parser := hclparse.NewParser()
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".tf") {
+			return nil
+		}
+
+		file, diags := parser.ParseHCLFile(path)
+		if diags.HasErrors() {
+			return fmt.Errorf("deprecation: parse %s: %s", path, diags.Error())
+		}
+
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			return nil
+		}
+		findings = append(findings, scanBody(path, body, rules)...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+func scanBody(path string, body *hclsyntax.Body, rules []Rule) []Finding {
+	var findings []Finding
+	for _, block := range body.Blocks {
+		if block.Type != "resource" || len(block.Labels) < 2 {
+			continue
+		}
+		resourceType, resourceName := block.Labels[0], block.Labels[1]
+		address := resourceType + "." + resourceName
+
+		for _, rule := range rules {
+			if rule.ResourceType != resourceType {
+				continue
+			}
+			if rule.Argument == "" {
+				findings = append(findings, Finding{File: path, Range: block.DefRange(), Rule: rule, Resource: address})
+				continue
+			}
+			if attr, ok := block.Body.Attributes[rule.Argument]; ok {
+				findings = append(findings, Finding{File: path, Range: attr.SrcRange, Rule: rule, Resource: address})
+				continue
+			}
+			for _, nested := range block.Body.Blocks {
+				if nested.Type == rule.Argument {
+					findings = append(findings, Finding{File: path, Range: nested.DefRange(), Rule: rule, Resource: address})
+				}
+			}
+		}
+	}
+	return findings
+}
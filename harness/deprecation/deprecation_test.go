@@ -0,0 +1,52 @@
+package deprecation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleTF = `
+resource "azurerm_kubernetes_cluster" "this" {
+  enable_pod_security_policy = true
+
+  role_based_access_control {
+    enabled = true
+  }
+}
+
+resource "azurerm_kubernetes_cluster_node_pool" "user" {
+  node_public_ip_enabled = true
+}
+`
+
+func TestScanDirFindsDeprecatedArgumentsAndBlocks(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(sampleTF), 0o644))
+
+	findings, err := ScanDir(dir, DefaultRules)
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+
+	var messages []string
+	for _, f := range findings {
+		messages = append(messages, f.Rule.Message)
+	}
+	assert.Contains(t, messages[0]+messages[1], "PodSecurityPolicy")
+}
+
+func TestScanDirIsCleanForCompliantModule(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`
+resource "azurerm_kubernetes_cluster_node_pool" "user" {
+  node_public_ip_enabled = true
+}
+`), 0o644))
+
+	findings, err := ScanDir(dir, DefaultRules)
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
@@ -0,0 +1,96 @@
+// Package devaccess verifies the documented developer access path into a
+// private AKS cluster — a Bastion native client tunnel, or the
+// "az aks command invoke" fallback — actually works for a non-admin AAD
+// identity with its intended RBAC, so the access story holds beyond the
+// CI service principal.
+package devaccess
+
+import (
+	"context"
+	"fmt"
+)
+
+// Identity is the non-admin AAD test identity the access path is verified
+// against.
+type Identity struct {
+	ObjectID    string
+	DisplayName string
+}
+
+// ExpectedAccess describes what Identity should and should not be able to
+// do once connected to the cluster.
+type ExpectedAccess struct {
+	AllowedNamespaces []string
+	ForbiddenVerbs    map[string][]string // namespace -> verbs that must be denied
+}
+
+// Tunnel opens a Bastion native client tunnel to the private cluster's
+// API server on behalf of an identity, returning a local address the
+// kubeconfig can target.
+type Tunnel interface {
+	Open(ctx context.Context, identity Identity) (localAddr string, closeFn func() error, err error)
+}
+
+// CommandInvoker runs `az aks command invoke` as a fallback path when no
+// tunnel is available, returning the command's combined output.
+type CommandInvoker interface {
+	Invoke(ctx context.Context, identity Identity, command string) (output string, err error)
+}
+
+// RBACProber runs kubectl-style checks against a connected cluster to
+// confirm an identity's effective RBAC matches what's expected.
+type RBACProber interface {
+	CanI(ctx context.Context, verb, namespace string) (bool, error)
+}
+
+// Violation is a single access-path or RBAC mismatch.
+type Violation struct {
+	Message string
+}
+
+// VerifyTunnel opens tunnel for identity and confirms it succeeds,
+// closing the tunnel before returning.
+func VerifyTunnel(ctx context.Context, tunnel Tunnel, identity Identity) error {
+	_, closeFn, err := tunnel.Open(ctx, identity)
+	if err != nil {
+		return fmt.Errorf("devaccess: open bastion tunnel for %s: %w", identity.DisplayName, err)
+	}
+	defer closeFn()
+	return nil
+}
+
+// VerifyCommandInvokeFallback runs a harmless read-only command through
+// the command-invoke fallback path and confirms it succeeds.
+func VerifyCommandInvokeFallback(ctx context.Context, invoker CommandInvoker, identity Identity, command string) error {
+	if _, err := invoker.Invoke(ctx, identity, command); err != nil {
+		return fmt.Errorf("devaccess: command invoke fallback for %s: %w", identity.DisplayName, err)
+	}
+	return nil
+}
+
+// VerifyRBAC checks that identity, once connected, can act in its
+// allowed namespaces and is denied the forbidden verbs.
+func VerifyRBAC(ctx context.Context, prober RBACProber, expected ExpectedAccess) ([]Violation, error) {
+	var violations []Violation
+	for _, ns := range expected.AllowedNamespaces {
+		ok, err := prober.CanI(ctx, "get", ns)
+		if err != nil {
+			return nil, fmt.Errorf("devaccess: rbac probe: %w", err)
+		}
+		if !ok {
+			violations = append(violations, Violation{Message: fmt.Sprintf("identity cannot get resources in allowed namespace %q", ns)})
+		}
+	}
+	for ns, verbs := range expected.ForbiddenVerbs {
+		for _, verb := range verbs {
+			ok, err := prober.CanI(ctx, verb, ns)
+			if err != nil {
+				return nil, fmt.Errorf("devaccess: rbac probe: %w", err)
+			}
+			if ok {
+				violations = append(violations, Violation{Message: fmt.Sprintf("identity can unexpectedly %s in namespace %q", verb, ns)})
+			}
+		}
+	}
+	return violations, nil
+}
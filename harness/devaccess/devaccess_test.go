@@ -0,0 +1,80 @@
+package devaccess
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTunnel struct {
+	err    error
+	closed bool
+}
+
+func (f *fakeTunnel) Open(ctx context.Context, identity Identity) (string, func() error, error) {
+	if f.err != nil {
+		return "", nil, f.err
+	}
+	return "127.0.0.1:6443", func() error { f.closed = true; return nil }, nil
+}
+
+type fakeInvoker struct{ err error }
+
+func (f *fakeInvoker) Invoke(ctx context.Context, identity Identity, command string) (string, error) {
+	return "", f.err
+}
+
+type fakeProber struct {
+	allowed map[string]bool // "verb/namespace" -> allowed
+}
+
+func (f *fakeProber) CanI(ctx context.Context, verb, namespace string) (bool, error) {
+	return f.allowed[verb+"/"+namespace], nil
+}
+
+func TestVerifyTunnelSucceedsAndCloses(t *testing.T) {
+	tunnel := &fakeTunnel{}
+	err := VerifyTunnel(context.Background(), tunnel, Identity{DisplayName: "dev-test-user"})
+	require.NoError(t, err)
+	assert.True(t, tunnel.closed)
+}
+
+func TestVerifyTunnelPropagatesOpenError(t *testing.T) {
+	tunnel := &fakeTunnel{err: errors.New("bastion unreachable")}
+	err := VerifyTunnel(context.Background(), tunnel, Identity{DisplayName: "dev-test-user"})
+	assert.Error(t, err)
+}
+
+func TestVerifyCommandInvokeFallback(t *testing.T) {
+	err := VerifyCommandInvokeFallback(context.Background(), &fakeInvoker{}, Identity{}, "kubectl get ns")
+	assert.NoError(t, err)
+}
+
+func TestVerifyRBACFlagsMissingAllowedAccess(t *testing.T) {
+	prober := &fakeProber{allowed: map[string]bool{}}
+	violations, err := VerifyRBAC(context.Background(), prober, ExpectedAccess{AllowedNamespaces: []string{"dev-team"}})
+	require.NoError(t, err)
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyRBACFlagsUnexpectedForbiddenAccess(t *testing.T) {
+	prober := &fakeProber{allowed: map[string]bool{"delete/kube-system": true}}
+	violations, err := VerifyRBAC(context.Background(), prober, ExpectedAccess{
+		ForbiddenVerbs: map[string][]string{"kube-system": {"delete"}},
+	})
+	require.NoError(t, err)
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyRBACPassesWhenAccessMatchesExpectations(t *testing.T) {
+	prober := &fakeProber{allowed: map[string]bool{"get/dev-team": true}}
+	violations, err := VerifyRBAC(context.Background(), prober, ExpectedAccess{
+		AllowedNamespaces: []string{"dev-team"},
+		ForbiddenVerbs:    map[string][]string{"kube-system": {"delete"}},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
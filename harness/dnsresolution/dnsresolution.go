@@ -0,0 +1,80 @@
+// Package dnsresolution verifies the AKS API server FQDN resolves to its
+// private IP from every peered VNet, catching the common failure mode
+// where the cluster's private DNS zone is linked to its own VNet but never
+// linked to the VNets peered with it.
+package dnsresolution
+
+import (
+	"context"
+	"fmt"
+)
+
+// Resolver looks up fqdn from within a peered VNet, typically by running a
+// DNS query on a probe VM already deployed there (e.g. via Azure Run
+// Command) and returns the resolved IP addresses.
+type Resolver interface {
+	Resolve(ctx context.Context, probeVMResourceID, fqdn string) ([]string, error)
+}
+
+// PeeredVNet identifies a VM to probe DNS resolution from within a
+// specific peered VNet.
+type PeeredVNet struct {
+	Name              string
+	ProbeVMResourceID string
+}
+
+// ExpectedConfig is what a compliant private DNS setup must resolve to.
+type ExpectedConfig struct {
+	FQDN      string
+	PrivateIP string
+}
+
+// Result is the outcome of probing a single peered VNet.
+type Result struct {
+	VNet     string
+	Resolved []string
+	Pass     bool
+	Detail   string
+}
+
+// VerifyResolution probes every vnet via resolver and checks the API
+// server FQDN resolves to expected.PrivateIP from each one.
+func VerifyResolution(ctx context.Context, resolver Resolver, vnets []PeeredVNet, expected ExpectedConfig) []Result {
+	results := make([]Result, 0, len(vnets))
+	for _, v := range vnets {
+		ips, err := resolver.Resolve(ctx, v.ProbeVMResourceID, expected.FQDN)
+		if err != nil {
+			results = append(results, Result{VNet: v.Name, Pass: false, Detail: fmt.Sprintf("resolve %s: %v", expected.FQDN, err)})
+			continue
+		}
+		if !containsIP(ips, expected.PrivateIP) {
+			results = append(results, Result{
+				VNet: v.Name, Resolved: ips, Pass: false,
+				Detail: fmt.Sprintf("resolved %s to %v from %s, expected %s (private DNS zone may not be linked to this VNet)", expected.FQDN, ips, v.Name, expected.PrivateIP),
+			})
+			continue
+		}
+		results = append(results, Result{VNet: v.Name, Resolved: ips, Pass: true})
+	}
+	return results
+}
+
+// Failures filters results down to the failed probes.
+func Failures(results []Result) []Result {
+	var failures []Result
+	for _, r := range results {
+		if !r.Pass {
+			failures = append(failures, r)
+		}
+	}
+	return failures
+}
+
+func containsIP(ips []string, want string) bool {
+	for _, ip := range ips {
+		if ip == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package dnsresolution
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeResolver struct {
+	byVM map[string][]string
+	err  map[string]error
+}
+
+func (f *fakeResolver) Resolve(ctx context.Context, probeVMResourceID, fqdn string) ([]string, error) {
+	if err, ok := f.err[probeVMResourceID]; ok {
+		return nil, err
+	}
+	return f.byVM[probeVMResourceID], nil
+}
+
+func TestVerifyResolutionPassesWhenAllVNetsResolveCorrectly(t *testing.T) {
+	resolver := &fakeResolver{byVM: map[string][]string{
+		"vm-a": {"10.0.1.5"},
+		"vm-b": {"10.0.1.5"},
+	}}
+	vnets := []PeeredVNet{{Name: "hub", ProbeVMResourceID: "vm-a"}, {Name: "spoke", ProbeVMResourceID: "vm-b"}}
+	expected := ExpectedConfig{FQDN: "cluster.privatelink.eastus2.azmk8s.io", PrivateIP: "10.0.1.5"}
+
+	results := VerifyResolution(context.Background(), resolver, vnets, expected)
+	assert.Empty(t, Failures(results))
+}
+
+func TestVerifyResolutionFlagsVNetMissingDNSLink(t *testing.T) {
+	resolver := &fakeResolver{byVM: map[string][]string{
+		"vm-a": {"10.0.1.5"},
+		"vm-b": {"52.1.2.3"}, // resolved publicly: zone not linked to this VNet
+	}}
+	vnets := []PeeredVNet{{Name: "hub", ProbeVMResourceID: "vm-a"}, {Name: "spoke", ProbeVMResourceID: "vm-b"}}
+	expected := ExpectedConfig{FQDN: "cluster.privatelink.eastus2.azmk8s.io", PrivateIP: "10.0.1.5"}
+
+	failures := Failures(VerifyResolution(context.Background(), resolver, vnets, expected))
+	assert.Len(t, failures, 1)
+	assert.Equal(t, "spoke", failures[0].VNet)
+}
+
+func TestVerifyResolutionFlagsResolveError(t *testing.T) {
+	resolver := &fakeResolver{err: map[string]error{"vm-a": errors.New("run command timed out")}}
+	vnets := []PeeredVNet{{Name: "hub", ProbeVMResourceID: "vm-a"}}
+
+	failures := Failures(VerifyResolution(context.Background(), resolver, vnets, ExpectedConfig{FQDN: "x", PrivateIP: "10.0.0.1"}))
+	assert.Len(t, failures, 1)
+	assert.Contains(t, failures[0].Detail, "timed out")
+}
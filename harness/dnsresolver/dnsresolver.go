@@ -0,0 +1,147 @@
+// Package dnsresolver verifies an Azure DNS Private Resolver deployed in
+// the hub: its inbound/outbound endpoints landed in the subnets the
+// design calls for, its forwarding ruleset routes each domain to the
+// right destination IPs, and the ruleset is actually linked to the VNets
+// that need it. A resolver with the wrong endpoint subnet, a stale
+// forwarding rule, or a missing VNet link fails silently — clients simply
+// fall back to public DNS or NXDOMAIN — so these are asserted directly
+// rather than inferred from whether resolution happens to work.
+//
+// The end-to-end resolution probe through the resolver reuses
+// harness/dnsresolution's Resolver interface and VerifyResolution: probing
+// through a DNS Private Resolver endpoint and probing through a VNet-peered
+// private DNS zone link are the same "resolve this FQDN from here" check.
+package dnsresolver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/dnsresolution"
+)
+
+// EndpointType distinguishes a DNS Private Resolver's inbound endpoint
+// (receives on-prem/VNet queries) from its outbound endpoint (forwards
+// via a ruleset).
+type EndpointType string
+
+const (
+	Inbound  EndpointType = "inbound"
+	Outbound EndpointType = "outbound"
+)
+
+// Endpoint is one resolver endpoint's actual subnet placement.
+type Endpoint struct {
+	Name     string
+	Type     EndpointType
+	SubnetID string
+}
+
+// Violation is a single resolver configuration item that doesn't match
+// the design.
+type Violation struct {
+	Message string
+}
+
+// VerifyEndpoints checks that endpoints contains an inbound endpoint in
+// expectedInboundSubnetID and an outbound endpoint in
+// expectedOutboundSubnetID. Only the first endpoint of each type is
+// checked; further endpoints of that type are not counted or checked, and
+// an empty expected subnet ID skips the check for that type.
+func VerifyEndpoints(endpoints []Endpoint, expectedInboundSubnetID, expectedOutboundSubnetID string) []Violation {
+	var violations []Violation
+	violations = append(violations, verifyEndpointSubnet(endpoints, Inbound, expectedInboundSubnetID)...)
+	violations = append(violations, verifyEndpointSubnet(endpoints, Outbound, expectedOutboundSubnetID)...)
+	return violations
+}
+
+func verifyEndpointSubnet(endpoints []Endpoint, want EndpointType, expectedSubnetID string) []Violation {
+	if expectedSubnetID == "" {
+		return nil
+	}
+	for _, e := range endpoints {
+		if e.Type == want {
+			if e.SubnetID != expectedSubnetID {
+				return []Violation{{Message: fmt.Sprintf(
+					"%s endpoint %s is in subnet %s, want %s", want, e.Name, e.SubnetID, expectedSubnetID)}}
+			}
+			return nil
+		}
+	}
+	return []Violation{{Message: fmt.Sprintf("no %s endpoint found, want one in subnet %s", want, expectedSubnetID)}}
+}
+
+// ForwardingRule is one domain-name rule in a DNS forwarding ruleset.
+type ForwardingRule struct {
+	DomainName string
+	TargetIPs  []string
+}
+
+// VerifyForwardingRuleset checks that rules routes every domain in
+// expected to exactly the target IPs expected specifies. A domain missing
+// from rules, or present with different target IPs, is a violation.
+func VerifyForwardingRuleset(rules []ForwardingRule, expected map[string][]string) []Violation {
+	byDomain := make(map[string]ForwardingRule, len(rules))
+	for _, r := range rules {
+		byDomain[r.DomainName] = r
+	}
+
+	var violations []Violation
+	for domain, wantIPs := range expected {
+		rule, ok := byDomain[domain]
+		if !ok {
+			violations = append(violations, Violation{Message: fmt.Sprintf("forwarding ruleset has no rule for domain %s, want target IPs %v", domain, wantIPs)})
+			continue
+		}
+		if !sameIPs(rule.TargetIPs, wantIPs) {
+			violations = append(violations, Violation{Message: fmt.Sprintf("domain %s forwards to %v, want %v", domain, rule.TargetIPs, wantIPs)})
+		}
+	}
+	return violations
+}
+
+func sameIPs(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	seen := make(map[string]bool, len(got))
+	for _, ip := range got {
+		seen[ip] = true
+	}
+	for _, ip := range want {
+		if !seen[ip] {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifyVNetLinks checks that every VNet ID in expectedVNetIDs appears in
+// linkedVNetIDs — the forwarding ruleset must be linked to every VNet
+// that needs to use it, not just the hub's own VNet.
+func VerifyVNetLinks(linkedVNetIDs, expectedVNetIDs []string) []Violation {
+	linked := make(map[string]bool, len(linkedVNetIDs))
+	for _, id := range linkedVNetIDs {
+		linked[id] = true
+	}
+
+	var violations []Violation
+	for _, id := range expectedVNetIDs {
+		if !linked[id] {
+			violations = append(violations, Violation{Message: fmt.Sprintf("forwarding ruleset is not linked to VNet %s", id)})
+		}
+	}
+	return violations
+}
+
+// VerifyProbe resolves fqdn through resolver from probeResourceID and
+// reports a Violation unless the result includes expectedIP.
+func VerifyProbe(ctx context.Context, resolver dnsresolution.Resolver, probeResourceID, fqdn, expectedIP string) []Violation {
+	results := dnsresolution.VerifyResolution(ctx, resolver, []dnsresolution.PeeredVNet{{Name: probeResourceID, ProbeVMResourceID: probeResourceID}}, dnsresolution.ExpectedConfig{FQDN: fqdn, PrivateIP: expectedIP})
+
+	var violations []Violation
+	for _, r := range dnsresolution.Failures(results) {
+		violations = append(violations, Violation{Message: r.Detail})
+	}
+	return violations
+}
@@ -0,0 +1,79 @@
+package dnsresolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyEndpointsPassesWhenBothInExpectedSubnets(t *testing.T) {
+	endpoints := []Endpoint{
+		{Name: "in", Type: Inbound, SubnetID: "/subnets/resolver-in"},
+		{Name: "out", Type: Outbound, SubnetID: "/subnets/resolver-out"},
+	}
+	assert.Empty(t, VerifyEndpoints(endpoints, "/subnets/resolver-in", "/subnets/resolver-out"))
+}
+
+func TestVerifyEndpointsFlagsWrongSubnet(t *testing.T) {
+	endpoints := []Endpoint{{Name: "in", Type: Inbound, SubnetID: "/subnets/wrong"}}
+	violations := VerifyEndpoints(endpoints, "/subnets/resolver-in", "")
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "in")
+}
+
+func TestVerifyEndpointsFlagsMissingEndpoint(t *testing.T) {
+	violations := VerifyEndpoints(nil, "/subnets/resolver-in", "/subnets/resolver-out")
+	assert.Len(t, violations, 2)
+}
+
+func TestVerifyForwardingRulesetPassesWhenTargetsMatch(t *testing.T) {
+	rules := []ForwardingRule{{DomainName: "corp.internal.", TargetIPs: []string{"10.1.0.4", "10.1.0.5"}}}
+	expected := map[string][]string{"corp.internal.": {"10.1.0.5", "10.1.0.4"}}
+	assert.Empty(t, VerifyForwardingRuleset(rules, expected))
+}
+
+func TestVerifyForwardingRulesetFlagsMissingDomain(t *testing.T) {
+	violations := VerifyForwardingRuleset(nil, map[string][]string{"corp.internal.": {"10.1.0.4"}})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "corp.internal.")
+}
+
+func TestVerifyForwardingRulesetFlagsDriftedTargetIPs(t *testing.T) {
+	rules := []ForwardingRule{{DomainName: "corp.internal.", TargetIPs: []string{"10.1.0.9"}}}
+	violations := VerifyForwardingRuleset(rules, map[string][]string{"corp.internal.": {"10.1.0.4"}})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "10.1.0.9")
+}
+
+func TestVerifyVNetLinksFlagsMissingLink(t *testing.T) {
+	violations := VerifyVNetLinks([]string{"/vnets/hub"}, []string{"/vnets/hub", "/vnets/spoke"})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "/vnets/spoke")
+}
+
+func TestVerifyVNetLinksPassesWhenAllLinked(t *testing.T) {
+	assert.Empty(t, VerifyVNetLinks([]string{"/vnets/hub", "/vnets/spoke"}, []string{"/vnets/hub", "/vnets/spoke"}))
+}
+
+type fakeResolver struct {
+	ips []string
+	err error
+}
+
+func (f fakeResolver) Resolve(ctx context.Context, probeVMResourceID, fqdn string) ([]string, error) {
+	return f.ips, f.err
+}
+
+func TestVerifyProbePassesWhenExpectedIPResolves(t *testing.T) {
+	resolver := fakeResolver{ips: []string{"10.2.0.10"}}
+	assert.Empty(t, VerifyProbe(context.Background(), resolver, "/vms/probe", "aks.example.com", "10.2.0.10"))
+}
+
+func TestVerifyProbeFlagsUnexpectedResolution(t *testing.T) {
+	resolver := fakeResolver{ips: []string{"20.1.2.3"}}
+	violations := VerifyProbe(context.Background(), resolver, "/vms/probe", "aks.example.com", "10.2.0.10")
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "aks.example.com")
+}
@@ -0,0 +1,77 @@
+package driftwatch
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/eventgrid/aznamespaces"
+)
+
+// Receiver pulls and acknowledges events from an Event Grid namespace
+// topic subscription, matching *aznamespaces.ReceiverClient's method set.
+type Receiver interface {
+	ReceiveEvents(ctx context.Context, options *aznamespaces.ReceiveEventsOptions) (aznamespaces.ReceiveEventsResponse, error)
+	AcknowledgeEvents(ctx context.Context, lockTokens []string, options *aznamespaces.AcknowledgeEventsOptions) (aznamespaces.AcknowledgeEventsResponse, error)
+}
+
+// Poller drains a topic subscription on an interval, dispatching every
+// received event through Handle and acknowledging it once handled —
+// including when Handle skipped it as unwatched — so it isn't
+// redelivered.
+type Poller struct {
+	Receiver     Receiver
+	WatchedTypes []string
+	PollInterval time.Duration
+}
+
+// Run polls until ctx is cancelled, returning ctx.Err(). Errors from an
+// individual RunDriftCheck are reported via onError rather than stopping
+// the loop, so one bad drift check doesn't take down the watcher.
+func (p Poller) Run(ctx context.Context, trigger Trigger, onError func(error)) error {
+	interval := p.PollInterval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.pollOnce(ctx, trigger, onError); err != nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p Poller) pollOnce(ctx context.Context, trigger Trigger, onError func(error)) error {
+	resp, err := p.Receiver.ReceiveEvents(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	var lockTokens []string
+	for _, detail := range resp.Details {
+		event := Event{Type: detail.Event.Type}
+		if detail.Event.Subject != nil {
+			event.Subject = *detail.Event.Subject
+		}
+		if err := Handle(ctx, trigger, event, p.WatchedTypes); err != nil {
+			onError(err)
+		}
+		if detail.BrokerProperties != nil && detail.BrokerProperties.LockToken != nil {
+			lockTokens = append(lockTokens, *detail.BrokerProperties.LockToken)
+		}
+	}
+
+	if len(lockTokens) == 0 {
+		return nil
+	}
+	_, err = p.Receiver.AcknowledgeEvents(ctx, lockTokens, nil)
+	return err
+}
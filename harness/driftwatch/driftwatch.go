@@ -0,0 +1,85 @@
+// Package driftwatch closes the loop between manual portal edits and this
+// repo's Terraform source of truth: it watches for Azure Resource Manager
+// change events on the managed resource groups and, for events matching
+// a watched resource type, triggers a drift-detection run against the
+// resource group the change happened in.
+//
+// This deliberately doesn't own how the drift-detection run itself works
+// — that's harness/runner (a plan) plus harness/tsoutput (did the plan
+// come back clean) composed by the caller behind the Trigger interface —
+// driftwatch only owns deciding which incoming events are worth reacting
+// to and extracting the resource group to act on.
+package driftwatch
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Trigger runs a drift-detection pass against resourceGroup, e.g. a
+// `terraspace plan` parsed with harness/tsoutput to confirm it comes back
+// clean.
+type Trigger interface {
+	RunDriftCheck(ctx context.Context, resourceGroup string) error
+}
+
+// resourceGroupPattern matches the resource group segment of an ARM
+// resource ID, e.g. "/subscriptions/.../resourceGroups/rg-aks-prod/...".
+var resourceGroupPattern = regexp.MustCompile(`(?i)/resourcegroups/([^/]+)`)
+
+// ResourceGroup extracts the resource group name from an Event Grid
+// event's Subject, which for Azure Resource Manager change events is the
+// full ARM resource ID of the resource that changed.
+func ResourceGroup(subject string) (string, error) {
+	matches := resourceGroupPattern.FindStringSubmatch(subject)
+	if matches == nil {
+		return "", fmt.Errorf("driftwatch: subject %q does not contain a resource group", subject)
+	}
+	return matches[1], nil
+}
+
+// Watched reports whether eventType is one of watchedTypes. Azure
+// Resource Manager change events use types like
+// "Microsoft.Resources.ResourceWriteSuccess" and
+// "Microsoft.Resources.ResourceDeleteSuccess".
+func Watched(eventType string, watchedTypes []string) bool {
+	for _, t := range watchedTypes {
+		if strings.EqualFold(eventType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultWatchedTypes are the Resource Manager event types that indicate
+// an out-of-band change worth checking for drift.
+var DefaultWatchedTypes = []string{
+	"Microsoft.Resources.ResourceWriteSuccess",
+	"Microsoft.Resources.ResourceDeleteSuccess",
+	"Microsoft.Resources.ResourceActionSuccess",
+}
+
+// Event is the subset of an Event Grid CloudEvent driftwatch acts on.
+type Event struct {
+	Type    string
+	Subject string
+}
+
+// Handle runs trigger's drift check if event's type is in watchedTypes and
+// its subject resolves to a resource group; events that don't match
+// either condition are silently ignored, not errors.
+func Handle(ctx context.Context, trigger Trigger, event Event, watchedTypes []string) error {
+	if !Watched(event.Type, watchedTypes) {
+		return nil
+	}
+	rg, err := ResourceGroup(event.Subject)
+	if err != nil {
+		return nil
+	}
+	if err := trigger.RunDriftCheck(ctx, rg); err != nil {
+		return fmt.Errorf("driftwatch: drift check for %s: %w", rg, err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package driftwatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTrigger struct {
+	checkedRG []string
+	err       error
+}
+
+func (f *fakeTrigger) RunDriftCheck(ctx context.Context, resourceGroup string) error {
+	f.checkedRG = append(f.checkedRG, resourceGroup)
+	return f.err
+}
+
+func TestResourceGroupExtractsFromARMID(t *testing.T) {
+	rg, err := ResourceGroup("/subscriptions/sub-1/resourceGroups/rg-aks-prod/providers/Microsoft.Network/virtualNetworks/vnet-hub")
+	require.NoError(t, err)
+	assert.Equal(t, "rg-aks-prod", rg)
+}
+
+func TestResourceGroupErrorsWithoutResourceGroupSegment(t *testing.T) {
+	_, err := ResourceGroup("/subscriptions/sub-1/providers/Microsoft.Resources/subscriptions")
+	assert.Error(t, err)
+}
+
+func TestWatchedIsCaseInsensitive(t *testing.T) {
+	assert.True(t, Watched("microsoft.resources.resourcewritesuccess", DefaultWatchedTypes))
+	assert.False(t, Watched("Microsoft.Resources.ResourceReadSuccess", DefaultWatchedTypes))
+}
+
+func TestHandleTriggersDriftCheckForWatchedEvent(t *testing.T) {
+	trigger := &fakeTrigger{}
+	event := Event{Type: "Microsoft.Resources.ResourceWriteSuccess", Subject: "/subscriptions/sub-1/resourceGroups/rg-aks-prod/providers/Microsoft.Network/virtualNetworks/vnet-hub"}
+
+	err := Handle(context.Background(), trigger, event, DefaultWatchedTypes)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rg-aks-prod"}, trigger.checkedRG)
+}
+
+func TestHandleIgnoresUnwatchedEventType(t *testing.T) {
+	trigger := &fakeTrigger{}
+	event := Event{Type: "Microsoft.Resources.ResourceReadSuccess", Subject: "/subscriptions/sub-1/resourceGroups/rg-aks-prod/providers/Microsoft.Network/virtualNetworks/vnet-hub"}
+
+	err := Handle(context.Background(), trigger, event, DefaultWatchedTypes)
+	require.NoError(t, err)
+	assert.Empty(t, trigger.checkedRG)
+}
+
+func TestHandleIgnoresEventWithoutResourceGroup(t *testing.T) {
+	trigger := &fakeTrigger{}
+	event := Event{Type: "Microsoft.Resources.ResourceWriteSuccess", Subject: "/subscriptions/sub-1/providers/Microsoft.Resources/subscriptions"}
+
+	err := Handle(context.Background(), trigger, event, DefaultWatchedTypes)
+	require.NoError(t, err)
+	assert.Empty(t, trigger.checkedRG)
+}
+
+func TestHandlePropagatesTriggerError(t *testing.T) {
+	trigger := &fakeTrigger{err: assert.AnError}
+	event := Event{Type: "Microsoft.Resources.ResourceWriteSuccess", Subject: "/subscriptions/sub-1/resourceGroups/rg-aks-prod/providers/Microsoft.Network/virtualNetworks/vnet-hub"}
+
+	err := Handle(context.Background(), trigger, event, DefaultWatchedTypes)
+	assert.Error(t, err)
+}
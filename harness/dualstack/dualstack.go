@@ -0,0 +1,90 @@
+// Package dualstack checks IPv6/dual-stack networking configuration when
+// it's enabled for an environment: the VNet address space and AKS network
+// profile carry the expected IPv6 ranges, and a pod is actually reachable
+// over IPv6 internally, not just configured to look dual-stack on paper.
+// Every check here is gated behind FeatureFlagEnv, matching
+// harness/anfverify's pattern for an opt-in capability most environments
+// don't use.
+package dualstack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v5"
+)
+
+// FeatureFlagEnv gates this package's checks behind an explicit opt-in set
+// by the dual-stack tfvars flag, since most environments are IPv4-only.
+const FeatureFlagEnv = "ENABLE_DUAL_STACK"
+
+// Enabled reports whether dual-stack verification should run, based on
+// getenv (pass os.Getenv in production).
+func Enabled(getenv func(string) string) bool {
+	return getenv(FeatureFlagEnv) == "true"
+}
+
+// Violation is a single dual-stack configuration mismatch.
+type Violation struct {
+	Message string
+}
+
+// VerifyNetworkProfile fails unless the AKS network profile carries both
+// IPv4 and IPv6 in IPFamilies, with a matching pod and service CIDR for
+// each family.
+func VerifyNetworkProfile(profile armcontainerservice.NetworkProfile) []Violation {
+	var violations []Violation
+
+	if !hasFamily(profile.IPFamilies, armcontainerservice.IPFamilyIPv4) {
+		violations = append(violations, Violation{Message: "network profile is missing IPv4 in ip_families"})
+	}
+	if !hasFamily(profile.IPFamilies, armcontainerservice.IPFamilyIPv6) {
+		violations = append(violations, Violation{Message: "network profile is missing IPv6 in ip_families"})
+	}
+	if len(profile.PodCidrs) < 2 {
+		violations = append(violations, Violation{Message: fmt.Sprintf(
+			"network profile has %d pod CIDR(s), want one per IP family (2) for dual-stack", len(profile.PodCidrs))})
+	}
+	if len(profile.ServiceCidrs) < 2 {
+		violations = append(violations, Violation{Message: fmt.Sprintf(
+			"network profile has %d service CIDR(s), want one per IP family (2) for dual-stack", len(profile.ServiceCidrs))})
+	}
+	return violations
+}
+
+// VerifyVNetAddressSpace fails unless the VNet's address space includes at
+// least one IPv6 CIDR.
+func VerifyVNetAddressSpace(addressSpace []string) []Violation {
+	for _, cidr := range addressSpace {
+		if strings.Contains(cidr, ":") {
+			return nil
+		}
+	}
+	return []Violation{{Message: "VNet address space has no IPv6 CIDR"}}
+}
+
+func hasFamily(families []*armcontainerservice.IPFamily, want armcontainerservice.IPFamily) bool {
+	for _, f := range families {
+		if f != nil && *f == want {
+			return true
+		}
+	}
+	return false
+}
+
+// IPv6Prober reaches a pod over its IPv6 address from inside the cluster's
+// network, proving IPv6 connectivity works end to end rather than just
+// being configured.
+type IPv6Prober interface {
+	ReachOverIPv6(ctx context.Context, podIPv6Addr string) error
+}
+
+// VerifyPodReachableOverIPv6 fails if the pod at podIPv6Addr cannot be
+// reached over IPv6.
+func VerifyPodReachableOverIPv6(ctx context.Context, prober IPv6Prober, podIPv6Addr string) []Violation {
+	if err := prober.ReachOverIPv6(ctx, podIPv6Addr); err != nil {
+		return []Violation{{Message: fmt.Sprintf("pod at %s is not reachable over IPv6: %v", podIPv6Addr, err)}}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package dualstack
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func familyPtr(f armcontainerservice.IPFamily) *armcontainerservice.IPFamily { return &f }
+
+func TestEnabledReflectsFeatureFlag(t *testing.T) {
+	assert.True(t, Enabled(func(string) string { return "true" }))
+	assert.False(t, Enabled(func(string) string { return "" }))
+}
+
+func TestVerifyNetworkProfilePassesWhenFullyDualStack(t *testing.T) {
+	profile := armcontainerservice.NetworkProfile{
+		IPFamilies:   []*armcontainerservice.IPFamily{familyPtr(armcontainerservice.IPFamilyIPv4), familyPtr(armcontainerservice.IPFamilyIPv6)},
+		PodCidrs:     []*string{ptr("10.244.0.0/16"), ptr("fd00:10:244::/64")},
+		ServiceCidrs: []*string{ptr("10.0.0.0/16"), ptr("fd00:10:0::/108")},
+	}
+	assert.Empty(t, VerifyNetworkProfile(profile))
+}
+
+func TestVerifyNetworkProfileFlagsMissingIPv6Family(t *testing.T) {
+	profile := armcontainerservice.NetworkProfile{
+		IPFamilies:   []*armcontainerservice.IPFamily{familyPtr(armcontainerservice.IPFamilyIPv4)},
+		PodCidrs:     []*string{ptr("10.244.0.0/16"), ptr("fd00:10:244::/64")},
+		ServiceCidrs: []*string{ptr("10.0.0.0/16"), ptr("fd00:10:0::/108")},
+	}
+	violations := VerifyNetworkProfile(profile)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "missing IPv6")
+}
+
+func TestVerifyNetworkProfileFlagsSingleStackCidrs(t *testing.T) {
+	profile := armcontainerservice.NetworkProfile{
+		IPFamilies:   []*armcontainerservice.IPFamily{familyPtr(armcontainerservice.IPFamilyIPv4), familyPtr(armcontainerservice.IPFamilyIPv6)},
+		PodCidrs:     []*string{ptr("10.244.0.0/16")},
+		ServiceCidrs: []*string{ptr("10.0.0.0/16")},
+	}
+	violations := VerifyNetworkProfile(profile)
+	require.Len(t, violations, 2)
+}
+
+func TestVerifyVNetAddressSpacePassesWithIPv6CIDR(t *testing.T) {
+	assert.Empty(t, VerifyVNetAddressSpace([]string{"10.0.0.0/16", "fd00:10:0::/56"}))
+}
+
+func TestVerifyVNetAddressSpaceFlagsMissingIPv6CIDR(t *testing.T) {
+	violations := VerifyVNetAddressSpace([]string{"10.0.0.0/16"})
+	require.Len(t, violations, 1)
+}
+
+type fakeProber struct{ err error }
+
+func (f fakeProber) ReachOverIPv6(ctx context.Context, addr string) error { return f.err }
+
+func TestVerifyPodReachableOverIPv6PassesWhenReachable(t *testing.T) {
+	assert.Empty(t, VerifyPodReachableOverIPv6(context.Background(), fakeProber{}, "fd00::1"))
+}
+
+func TestVerifyPodReachableOverIPv6FlagsUnreachablePod(t *testing.T) {
+	violations := VerifyPodReachableOverIPv6(context.Background(), fakeProber{err: errors.New("timeout")}, "fd00::1")
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "not reachable")
+}
+
+func ptr(s string) *string { return &s }
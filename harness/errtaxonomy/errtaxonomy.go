@@ -0,0 +1,109 @@
+// Package errtaxonomy defines typed error categories for failures
+// surfaced by the runner and verification layers, so retry policies,
+// reporting, and flake tracking can branch on error type instead of
+// string-matching "error" in raw CLI output.
+package errtaxonomy
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Category is one of a fixed set of failure classes a Terraspace/Terraform
+// run or verification step can fall into.
+type Category string
+
+const (
+	// CategoryAuth means the caller's credentials were rejected or expired.
+	CategoryAuth Category = "auth"
+	// CategoryQuota means an Azure subscription/resource quota was exhausted.
+	CategoryQuota Category = "quota"
+	// CategoryThrottle means ARM rate-limited the request; safe to retry
+	// after backing off.
+	CategoryThrottle Category = "throttle"
+	// CategoryPolicyDenied means an Azure Policy or RBAC assignment denied
+	// the operation; retrying without a config change will not help.
+	CategoryPolicyDenied Category = "policy_denied"
+	// CategoryTerraformSyntax means the failure is a configuration error
+	// (bad HCL, invalid reference, type mismatch) caught before any API call.
+	CategoryTerraformSyntax Category = "terraform_syntax"
+	// CategoryProviderBug means the Terraform provider itself misbehaved
+	// (panic, malformed diff, known provider issue).
+	CategoryProviderBug Category = "provider_bug"
+	// CategoryTransient means a network blip or timeout unrelated to the
+	// above; safe to retry.
+	CategoryTransient Category = "transient"
+	// CategoryUnknown means none of the known signatures matched.
+	CategoryUnknown Category = "unknown"
+)
+
+// Retryable reports whether a failure in this category is worth retrying
+// without any change in configuration or credentials.
+func (c Category) Retryable() bool {
+	switch c {
+	case CategoryThrottle, CategoryTransient:
+		return true
+	default:
+		return false
+	}
+}
+
+// Error is a categorized failure, optionally wrapping an underlying error.
+type Error struct {
+	Category Category
+	Message  string
+	Err      error
+}
+
+// New returns a categorized Error with no wrapped cause.
+func New(category Category, message string) *Error {
+	return &Error{Category: category, Message: message}
+}
+
+// Wrap returns a categorized Error wrapping err.
+func Wrap(category Category, err error) *Error {
+	return &Error{Category: category, Message: err.Error(), Err: err}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("errtaxonomy: [%s] %s", e.Category, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// signature pairs a regexp matched against raw CLI output with the
+// category it indicates. Order matters: the first match wins, so more
+// specific patterns are listed before general ones.
+type signature struct {
+	pattern  *regexp.Regexp
+	category Category
+}
+
+var signatures = []signature{
+	{regexp.MustCompile(`(?i)AuthorizationFailed|InvalidAuthenticationToken|AADSTS`), CategoryAuth},
+	{regexp.MustCompile(`(?i)QuotaExceeded|exceeded.*quota|too many.*(cores|resources)`), CategoryQuota},
+	{regexp.MustCompile(`(?i)TooManyRequests|RetryAfter|429`), CategoryThrottle},
+	{regexp.MustCompile(`(?i)RequestDisallowedByPolicy|PolicyViolation|denied by policy`), CategoryPolicyDenied},
+	{regexp.MustCompile(`(?i)Error: (Invalid|Unsupported|Missing).*\b(argument|block|reference)\b|Error parsing`), CategoryTerraformSyntax},
+	{regexp.MustCompile(`(?i)panic:|Plugin did not respond|provider produced an? invalid`), CategoryProviderBug},
+	{regexp.MustCompile(`(?i)context deadline exceeded|connection reset|i/o timeout|EOF`), CategoryTransient},
+}
+
+// Classify inspects raw CLI output (typically a runner.Result.Output) and
+// returns the best-matching category, or CategoryUnknown if no known
+// signature is present.
+func Classify(output string) Category {
+	for _, sig := range signatures {
+		if sig.pattern.MatchString(output) {
+			return sig.category
+		}
+	}
+	return CategoryUnknown
+}
+
+// ClassifyErr wraps err with the category Classify derives from output.
+func ClassifyErr(output string, err error) *Error {
+	return Wrap(Classify(output), err)
+}
@@ -0,0 +1,51 @@
+package errtaxonomy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		name   string
+		output string
+		want   Category
+	}{
+		{"auth", "Error: authorizing: AADSTS700016: Application not found", CategoryAuth},
+		{"quota", "Error: creating: QuotaExceeded: Operation could not be completed as it results in exceeding approved Total Regional Cores quota", CategoryQuota},
+		{"throttle", "Error: reading: unexpected status 429 (TooManyRequests)", CategoryThrottle},
+		{"policy", "Error: creating: RequestDisallowedByPolicy: Resource was disallowed by policy", CategoryPolicyDenied},
+		{"syntax", "Error: Unsupported argument\n\non main.tf line 12: an argument named \"replicas\" is not expected here", CategoryTerraformSyntax},
+		{"provider bug", "panic: runtime error: invalid memory address or nil pointer dereference", CategoryProviderBug},
+		{"transient", "Error: creating: context deadline exceeded", CategoryTransient},
+		{"unknown", "Error: something completely unrelated happened", CategoryUnknown},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, Classify(c.output))
+		})
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	assert.True(t, CategoryThrottle.Retryable())
+	assert.True(t, CategoryTransient.Retryable())
+	assert.False(t, CategoryAuth.Retryable())
+	assert.False(t, CategoryPolicyDenied.Retryable())
+}
+
+func TestClassifyErrWrapsUnderlyingError(t *testing.T) {
+	cause := errors.New("unexpected status 429")
+	classified := ClassifyErr("Error: TooManyRequests", cause)
+
+	assert.Equal(t, CategoryThrottle, classified.Category)
+	assert.ErrorIs(t, classified, cause)
+}
+
+func TestErrorMessageIncludesCategory(t *testing.T) {
+	err := New(CategoryQuota, "regional core quota exhausted")
+	assert.Contains(t, err.Error(), "quota")
+	assert.Contains(t, err.Error(), "regional core quota exhausted")
+}
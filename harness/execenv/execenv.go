@@ -0,0 +1,102 @@
+// Package execenv provides the plumbing this suite needs to run inside
+// the repo's test container image on a self-hosted runner instead of a
+// developer's machine: finding the repo root from an arbitrary working
+// directory, checking the CLI tools the harness shells out to are on
+// PATH, passing the host's Azure auth through to the container, and the
+// cache mounts that keep repeated runs from re-downloading providers and
+// modules.
+package execenv
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// RequiredTools are the CLI binaries the harness invokes directly or
+// through Terraspace.
+var RequiredTools = []string{"terraspace", "terraform", "az", "kubectl"}
+
+// RepoRoot walks up from startDir looking for go.mod, returning the first
+// directory that contains one, or an error if none is found before
+// reaching the filesystem root.
+func RepoRoot(startDir string) (string, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return "", fmt.Errorf("execenv: resolve %s: %w", startDir, err)
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("execenv: no go.mod found above %s", startDir)
+		}
+		dir = parent
+	}
+}
+
+// Violation is a single missing prerequisite.
+type Violation struct {
+	Message string
+}
+
+// LookPath resolves a tool name to its path, matching exec.LookPath's
+// signature so a real implementation can be passed directly.
+type LookPath func(name string) (string, error)
+
+// CheckTools reports a Violation for every tool lookPath can't resolve.
+func CheckTools(lookPath LookPath, tools []string) []Violation {
+	var violations []Violation
+	for _, tool := range tools {
+		if _, err := lookPath(tool); err != nil {
+			violations = append(violations, Violation{Message: fmt.Sprintf("required tool %q not found on PATH: %v", tool, err)})
+		}
+	}
+	return violations
+}
+
+// AuthEnvVars are the environment variables carrying Azure auth that must
+// pass from the host into the test container unchanged.
+var AuthEnvVars = []string{
+	"ARM_CLIENT_ID",
+	"ARM_CLIENT_SECRET",
+	"ARM_TENANT_ID",
+	"ARM_SUBSCRIPTION_ID",
+	"AZURE_CONFIG_DIR",
+}
+
+// Getenv matches os.Getenv's signature so a real implementation can be
+// passed directly.
+type Getenv func(key string) string
+
+// PassthroughEnv returns the subset of vars that are actually set in the
+// host environment, ready to inject into the container.
+func PassthroughEnv(getenv Getenv, vars []string) map[string]string {
+	env := make(map[string]string)
+	for _, key := range vars {
+		if val := getenv(key); val != "" {
+			env[key] = val
+		}
+	}
+	return env
+}
+
+// CacheMount is a host directory that should be bind-mounted into the
+// test container so repeated runs reuse downloaded providers/modules
+// instead of refetching them every time.
+type CacheMount struct {
+	HostPath      string
+	ContainerPath string
+}
+
+// DefaultCacheMounts returns the cache directories this harness benefits
+// from persisting across container runs, rooted at homeDir.
+func DefaultCacheMounts(homeDir string) []CacheMount {
+	return []CacheMount{
+		{HostPath: filepath.Join(homeDir, ".terraform.d", "plugin-cache"), ContainerPath: "/root/.terraform.d/plugin-cache"},
+		{HostPath: filepath.Join(homeDir, ".azure"), ContainerPath: "/root/.azure"},
+		{HostPath: filepath.Join(homeDir, ".cache", "terraspace"), ContainerPath: "/root/.cache/terraspace"},
+	}
+}
@@ -0,0 +1,74 @@
+package execenv
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoRootFindsGoModAtStart(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module x\n"), 0644))
+
+	root, err := RepoRoot(dir)
+	require.NoError(t, err)
+	assert.Equal(t, dir, root)
+}
+
+func TestRepoRootWalksUpFromNestedDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module x\n"), 0644))
+	nested := filepath.Join(dir, "harness", "execenv")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+
+	root, err := RepoRoot(nested)
+	require.NoError(t, err)
+	assert.Equal(t, dir, root)
+}
+
+func TestRepoRootErrorsWhenNoGoModFound(t *testing.T) {
+	dir := t.TempDir()
+	_, err := RepoRoot(dir)
+	assert.Error(t, err)
+}
+
+func TestCheckToolsFlagsMissingTools(t *testing.T) {
+	lookPath := func(name string) (string, error) {
+		if name == "terraform" {
+			return "/usr/bin/terraform", nil
+		}
+		return "", errors.New("not found")
+	}
+
+	violations := CheckTools(lookPath, []string{"terraform", "az", "kubectl"})
+	require.Len(t, violations, 2)
+	assert.Contains(t, violations[0].Message, "az")
+	assert.Contains(t, violations[1].Message, "kubectl")
+}
+
+func TestCheckToolsEmptyWhenAllResolve(t *testing.T) {
+	lookPath := func(name string) (string, error) { return "/usr/bin/" + name, nil }
+	violations := CheckTools(lookPath, RequiredTools)
+	assert.Empty(t, violations)
+}
+
+func TestPassthroughEnvOnlyIncludesSetVars(t *testing.T) {
+	values := map[string]string{"ARM_CLIENT_ID": "abc", "ARM_TENANT_ID": ""}
+	getenv := func(key string) string { return values[key] }
+
+	env := PassthroughEnv(getenv, AuthEnvVars)
+	assert.Equal(t, map[string]string{"ARM_CLIENT_ID": "abc"}, env)
+}
+
+func TestDefaultCacheMountsRootedAtHomeDir(t *testing.T) {
+	mounts := DefaultCacheMounts("/home/runner")
+	require.NotEmpty(t, mounts)
+	for _, m := range mounts {
+		assert.True(t, filepath.IsAbs(m.HostPath))
+		assert.True(t, filepath.IsAbs(m.ContainerPath))
+	}
+}
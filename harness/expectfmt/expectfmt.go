@@ -0,0 +1,97 @@
+// Package expectfmt defines the canonical form for stack expectations
+// fixtures (test/expectations/*.yaml) and validates that they're both
+// well-formed (no unknown keys, so a typo'd field doesn't silently no-op)
+// and normalized, so fixtures stay reviewable as they grow.
+package expectfmt
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Expectation is the strict schema for a stack's expectations fixture.
+type Expectation struct {
+	Stack     string                `yaml:"stack"`
+	Resources []ResourceExpectation `yaml:"resources"`
+}
+
+// ResourceExpectation asserts the attributes a single planned resource
+// must have.
+type ResourceExpectation struct {
+	Address    string            `yaml:"address"`
+	Type       string            `yaml:"type"`
+	Attributes map[string]string `yaml:"attributes,omitempty"`
+}
+
+// Parse strictly decodes fixture YAML, rejecting unknown keys.
+func Parse(data []byte) (Expectation, error) {
+	var exp Expectation
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&exp); err != nil {
+		return Expectation{}, fmt.Errorf("expectfmt: decode: %w", err)
+	}
+	return exp, nil
+}
+
+// Format re-marshals exp in this repo's canonical two-space-indented form.
+func Format(exp Expectation) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(exp); err != nil {
+		return nil, fmt.Errorf("expectfmt: encode: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("expectfmt: close encoder: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// CheckNormalized parses data and reformats it, returning an error if the
+// fixture has unknown keys or isn't already in canonical form.
+func CheckNormalized(data []byte) error {
+	exp, err := Parse(data)
+	if err != nil {
+		return err
+	}
+	formatted, err := Format(exp)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(formatted, data) {
+		return fmt.Errorf("expectfmt: fixture is not in canonical form; run the formatter to fix it")
+	}
+	return nil
+}
+
+// CheckDir validates every *.yaml fixture under root, returning one error
+// per non-compliant file rather than stopping at the first.
+func CheckDir(root string) []error {
+	var errs []error
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".yaml") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			return nil
+		}
+		if err := CheckNormalized(data); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+		}
+		return nil
+	})
+	return errs
+}
@@ -0,0 +1,41 @@
+package expectfmt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRejectsUnknownKeys(t *testing.T) {
+	_, err := Parse([]byte("stack: aks-cluster\nresrouces: []\n"))
+	assert.Error(t, err)
+}
+
+func TestCheckNormalizedPassesForCanonicalFixture(t *testing.T) {
+	data := []byte("stack: aks-cluster\nresources:\n    - address: azurerm_kubernetes_cluster.this\n      type: azurerm_kubernetes_cluster\n")
+	exp, err := Parse(data)
+	require.NoError(t, err)
+	formatted, err := Format(exp)
+	require.NoError(t, err)
+	assert.NoError(t, CheckNormalized(formatted))
+}
+
+func TestCheckNormalizedFlagsNonCanonicalIndentation(t *testing.T) {
+	data := []byte("stack: aks-cluster\nresources:\n- address: azurerm_kubernetes_cluster.this\n  type: azurerm_kubernetes_cluster\n")
+	err := CheckNormalized(data)
+	assert.Error(t, err)
+}
+
+func TestCheckDirReportsEveryBadFixture(t *testing.T) {
+	dir := t.TempDir()
+	good, err := Format(Expectation{Stack: "core"})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "core.yaml"), good, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.yaml"), []byte("stack: bad\nunknown_key: true\n"), 0o644))
+
+	errs := CheckDir(dir)
+	assert.Len(t, errs, 1)
+}
@@ -0,0 +1,93 @@
+package expiryaudit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azcertificates"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+// KeyVaultSecretLister lists every enabled secret in a vault that has an
+// expiry set.
+type KeyVaultSecretLister struct {
+	VaultName string
+	Client    *azsecrets.Client
+}
+
+func (l KeyVaultSecretLister) Kind() string { return "keyvault-secret:" + l.VaultName }
+
+func (l KeyVaultSecretLister) List(ctx context.Context) ([]Item, error) {
+	var items []Item
+	pager := l.Client.NewListSecretPropertiesPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list secret properties: %w", err)
+		}
+		for _, props := range page.Value {
+			if props == nil || props.Attributes == nil || props.Attributes.Expires == nil {
+				continue
+			}
+			if props.Attributes.Enabled != nil && !*props.Attributes.Enabled {
+				continue
+			}
+			items = append(items, Item{
+				Kind:      "keyvault-secret",
+				Scope:     l.VaultName,
+				Name:      secretName(props.ID),
+				ExpiresAt: *props.Attributes.Expires,
+			})
+		}
+	}
+	return items, nil
+}
+
+// KeyVaultCertificateLister lists every enabled certificate in a vault
+// that has an expiry set.
+type KeyVaultCertificateLister struct {
+	VaultName string
+	Client    *azcertificates.Client
+}
+
+func (l KeyVaultCertificateLister) Kind() string { return "keyvault-certificate:" + l.VaultName }
+
+func (l KeyVaultCertificateLister) List(ctx context.Context) ([]Item, error) {
+	var items []Item
+	pager := l.Client.NewListCertificatePropertiesPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list certificate properties: %w", err)
+		}
+		for _, props := range page.Value {
+			if props == nil || props.Attributes == nil || props.Attributes.Expires == nil {
+				continue
+			}
+			if props.Attributes.Enabled != nil && !*props.Attributes.Enabled {
+				continue
+			}
+			items = append(items, Item{
+				Kind:      "keyvault-certificate",
+				Scope:     l.VaultName,
+				Name:      certificateName(props.ID),
+				ExpiresAt: *props.Attributes.Expires,
+			})
+		}
+	}
+	return items, nil
+}
+
+func secretName(id *azsecrets.ID) string {
+	if id == nil {
+		return ""
+	}
+	return id.Name()
+}
+
+func certificateName(id *azcertificates.ID) string {
+	if id == nil {
+		return ""
+	}
+	return id.Name()
+}
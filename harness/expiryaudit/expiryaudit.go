@@ -0,0 +1,105 @@
+// Package expiryaudit enumerates everything in the environment with a
+// hard expiry — Key Vault certificates and secrets today — and reports
+// anything expiring within a caller-supplied window, so a certificate
+// lapsing silently doesn't become a surprise outage. Each source is a
+// Lister, matching the composition style of harness/auditmode's Check: any
+// existing or future expiring-credential source (AAD app credentials, an
+// AKS client certificate) can be adapted to this interface and passed in
+// without expiryaudit needing to know about it. This repo doesn't
+// currently depend on a Microsoft Graph client for AAD application
+// credentials, so no Lister for that source ships here; a caller with
+// Graph access can supply one.
+package expiryaudit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Item is one credential or certificate with an expiry.
+type Item struct {
+	Kind      string
+	Scope     string
+	Name      string
+	ExpiresAt time.Time
+}
+
+// Lister enumerates the expiring items from one source.
+type Lister interface {
+	Kind() string
+	List(ctx context.Context) ([]Item, error)
+}
+
+// Finding is an Item that falls within the audit window.
+type Finding struct {
+	Item          Item
+	DaysRemaining int
+}
+
+// SourceError records a Lister that failed, without aborting the rest of
+// the audit.
+type SourceError struct {
+	Kind string
+	Err  error
+}
+
+// Report is the outcome of auditing every Lister.
+type Report struct {
+	GeneratedAt time.Time
+	Findings    []Finding
+	Errors      []SourceError
+}
+
+// Clean reports whether the audit found nothing expiring soon and every
+// source listed successfully.
+func (r Report) Clean() bool {
+	return len(r.Findings) == 0 && len(r.Errors) == 0
+}
+
+// Audit lists every source and returns the items expiring within the next
+// `within` duration of now, sorted soonest-first. A Lister that errors is
+// recorded in Report.Errors rather than aborting the remaining sources, so
+// one broken vault query doesn't hide expiries reported by every other
+// source.
+func Audit(ctx context.Context, listers []Lister, within time.Duration, now func() time.Time) Report {
+	if now == nil {
+		now = time.Now
+	}
+	report := Report{GeneratedAt: now()}
+
+	for _, lister := range listers {
+		items, err := lister.List(ctx)
+		if err != nil {
+			report.Errors = append(report.Errors, SourceError{
+				Kind: lister.Kind(),
+				Err:  fmt.Errorf("expiryaudit: list %s: %w", lister.Kind(), err),
+			})
+			continue
+		}
+		for _, item := range items {
+			if item.ExpiresAt.IsZero() {
+				continue
+			}
+			remaining := item.ExpiresAt.Sub(now())
+			if remaining > within {
+				continue
+			}
+			report.Findings = append(report.Findings, Finding{
+				Item:          item,
+				DaysRemaining: int(remaining.Hours() / 24),
+			})
+		}
+	}
+
+	sortFindingsByExpiry(report.Findings)
+	return report
+}
+
+func sortFindingsByExpiry(findings []Finding) {
+	for i := 1; i < len(findings); i++ {
+		for j := i; j > 0 && findings[j].Item.ExpiresAt.Before(findings[j-1].Item.ExpiresAt); j-- {
+			findings[j], findings[j-1] = findings[j-1], findings[j]
+		}
+	}
+}
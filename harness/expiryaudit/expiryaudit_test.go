@@ -0,0 +1,80 @@
+package expiryaudit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLister struct {
+	kind  string
+	items []Item
+	err   error
+}
+
+func (f fakeLister) Kind() string { return f.kind }
+func (f fakeLister) List(ctx context.Context) ([]Item, error) {
+	return f.items, f.err
+}
+
+func TestAuditFlagsItemsExpiringWithinWindow(t *testing.T) {
+	now := time.Date(2026, 8, 9, 0, 0, 0, 0, time.UTC)
+	lister := fakeLister{kind: "keyvault-secret", items: []Item{
+		{Name: "db-password", ExpiresAt: now.Add(5 * 24 * time.Hour)},
+		{Name: "long-lived", ExpiresAt: now.Add(200 * 24 * time.Hour)},
+	}}
+
+	report := Audit(context.Background(), []Lister{lister}, 30*24*time.Hour, func() time.Time { return now })
+
+	require.Len(t, report.Findings, 1)
+	assert.Equal(t, "db-password", report.Findings[0].Item.Name)
+	assert.Equal(t, 5, report.Findings[0].DaysRemaining)
+}
+
+func TestAuditSkipsItemsWithNoExpiry(t *testing.T) {
+	now := time.Now()
+	lister := fakeLister{kind: "keyvault-secret", items: []Item{{Name: "no-expiry"}}}
+
+	report := Audit(context.Background(), []Lister{lister}, 30*24*time.Hour, func() time.Time { return now })
+
+	assert.Empty(t, report.Findings)
+	assert.True(t, report.Clean())
+}
+
+func TestAuditRecordsSourceErrorWithoutAbortingOtherSources(t *testing.T) {
+	now := time.Now()
+	broken := fakeLister{kind: "keyvault-secret", err: errors.New("vault unreachable")}
+	working := fakeLister{kind: "keyvault-certificate", items: []Item{
+		{Name: "tls-cert", ExpiresAt: now.Add(24 * time.Hour)},
+	}}
+
+	report := Audit(context.Background(), []Lister{broken, working}, 30*24*time.Hour, func() time.Time { return now })
+
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, "keyvault-secret", report.Errors[0].Kind)
+	require.Len(t, report.Findings, 1)
+	assert.Equal(t, "tls-cert", report.Findings[0].Item.Name)
+}
+
+func TestAuditSortsFindingsSoonestFirst(t *testing.T) {
+	now := time.Now()
+	lister := fakeLister{kind: "keyvault-secret", items: []Item{
+		{Name: "expires-later", ExpiresAt: now.Add(20 * 24 * time.Hour)},
+		{Name: "expires-soon", ExpiresAt: now.Add(2 * 24 * time.Hour)},
+	}}
+
+	report := Audit(context.Background(), []Lister{lister}, 30*24*time.Hour, func() time.Time { return now })
+
+	require.Len(t, report.Findings, 2)
+	assert.Equal(t, "expires-soon", report.Findings[0].Item.Name)
+	assert.Equal(t, "expires-later", report.Findings[1].Item.Name)
+}
+
+func TestReportCleanFalseWhenErrorsPresentEvenWithNoFindings(t *testing.T) {
+	report := Report{Errors: []SourceError{{Kind: "x", Err: errors.New("boom")}}}
+	assert.False(t, report.Clean())
+}
@@ -0,0 +1,80 @@
+// Package exprtest table-tests complex HCL expressions used in module
+// locals (naming maps, subnet calculations, tag merging) by evaluating
+// them directly with the HCL/cty evaluator this repo already pins, so
+// expression bugs are caught without any cloud interaction or a
+// `terraform console` subprocess.
+package exprtest
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// DefaultFunctions are the subset of Terraform's built-in functions most
+// commonly used in module locals (naming maps, tag merging, subnet
+// calculations). Cases needing others can't currently supply extra
+// functions; add here as new locals require them.
+var DefaultFunctions = map[string]function.Function{
+	"merge":    stdlib.MergeFunc,
+	"length":   stdlib.LengthFunc,
+	"lookup":   stdlib.LookupFunc,
+	"concat":   stdlib.ConcatFunc,
+	"contains": stdlib.ContainsFunc,
+}
+
+// Case is a single table-driven expression test.
+type Case struct {
+	// Name identifies the case in test output.
+	Name string
+	// Expr is a raw HCL expression, e.g. `merge(var.base_tags, {env = var.environment})`.
+	Expr string
+	// Vars are the identifiers available to Expr, keyed by top-level name
+	// (e.g. "var" -> object with "environment", "base_tags", ...).
+	Vars map[string]cty.Value
+	// Want is the expected evaluated value.
+	Want cty.Value
+}
+
+// Result is the outcome of evaluating a single Case.
+type Result struct {
+	Case   Case
+	Got    cty.Value
+	Err    error
+	Passed bool
+}
+
+// Run evaluates every case and reports whether its result matched Want.
+func Run(cases []Case) []Result {
+	results := make([]Result, 0, len(cases))
+	for _, c := range cases {
+		got, err := Eval(c.Expr, c.Vars)
+		results = append(results, Result{
+			Case:   c,
+			Got:    got,
+			Err:    err,
+			Passed: err == nil && got.RawEquals(c.Want),
+		})
+	}
+	return results
+}
+
+// Eval parses and evaluates a single HCL expression against vars, with
+// DefaultFunctions available for calls like merge() and length().
+func Eval(expr string, vars map[string]cty.Value) (cty.Value, error) {
+	parsed, diags := hclsyntax.ParseExpression([]byte(expr), "<exprtest>", hcl.InitialPos)
+	if diags.HasErrors() {
+		return cty.NilVal, fmt.Errorf("exprtest: parse %q: %s", expr, diags.Error())
+	}
+
+	ctx := &hcl.EvalContext{Variables: vars, Functions: DefaultFunctions}
+	val, diags := parsed.Value(ctx)
+	if diags.HasErrors() {
+		return cty.NilVal, fmt.Errorf("exprtest: evaluate %q: %s", expr, diags.Error())
+	}
+	return val, nil
+}
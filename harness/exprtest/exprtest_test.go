@@ -0,0 +1,67 @@
+package exprtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestEvalTagMerging(t *testing.T) {
+	vars := map[string]cty.Value{
+		"var": cty.ObjectVal(map[string]cty.Value{
+			"base_tags": cty.ObjectVal(map[string]cty.Value{
+				"owner": cty.StringVal("platform"),
+			}),
+			"environment": cty.StringVal("prod"),
+		}),
+	}
+	got, err := Eval(`merge(var.base_tags, {env = var.environment})`, vars)
+	require.NoError(t, err)
+
+	assert.Equal(t, cty.StringVal("platform"), got.GetAttr("owner"))
+	assert.Equal(t, cty.StringVal("prod"), got.GetAttr("env"))
+}
+
+func TestEvalReturnsErrorForInvalidExpression(t *testing.T) {
+	_, err := Eval(`this is not valid hcl (`, nil)
+	assert.Error(t, err)
+}
+
+func TestRunTableDrivenCases(t *testing.T) {
+	cases := []Case{
+		{
+			Name: "naming map produces expected cluster name",
+			Expr: `"${var.prefix}-${var.env}-aks"`,
+			Vars: map[string]cty.Value{
+				"var": cty.ObjectVal(map[string]cty.Value{
+					"prefix": cty.StringVal("plat"),
+					"env":    cty.StringVal("prod"),
+				}),
+			},
+			Want: cty.StringVal("plat-prod-aks"),
+		},
+		{
+			Name: "subnet count calculation",
+			Expr: `length(var.subnet_prefixes) * 2`,
+			Vars: map[string]cty.Value{
+				"var": cty.ObjectVal(map[string]cty.Value{
+					"subnet_prefixes": cty.ListVal([]cty.Value{cty.StringVal("10.0.0.0/24"), cty.StringVal("10.0.1.0/24")}),
+				}),
+			},
+			Want: cty.NumberIntVal(4),
+		},
+		{
+			Name: "wrong expectation fails",
+			Expr: `1 + 1`,
+			Want: cty.NumberIntVal(3),
+		},
+	}
+
+	results := Run(cases)
+	require.Len(t, results, 3)
+	assert.True(t, results[0].Passed)
+	assert.True(t, results[1].Passed)
+	assert.False(t, results[2].Passed)
+}
@@ -0,0 +1,74 @@
+// Package extensioninventory asserts the cluster's installed extensions
+// (Dapr, Flux, ACRpull, etc.) exactly match what this env's config says
+// should be installed: nothing missing, and nothing installed
+// out-of-band that config doesn't account for.
+package extensioninventory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/kubernetesconfiguration/armkubernetesconfiguration"
+)
+
+// ExpectedExtension is one extension this env's config expects installed.
+type ExpectedExtension struct {
+	Name          string
+	ExtensionType string
+}
+
+// Violation is a single missing, unexpected, or unhealthy extension.
+type Violation struct {
+	Message string
+}
+
+// VerifyInventory compares the extensions actually installed on the
+// cluster against expected, flagging any expected extension that's
+// missing or not yet Succeeded, and any installed extension that isn't
+// in expected at all.
+func VerifyInventory(installed []*armkubernetesconfiguration.Extension, expected []ExpectedExtension) []Violation {
+	byName := make(map[string]*armkubernetesconfiguration.Extension, len(installed))
+	for _, e := range installed {
+		if e.Name != nil {
+			byName[*e.Name] = e
+		}
+	}
+
+	wanted := make(map[string]bool, len(expected))
+	var violations []Violation
+
+	for _, exp := range expected {
+		wanted[exp.Name] = true
+		ext, ok := byName[exp.Name]
+		if !ok {
+			violations = append(violations, Violation{Message: fmt.Sprintf("extension %q is expected but not installed", exp.Name)})
+			continue
+		}
+		if ext.Properties == nil || ext.Properties.ExtensionType == nil || *ext.Properties.ExtensionType != exp.ExtensionType {
+			violations = append(violations, Violation{Message: fmt.Sprintf("extension %q has type %s, expected %s", exp.Name, extensionType(ext), exp.ExtensionType)})
+		}
+		if ext.Properties == nil || ext.Properties.ProvisioningState == nil || *ext.Properties.ProvisioningState != armkubernetesconfiguration.ProvisioningStateSucceeded {
+			violations = append(violations, Violation{Message: fmt.Sprintf("extension %q is not in a Succeeded provisioning state", exp.Name)})
+		}
+	}
+
+	var unexpected []string
+	for name := range byName {
+		if !wanted[name] {
+			unexpected = append(unexpected, name)
+		}
+	}
+	sort.Strings(unexpected)
+	for _, name := range unexpected {
+		violations = append(violations, Violation{Message: fmt.Sprintf("extension %q is installed but not declared in this env's config", name)})
+	}
+
+	return violations
+}
+
+func extensionType(ext *armkubernetesconfiguration.Extension) string {
+	if ext.Properties == nil || ext.Properties.ExtensionType == nil {
+		return "<unset>"
+	}
+	return *ext.Properties.ExtensionType
+}
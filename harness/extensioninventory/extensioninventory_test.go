@@ -0,0 +1,61 @@
+package extensioninventory
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/kubernetesconfiguration/armkubernetesconfiguration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+
+func extension(name, extType string, state armkubernetesconfiguration.ProvisioningState) *armkubernetesconfiguration.Extension {
+	return &armkubernetesconfiguration.Extension{
+		Name: strPtr(name),
+		Properties: &armkubernetesconfiguration.ExtensionProperties{
+			ExtensionType:     strPtr(extType),
+			ProvisioningState: &state,
+		},
+	}
+}
+
+func TestVerifyInventoryFlagsMissingExtension(t *testing.T) {
+	expected := []ExpectedExtension{{Name: "dapr", ExtensionType: "Microsoft.Dapr"}}
+	violations := VerifyInventory(nil, expected)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "not installed")
+}
+
+func TestVerifyInventoryFlagsWrongType(t *testing.T) {
+	installed := []*armkubernetesconfiguration.Extension{extension("dapr", "Microsoft.Flux", armkubernetesconfiguration.ProvisioningStateSucceeded)}
+	violations := VerifyInventory(installed, []ExpectedExtension{{Name: "dapr", ExtensionType: "Microsoft.Dapr"}})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "expected Microsoft.Dapr")
+}
+
+func TestVerifyInventoryFlagsUnhealthyExtension(t *testing.T) {
+	installed := []*armkubernetesconfiguration.Extension{extension("dapr", "Microsoft.Dapr", armkubernetesconfiguration.ProvisioningStateFailed)}
+	violations := VerifyInventory(installed, []ExpectedExtension{{Name: "dapr", ExtensionType: "Microsoft.Dapr"}})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "Succeeded")
+}
+
+func TestVerifyInventoryFlagsUnexpectedExtension(t *testing.T) {
+	installed := []*armkubernetesconfiguration.Extension{extension("mystery-agent", "Microsoft.Unknown", armkubernetesconfiguration.ProvisioningStateSucceeded)}
+	violations := VerifyInventory(installed, nil)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "not declared")
+}
+
+func TestVerifyInventoryPassesOnExactMatch(t *testing.T) {
+	installed := []*armkubernetesconfiguration.Extension{
+		extension("dapr", "Microsoft.Dapr", armkubernetesconfiguration.ProvisioningStateSucceeded),
+		extension("flux", "microsoft.flux", armkubernetesconfiguration.ProvisioningStateSucceeded),
+	}
+	expected := []ExpectedExtension{
+		{Name: "dapr", ExtensionType: "Microsoft.Dapr"},
+		{Name: "flux", ExtensionType: "microsoft.flux"},
+	}
+	assert.Empty(t, VerifyInventory(installed, expected))
+}
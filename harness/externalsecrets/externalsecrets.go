@@ -0,0 +1,130 @@
+// Package externalsecrets validates the External Secrets Operator path
+// for workloads that use it alongside or instead of the Key Vault CSI
+// driver: it writes a Key Vault secret, waits for the corresponding
+// Kubernetes Secret to materialize, updates the Key Vault value, and
+// waits again for the refresh to land — exercising the actual polling
+// interval and identity configuration rather than just checking that the
+// ExternalSecret resource exists.
+package externalsecrets
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// KeyVaultSetter writes a secret value to Key Vault, typically via the
+// azsecrets SDK.
+type KeyVaultSetter interface {
+	SetSecret(ctx context.Context, vaultName, secretName, value string) error
+}
+
+// SecretReader reads a key out of a materialized Kubernetes Secret,
+// typically via client-go.
+type SecretReader interface {
+	ReadSecret(ctx context.Context, namespace, name, key string) (string, error)
+}
+
+// Options configures the poll loops used to wait for the operator to
+// sync. PollInterval, Now and Sleep default to production values when
+// left zero; tests override Now/Sleep to run without real delays.
+type Options struct {
+	Timeout      time.Duration
+	PollInterval time.Duration
+	Now          func() time.Time
+	Sleep        func(time.Duration)
+}
+
+// Target identifies the Key Vault secret and the Kubernetes Secret an
+// ExternalSecret resource is expected to sync it into.
+type Target struct {
+	VaultName     string
+	SecretName    string
+	Namespace     string
+	K8sSecretName string
+	Key           string
+}
+
+// Result reports whether the initial sync and the refresh-after-update
+// both landed within opts.Timeout.
+type Result struct {
+	Materialized bool
+	Updated      bool
+	LastErr      error
+}
+
+// Passed reports whether both the initial materialization and the
+// post-update refresh succeeded.
+func (r Result) Passed() bool {
+	return r.Materialized && r.Updated
+}
+
+// VerifyMaterializeAndUpdate writes initialValue to Key Vault and waits
+// for target's Kubernetes Secret to reflect it, then writes updatedValue
+// and waits again, validating that External Secrets Operator both syncs a
+// new secret and picks up value changes within its configured polling
+// interval.
+func VerifyMaterializeAndUpdate(ctx context.Context, kv KeyVaultSetter, reader SecretReader, target Target, initialValue, updatedValue string, opts Options) Result {
+	var result Result
+
+	if err := kv.SetSecret(ctx, target.VaultName, target.SecretName, initialValue); err != nil {
+		result.LastErr = fmt.Errorf("externalsecrets: write initial value to %s/%s: %w", target.VaultName, target.SecretName, err)
+		return result
+	}
+	if err := waitForValue(ctx, reader, target, initialValue, opts); err != nil {
+		result.LastErr = fmt.Errorf("externalsecrets: %s/%s never materialized: %w", target.Namespace, target.K8sSecretName, err)
+		return result
+	}
+	result.Materialized = true
+
+	if err := kv.SetSecret(ctx, target.VaultName, target.SecretName, updatedValue); err != nil {
+		result.LastErr = fmt.Errorf("externalsecrets: write updated value to %s/%s: %w", target.VaultName, target.SecretName, err)
+		return result
+	}
+	if err := waitForValue(ctx, reader, target, updatedValue, opts); err != nil {
+		result.LastErr = fmt.Errorf("externalsecrets: %s/%s never picked up the updated value: %w", target.Namespace, target.K8sSecretName, err)
+		return result
+	}
+	result.Updated = true
+
+	return result
+}
+
+func waitForValue(ctx context.Context, reader SecretReader, target Target, want string, opts Options) error {
+	now := opts.Now
+	if now == nil {
+		now = time.Now
+	}
+	sleep := opts.Sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	pollInterval := opts.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 10 * time.Second
+	}
+
+	start := now()
+	var lastErr error
+	for {
+		got, err := reader.ReadSecret(ctx, target.Namespace, target.K8sSecretName, target.Key)
+		if err == nil && got == want {
+			return nil
+		}
+		if err != nil {
+			lastErr = err
+		} else {
+			lastErr = fmt.Errorf("value is %q, want %q", got, want)
+		}
+		if now().Sub(start) >= opts.Timeout {
+			return lastErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		sleep(pollInterval)
+	}
+}
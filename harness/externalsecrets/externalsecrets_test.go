@@ -0,0 +1,147 @@
+package externalsecrets
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeKeyVault struct {
+	values map[string]string
+	err    error
+}
+
+func (f *fakeKeyVault) SetSecret(ctx context.Context, vaultName, secretName, value string) error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.values == nil {
+		f.values = make(map[string]string)
+	}
+	f.values[vaultName+"/"+secretName] = value
+	return nil
+}
+
+type fakeSecretReader struct {
+	kv          *fakeKeyVault
+	vaultName   string
+	secretName  string
+	delayReads  int
+	readAttempt int
+}
+
+func (f *fakeSecretReader) ReadSecret(ctx context.Context, namespace, name, key string) (string, error) {
+	f.readAttempt++
+	if f.readAttempt <= f.delayReads {
+		return "", errors.New("secret not found yet")
+	}
+	return f.kv.values[f.vaultName+"/"+f.secretName], nil
+}
+
+func fakeClock(start time.Time) (now func() time.Time, sleep func(time.Duration)) {
+	current := start
+	now = func() time.Time { return current }
+	sleep = func(d time.Duration) { current = current.Add(d) }
+	return
+}
+
+func TestVerifyMaterializeAndUpdatePassesWhenSyncsPromptly(t *testing.T) {
+	kv := &fakeKeyVault{}
+	reader := &fakeSecretReader{kv: kv, vaultName: "kv1", secretName: "db-password"}
+	target := Target{VaultName: "kv1", SecretName: "db-password", Namespace: "app", K8sSecretName: "db-password", Key: "password"}
+	now, sleep := fakeClock(time.Unix(0, 0))
+
+	result := VerifyMaterializeAndUpdate(context.Background(), kv, reader, target, "initial", "rotated",
+		Options{Timeout: time.Minute, PollInterval: time.Second, Now: now, Sleep: sleep})
+
+	assert.True(t, result.Passed())
+}
+
+func TestVerifyMaterializeAndUpdateToleratesSyncDelay(t *testing.T) {
+	kv := &fakeKeyVault{}
+	reader := &fakeSecretReader{kv: kv, vaultName: "kv1", secretName: "db-password", delayReads: 2}
+	target := Target{VaultName: "kv1", SecretName: "db-password", Namespace: "app", K8sSecretName: "db-password", Key: "password"}
+	now, sleep := fakeClock(time.Unix(0, 0))
+
+	result := VerifyMaterializeAndUpdate(context.Background(), kv, reader, target, "initial", "rotated",
+		Options{Timeout: time.Minute, PollInterval: 10 * time.Second, Now: now, Sleep: sleep})
+
+	assert.True(t, result.Passed())
+}
+
+func TestVerifyMaterializeAndUpdateFailsWhenNeverMaterializes(t *testing.T) {
+	kv := &fakeKeyVault{}
+	reader := &fakeSecretReader{kv: kv, vaultName: "kv1", secretName: "db-password", delayReads: 1000}
+	target := Target{VaultName: "kv1", SecretName: "db-password", Namespace: "app", K8sSecretName: "db-password", Key: "password"}
+	now, sleep := fakeClock(time.Unix(0, 0))
+
+	result := VerifyMaterializeAndUpdate(context.Background(), kv, reader, target, "initial", "rotated",
+		Options{Timeout: 30 * time.Second, PollInterval: 10 * time.Second, Now: now, Sleep: sleep})
+
+	assert.False(t, result.Passed())
+	assert.False(t, result.Materialized)
+	assert.Error(t, result.LastErr)
+}
+
+func TestVerifyMaterializeAndUpdateFailsOnKeyVaultWriteError(t *testing.T) {
+	kv := &fakeKeyVault{err: errors.New("access denied")}
+	reader := &fakeSecretReader{kv: kv}
+	target := Target{VaultName: "kv1", SecretName: "db-password", Namespace: "app", K8sSecretName: "db-password", Key: "password"}
+	now, sleep := fakeClock(time.Unix(0, 0))
+
+	result := VerifyMaterializeAndUpdate(context.Background(), kv, reader, target, "initial", "rotated",
+		Options{Timeout: time.Minute, PollInterval: time.Second, Now: now, Sleep: sleep})
+
+	assert.False(t, result.Passed())
+	assert.Error(t, result.LastErr)
+}
+
+func TestVerifyMaterializeAndUpdateStopsAfterMaterializeIfUpdateNeverLands(t *testing.T) {
+	kv := &fakeKeyVault{}
+	reader := &fakeSecretReader{kv: kv, vaultName: "kv1", secretName: "db-password"}
+	target := Target{VaultName: "kv1", SecretName: "db-password", Namespace: "app", K8sSecretName: "db-password", Key: "password"}
+	now, sleep := fakeClock(time.Unix(0, 0))
+
+	// reader always returns the value currently stored in kv; simulate the
+	// operator never picking up the second write with a reader whose
+	// ReadSecret keeps returning the initial value forever.
+	stuckReader := &stuckAfterFirstReader{fakeSecretReader: reader}
+
+	result := VerifyMaterializeAndUpdate(context.Background(), kv, stuckReader, target, "initial", "rotated",
+		Options{Timeout: 20 * time.Second, PollInterval: 10 * time.Second, Now: now, Sleep: sleep})
+
+	assert.True(t, result.Materialized)
+	assert.False(t, result.Updated)
+}
+
+func TestVerifyMaterializeAndUpdateReturnsPromptlyWhenContextIsCanceled(t *testing.T) {
+	kv := &fakeKeyVault{}
+	reader := &fakeSecretReader{kv: kv, vaultName: "kv1", secretName: "db-password", delayReads: 1000}
+	target := Target{VaultName: "kv1", SecretName: "db-password", Namespace: "app", K8sSecretName: "db-password", Key: "password"}
+	now, sleep := fakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := VerifyMaterializeAndUpdate(ctx, kv, reader, target, "initial", "rotated",
+		Options{Timeout: time.Hour, PollInterval: 10 * time.Second, Now: now, Sleep: sleep})
+
+	assert.False(t, result.Passed())
+	assert.ErrorIs(t, result.LastErr, context.Canceled)
+	assert.Equal(t, 1, reader.readAttempt)
+}
+
+type stuckAfterFirstReader struct {
+	*fakeSecretReader
+	materialized bool
+}
+
+func (s *stuckAfterFirstReader) ReadSecret(ctx context.Context, namespace, name, key string) (string, error) {
+	if !s.materialized {
+		s.materialized = true
+		return "initial", nil
+	}
+	return "initial", nil
+}
@@ -0,0 +1,88 @@
+// Package failover measures DNS/global-load-balancer failover behavior when
+// a primary region's ingress is disrupted, so failover time is verified in
+// CI rather than assumed from the TTL configured in Terraform.
+package failover
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Prober reports whether the global entrypoint (Traffic Manager / Front
+// Door) is currently routing traffic to the secondary region.
+type Prober interface {
+	ResolvesToSecondary(ctx context.Context) (bool, error)
+}
+
+// Trigger disrupts the primary region's ingress (e.g. scaling a deployment
+// to zero, or applying an NSG deny rule) so failover can be observed, and
+// restores it once the measurement is done.
+type Trigger interface {
+	DisruptPrimary(ctx context.Context) error
+	RestorePrimary(ctx context.Context) error
+}
+
+// Result is the outcome of a single failover measurement.
+type Result struct {
+	Detected  bool
+	Elapsed   time.Duration
+	WithinTTL bool
+}
+
+// Options configures Measure. PollInterval, Now and Sleep default to
+// production values when left zero; tests override Now/Sleep to run the
+// polling loop without real delays.
+type Options struct {
+	TTL          time.Duration
+	PollInterval time.Duration
+	Timeout      time.Duration
+	Now          func() time.Time
+	Sleep        func(time.Duration)
+}
+
+// Measure disrupts the primary region via trigger, then polls prober until
+// it reports the secondary is serving traffic (or Timeout elapses),
+// returning how long failover took and whether it happened within the
+// configured TTL window. The primary is always restored before returning.
+func Measure(ctx context.Context, trigger Trigger, prober Prober, opts Options) (Result, error) {
+	now := opts.Now
+	if now == nil {
+		now = time.Now
+	}
+	sleep := opts.Sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	pollInterval := opts.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+
+	if err := trigger.DisruptPrimary(ctx); err != nil {
+		return Result{}, fmt.Errorf("failover: disrupt primary: %w", err)
+	}
+	defer trigger.RestorePrimary(ctx)
+
+	start := now()
+	for {
+		ok, err := prober.ResolvesToSecondary(ctx)
+		if err != nil {
+			return Result{}, fmt.Errorf("failover: probe: %w", err)
+		}
+		if ok {
+			elapsed := now().Sub(start)
+			return Result{Detected: true, Elapsed: elapsed, WithinTTL: elapsed <= opts.TTL}, nil
+		}
+		if now().Sub(start) >= opts.Timeout {
+			return Result{Detected: false, Elapsed: now().Sub(start)}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return Result{}, ctx.Err()
+		default:
+		}
+		sleep(pollInterval)
+	}
+}
@@ -0,0 +1,104 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTrigger struct {
+	disrupted bool
+	restored  bool
+}
+
+func (f *fakeTrigger) DisruptPrimary(ctx context.Context) error {
+	f.disrupted = true
+	return nil
+}
+
+func (f *fakeTrigger) RestorePrimary(ctx context.Context) error {
+	f.restored = true
+	return nil
+}
+
+type fakeProber struct {
+	healthyAfter int
+	calls        int
+	err          error
+}
+
+func (f *fakeProber) ResolvesToSecondary(ctx context.Context) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	f.calls++
+	return f.calls >= f.healthyAfter, nil
+}
+
+func TestMeasureReportsElapsedWithinTTL(t *testing.T) {
+	clock := time.Unix(0, 0)
+	trigger := &fakeTrigger{}
+	prober := &fakeProber{healthyAfter: 3}
+
+	result, err := Measure(context.Background(), trigger, prober, Options{
+		TTL:          30 * time.Second,
+		PollInterval: 5 * time.Second,
+		Timeout:      time.Minute,
+		Now:          func() time.Time { return clock },
+		Sleep:        func(d time.Duration) { clock = clock.Add(d) },
+	})
+
+	require.NoError(t, err)
+	assert.True(t, trigger.disrupted)
+	assert.True(t, trigger.restored)
+	assert.True(t, result.Detected)
+	assert.True(t, result.WithinTTL)
+	assert.Equal(t, 10*time.Second, result.Elapsed)
+}
+
+func TestMeasureFlagsFailoverOutsideTTL(t *testing.T) {
+	clock := time.Unix(0, 0)
+	prober := &fakeProber{healthyAfter: 10}
+
+	result, err := Measure(context.Background(), &fakeTrigger{}, prober, Options{
+		TTL:          15 * time.Second,
+		PollInterval: 5 * time.Second,
+		Timeout:      time.Minute,
+		Now:          func() time.Time { return clock },
+		Sleep:        func(d time.Duration) { clock = clock.Add(d) },
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Detected)
+	assert.False(t, result.WithinTTL)
+}
+
+func TestMeasureTimesOutWithoutDetection(t *testing.T) {
+	clock := time.Unix(0, 0)
+	prober := &fakeProber{healthyAfter: 1000}
+
+	result, err := Measure(context.Background(), &fakeTrigger{}, prober, Options{
+		TTL:          15 * time.Second,
+		PollInterval: 5 * time.Second,
+		Timeout:      20 * time.Second,
+		Now:          func() time.Time { return clock },
+		Sleep:        func(d time.Duration) { clock = clock.Add(d) },
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.Detected)
+}
+
+func TestMeasurePropagatesProbeError(t *testing.T) {
+	prober := &fakeProber{err: errors.New("dns lookup failed")}
+
+	_, err := Measure(context.Background(), &fakeTrigger{}, prober, Options{
+		Timeout: time.Minute,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dns lookup failed")
+}
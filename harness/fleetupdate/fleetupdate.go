@@ -0,0 +1,129 @@
+// Package fleetupdate verifies Azure Kubernetes Fleet Manager is wired up
+// as coded: member clusters have joined the fleet, the configured update
+// strategy's stages/groups match what's expected, and a staged update run
+// progresses through those stages in the defined order.
+package fleetupdate
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservicefleet/armcontainerservicefleet"
+)
+
+// ExpectedMember is a cluster that must have joined the fleet, optionally
+// as part of a named update group.
+type ExpectedMember struct {
+	ClusterResourceID string
+	Group             string
+}
+
+// Violation is a single fleet configuration or progress mismatch.
+type Violation struct {
+	Message string
+}
+
+// VerifyMembership checks every expected member has joined the fleet in
+// a Succeeded state and, if a group is specified, belongs to it.
+func VerifyMembership(members []*armcontainerservicefleet.FleetMember, expected []ExpectedMember) []Violation {
+	byClusterID := make(map[string]*armcontainerservicefleet.FleetMember, len(members))
+	for _, m := range members {
+		if m.Properties != nil && m.Properties.ClusterResourceID != nil {
+			byClusterID[*m.Properties.ClusterResourceID] = m
+		}
+	}
+
+	var violations []Violation
+	for _, exp := range expected {
+		member, ok := byClusterID[exp.ClusterResourceID]
+		if !ok {
+			violations = append(violations, Violation{Message: fmt.Sprintf("cluster %q has not joined the fleet", exp.ClusterResourceID)})
+			continue
+		}
+		if member.Properties.ProvisioningState == nil || *member.Properties.ProvisioningState != armcontainerservicefleet.FleetMemberProvisioningStateSucceeded {
+			violations = append(violations, Violation{Message: fmt.Sprintf("fleet member for cluster %q is not in a Succeeded provisioning state", exp.ClusterResourceID)})
+		}
+		if exp.Group != "" && (member.Properties.Group == nil || *member.Properties.Group != exp.Group) {
+			violations = append(violations, Violation{Message: fmt.Sprintf("cluster %q is not in expected update group %q", exp.ClusterResourceID, exp.Group)})
+		}
+	}
+	return violations
+}
+
+// ExpectedStage is one stage of the expected update strategy, listing the
+// update groups it targets in the order they were coded.
+type ExpectedStage struct {
+	Name   string
+	Groups []string
+}
+
+// VerifyStrategy checks the fleet's configured update strategy has the
+// same stages, in the same order, targeting the same groups, as coded.
+func VerifyStrategy(strategy armcontainerservicefleet.UpdateRunStrategy, expected []ExpectedStage) []Violation {
+	if len(strategy.Stages) != len(expected) {
+		return []Violation{{Message: fmt.Sprintf("update strategy has %d stage(s), expected %d", len(strategy.Stages), len(expected))}}
+	}
+
+	var violations []Violation
+	for i, exp := range expected {
+		stage := strategy.Stages[i]
+		if stage.Name == nil || *stage.Name != exp.Name {
+			violations = append(violations, Violation{Message: fmt.Sprintf("stage %d is named %q, expected %q", i, derefStr(stage.Name), exp.Name)})
+			continue
+		}
+		groups := make([]string, 0, len(stage.Groups))
+		for _, g := range stage.Groups {
+			if g.Name != nil {
+				groups = append(groups, *g.Name)
+			}
+		}
+		if !equalStrings(groups, exp.Groups) {
+			violations = append(violations, Violation{Message: fmt.Sprintf("stage %q targets groups %v, expected %v", exp.Name, groups, exp.Groups)})
+		}
+	}
+	return violations
+}
+
+// VerifyProgression checks a staged update run's stages reached
+// Completed in the same order they're defined, with no stage starting
+// before the previous one completed.
+func VerifyProgression(status armcontainerservicefleet.UpdateRunStatus) []Violation {
+	var violations []Violation
+	var prevCompleted *armcontainerservicefleet.UpdateStageStatus
+
+	for _, stage := range status.Stages {
+		if stage.Status == nil || stage.Status.State == nil {
+			violations = append(violations, Violation{Message: fmt.Sprintf("stage %q has no recorded status", derefStr(stage.Name))})
+			continue
+		}
+		if *stage.Status.State != armcontainerservicefleet.UpdateStateCompleted {
+			violations = append(violations, Violation{Message: fmt.Sprintf("stage %q did not complete (state=%s)", derefStr(stage.Name), *stage.Status.State)})
+			continue
+		}
+		if prevCompleted != nil && prevCompleted.Status.CompletedTime != nil && stage.Status.StartTime != nil {
+			if stage.Status.StartTime.Before(*prevCompleted.Status.CompletedTime) {
+				violations = append(violations, Violation{Message: fmt.Sprintf("stage %q started before stage %q completed", derefStr(stage.Name), derefStr(prevCompleted.Name))})
+			}
+		}
+		prevCompleted = stage
+	}
+	return violations
+}
+
+func derefStr(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
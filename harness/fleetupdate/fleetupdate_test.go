@@ -0,0 +1,117 @@
+package fleetupdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservicefleet/armcontainerservicefleet"
+	"github.com/stretchr/testify/assert"
+)
+
+func strPtr(s string) *string { return &s }
+
+func fleetMember(clusterID, group string, state armcontainerservicefleet.FleetMemberProvisioningState) *armcontainerservicefleet.FleetMember {
+	return &armcontainerservicefleet.FleetMember{
+		Properties: &armcontainerservicefleet.FleetMemberProperties{
+			ClusterResourceID: strPtr(clusterID),
+			Group:             strPtr(group),
+			ProvisioningState: &state,
+		},
+	}
+}
+
+func TestVerifyMembershipFlagsUnjoinedCluster(t *testing.T) {
+	expected := []ExpectedMember{{ClusterResourceID: "/subscriptions/x/payments", Group: "payments"}}
+	violations := VerifyMembership(nil, expected)
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyMembershipFlagsWrongGroup(t *testing.T) {
+	members := []*armcontainerservicefleet.FleetMember{
+		fleetMember("/subscriptions/x/payments", "wrong-group", armcontainerservicefleet.FleetMemberProvisioningStateSucceeded),
+	}
+	expected := []ExpectedMember{{ClusterResourceID: "/subscriptions/x/payments", Group: "payments"}}
+	violations := VerifyMembership(members, expected)
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyMembershipPassesOnMatch(t *testing.T) {
+	members := []*armcontainerservicefleet.FleetMember{
+		fleetMember("/subscriptions/x/payments", "payments", armcontainerservicefleet.FleetMemberProvisioningStateSucceeded),
+	}
+	expected := []ExpectedMember{{ClusterResourceID: "/subscriptions/x/payments", Group: "payments"}}
+	assert.Empty(t, VerifyMembership(members, expected))
+}
+
+func TestVerifyStrategyFlagsStageCountMismatch(t *testing.T) {
+	strategy := armcontainerservicefleet.UpdateRunStrategy{
+		Stages: []*armcontainerservicefleet.UpdateStage{{Name: strPtr("canary")}},
+	}
+	violations := VerifyStrategy(strategy, []ExpectedStage{{Name: "canary"}, {Name: "prod"}})
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyStrategyFlagsGroupMismatch(t *testing.T) {
+	strategy := armcontainerservicefleet.UpdateRunStrategy{
+		Stages: []*armcontainerservicefleet.UpdateStage{
+			{Name: strPtr("prod"), Groups: []*armcontainerservicefleet.UpdateGroup{{Name: strPtr("checkout")}}},
+		},
+	}
+	violations := VerifyStrategy(strategy, []ExpectedStage{{Name: "prod", Groups: []string{"payments"}}})
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyStrategyPassesOnMatch(t *testing.T) {
+	strategy := armcontainerservicefleet.UpdateRunStrategy{
+		Stages: []*armcontainerservicefleet.UpdateStage{
+			{Name: strPtr("canary"), Groups: []*armcontainerservicefleet.UpdateGroup{{Name: strPtr("checkout")}}},
+			{Name: strPtr("prod"), Groups: []*armcontainerservicefleet.UpdateGroup{{Name: strPtr("payments")}}},
+		},
+	}
+	expected := []ExpectedStage{
+		{Name: "canary", Groups: []string{"checkout"}},
+		{Name: "prod", Groups: []string{"payments"}},
+	}
+	assert.Empty(t, VerifyStrategy(strategy, expected))
+}
+
+func TestVerifyProgressionFlagsIncompleteStage(t *testing.T) {
+	running := armcontainerservicefleet.UpdateStateRunning
+	status := armcontainerservicefleet.UpdateRunStatus{
+		Stages: []*armcontainerservicefleet.UpdateStageStatus{
+			{Name: strPtr("canary"), Status: &armcontainerservicefleet.UpdateStatus{State: &running}},
+		},
+	}
+	violations := VerifyProgression(status)
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyProgressionFlagsOutOfOrderStage(t *testing.T) {
+	completed := armcontainerservicefleet.UpdateStateCompleted
+	firstDone := time.Date(2026, 8, 9, 10, 0, 0, 0, time.UTC)
+	secondStart := firstDone.Add(-time.Minute)
+
+	status := armcontainerservicefleet.UpdateRunStatus{
+		Stages: []*armcontainerservicefleet.UpdateStageStatus{
+			{Name: strPtr("canary"), Status: &armcontainerservicefleet.UpdateStatus{State: &completed, CompletedTime: &firstDone}},
+			{Name: strPtr("prod"), Status: &armcontainerservicefleet.UpdateStatus{State: &completed, StartTime: &secondStart, CompletedTime: &firstDone}},
+		},
+	}
+	violations := VerifyProgression(status)
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyProgressionPassesForInOrderCompletion(t *testing.T) {
+	completed := armcontainerservicefleet.UpdateStateCompleted
+	firstDone := time.Date(2026, 8, 9, 10, 0, 0, 0, time.UTC)
+	secondStart := firstDone.Add(time.Minute)
+	secondDone := secondStart.Add(5 * time.Minute)
+
+	status := armcontainerservicefleet.UpdateRunStatus{
+		Stages: []*armcontainerservicefleet.UpdateStageStatus{
+			{Name: strPtr("canary"), Status: &armcontainerservicefleet.UpdateStatus{State: &completed, CompletedTime: &firstDone}},
+			{Name: strPtr("prod"), Status: &armcontainerservicefleet.UpdateStatus{State: &completed, StartTime: &secondStart, CompletedTime: &secondDone}},
+		},
+	}
+	assert.Empty(t, VerifyProgression(status))
+}
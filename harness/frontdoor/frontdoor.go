@@ -0,0 +1,170 @@
+// Package frontdoor verifies the global entrypoint (Azure Front Door
+// Standard/Premium) is wired the way this module expects: origins point at
+// the private link/internal endpoints for the regional AKS ingress, a WAF
+// policy is attached, health probes target the right path, and custom
+// domains have a validated certificate. Mirrors the verification style of
+// harness/plslink for the AKS-adjacent private endpoint.
+package frontdoor
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cdn/armcdn"
+)
+
+// ExpectedOrigin is a single origin this module expects an AFD origin group
+// to load-balance across.
+type ExpectedOrigin struct {
+	HostName        string
+	Weight          int32
+	EnforceCertName bool
+}
+
+// ExpectedConfig is what a compliant Front Door profile must look like for
+// a given endpoint.
+type ExpectedConfig struct {
+	Origins           []ExpectedOrigin
+	ProbePath         string
+	ProbeProtocol     armcdn.ProbeProtocol
+	WAFPolicyAttached bool
+	CustomDomains     []string
+}
+
+// Violation is a single mismatch between the live Front Door configuration
+// and ExpectedConfig.
+type Violation struct {
+	Message string
+}
+
+// VerifyOrigins checks that origins contains exactly the hosts, weights and
+// cert-check settings ExpectedConfig requires, catching drift such as an
+// origin left pointing at a public endpoint after a private link migration.
+func VerifyOrigins(origins []*armcdn.AFDOrigin, expected ExpectedConfig) []Violation {
+	byHost := make(map[string]*armcdn.AFDOrigin, len(origins))
+	for _, o := range origins {
+		if o.Properties == nil || o.Properties.HostName == nil {
+			continue
+		}
+		byHost[*o.Properties.HostName] = o
+	}
+
+	var violations []Violation
+	for _, exp := range expected.Origins {
+		origin, ok := byHost[exp.HostName]
+		if !ok {
+			violations = append(violations, Violation{Message: fmt.Sprintf("expected origin %q not found", exp.HostName)})
+			continue
+		}
+		props := origin.Properties
+		if props.Weight == nil || *props.Weight != exp.Weight {
+			violations = append(violations, Violation{Message: fmt.Sprintf("origin %q has weight %v, expected %d", exp.HostName, deref(props.Weight), exp.Weight)})
+		}
+		if props.EnforceCertificateNameCheck == nil || *props.EnforceCertificateNameCheck != exp.EnforceCertName {
+			violations = append(violations, Violation{Message: fmt.Sprintf("origin %q enforce-cert-name-check is %v, expected %v", exp.HostName, derefBool(props.EnforceCertificateNameCheck), exp.EnforceCertName)})
+		}
+	}
+	wanted := make(map[string]bool, len(expected.Origins))
+	for _, exp := range expected.Origins {
+		wanted[exp.HostName] = true
+	}
+	for _, o := range origins {
+		if o.Properties != nil && o.Properties.HostName != nil && !wanted[*o.Properties.HostName] {
+			violations = append(violations, Violation{Message: fmt.Sprintf("origin %q is not in the expected origin list", *o.Properties.HostName)})
+		}
+	}
+	return violations
+}
+
+// VerifyHealthProbe checks the origin group's health probe path and
+// protocol match ExpectedConfig.
+func VerifyHealthProbe(probe *armcdn.HealthProbeParameters, expected ExpectedConfig) []Violation {
+	if probe == nil {
+		return []Violation{{Message: "origin group has no health probe configured"}}
+	}
+	var violations []Violation
+	if probe.ProbePath == nil || *probe.ProbePath != expected.ProbePath {
+		violations = append(violations, Violation{Message: fmt.Sprintf("health probe path is %v, expected %q", derefStr(probe.ProbePath), expected.ProbePath)})
+	}
+	if probe.ProbeProtocol == nil || *probe.ProbeProtocol != expected.ProbeProtocol {
+		violations = append(violations, Violation{Message: fmt.Sprintf("health probe protocol is %v, expected %q", derefProtocol(probe.ProbeProtocol), expected.ProbeProtocol)})
+	}
+	return violations
+}
+
+// VerifyWAFAttached checks that at least one WAF security policy is
+// associated with the profile when ExpectedConfig requires one.
+func VerifyWAFAttached(policies []*armcdn.SecurityPolicy, expected ExpectedConfig) []Violation {
+	if !expected.WAFPolicyAttached {
+		return nil
+	}
+	for _, p := range policies {
+		if p.Properties == nil {
+			continue
+		}
+		if waf, ok := p.Properties.Parameters.(*armcdn.SecurityPolicyWebApplicationFirewallParameters); ok && waf.WafPolicy != nil {
+			return nil
+		}
+	}
+	return []Violation{{Message: "no WAF policy is attached to the Front Door profile"}}
+}
+
+// VerifyCustomDomains checks every domain in ExpectedConfig is present and
+// has an approved (valid) certificate/domain validation state.
+func VerifyCustomDomains(domains []*armcdn.AFDDomain, expected ExpectedConfig) []Violation {
+	byHost := make(map[string]*armcdn.AFDDomain, len(domains))
+	for _, d := range domains {
+		if d.Properties == nil || d.Properties.HostName == nil {
+			continue
+		}
+		byHost[*d.Properties.HostName] = d
+	}
+
+	var violations []Violation
+	for _, host := range expected.CustomDomains {
+		domain, ok := byHost[host]
+		if !ok {
+			violations = append(violations, Violation{Message: fmt.Sprintf("expected custom domain %q not found", host)})
+			continue
+		}
+		state := domain.Properties.DomainValidationState
+		if state == nil || *state != armcdn.DomainValidationStateApproved {
+			violations = append(violations, Violation{Message: fmt.Sprintf("custom domain %q validation state is %v, expected Approved", host, derefState(state))})
+		}
+	}
+	return violations
+}
+
+func deref(v *int32) int32 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func derefBool(v *bool) bool {
+	if v == nil {
+		return false
+	}
+	return *v
+}
+
+func derefStr(v *string) string {
+	if v == nil {
+		return "<nil>"
+	}
+	return *v
+}
+
+func derefProtocol(v *armcdn.ProbeProtocol) string {
+	if v == nil {
+		return "<nil>"
+	}
+	return string(*v)
+}
+
+func derefState(v *armcdn.DomainValidationState) string {
+	if v == nil {
+		return "<nil>"
+	}
+	return string(*v)
+}
@@ -0,0 +1,81 @@
+package frontdoor
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cdn/armcdn"
+	"github.com/stretchr/testify/assert"
+)
+
+func strPtr(s string) *string { return &s }
+func i32Ptr(i int32) *int32   { return &i }
+func boolPtr(b bool) *bool    { return &b }
+
+func TestVerifyOriginsFlagsWrongWeightAndMissingOrigin(t *testing.T) {
+	origins := []*armcdn.AFDOrigin{
+		{Properties: &armcdn.AFDOriginProperties{
+			HostName:                    strPtr("aks-ingress.privatelink.internal"),
+			Weight:                      i32Ptr(500),
+			EnforceCertificateNameCheck: boolPtr(true),
+		}},
+	}
+	expected := ExpectedConfig{Origins: []ExpectedOrigin{
+		{HostName: "aks-ingress.privatelink.internal", Weight: 1000, EnforceCertName: true},
+		{HostName: "missing.privatelink.internal", Weight: 1000, EnforceCertName: true},
+	}}
+
+	violations := VerifyOrigins(origins, expected)
+	assert.Len(t, violations, 2)
+}
+
+func TestVerifyOriginsCleanWhenMatching(t *testing.T) {
+	origins := []*armcdn.AFDOrigin{
+		{Properties: &armcdn.AFDOriginProperties{
+			HostName:                    strPtr("aks-ingress.privatelink.internal"),
+			Weight:                      i32Ptr(1000),
+			EnforceCertificateNameCheck: boolPtr(true),
+		}},
+	}
+	expected := ExpectedConfig{Origins: []ExpectedOrigin{
+		{HostName: "aks-ingress.privatelink.internal", Weight: 1000, EnforceCertName: true},
+	}}
+
+	assert.Empty(t, VerifyOrigins(origins, expected))
+}
+
+func TestVerifyHealthProbeFlagsWrongPath(t *testing.T) {
+	protocol := armcdn.ProbeProtocolHTTPS
+	probe := &armcdn.HealthProbeParameters{
+		ProbePath:     strPtr("/wrong"),
+		ProbeProtocol: &protocol,
+	}
+	expected := ExpectedConfig{ProbePath: "/healthz", ProbeProtocol: armcdn.ProbeProtocolHTTPS}
+
+	violations := VerifyHealthProbe(probe, expected)
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyWAFAttachedFlagsMissingPolicy(t *testing.T) {
+	violations := VerifyWAFAttached(nil, ExpectedConfig{WAFPolicyAttached: true})
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyWAFAttachedPassesWhenPolicyPresent(t *testing.T) {
+	policies := []*armcdn.SecurityPolicy{
+		{Properties: &armcdn.SecurityPolicyProperties{
+			Parameters: &armcdn.SecurityPolicyWebApplicationFirewallParameters{
+				WafPolicy: &armcdn.ResourceReference{ID: strPtr("/subscriptions/x/wafPolicies/y")},
+			},
+		}},
+	}
+	assert.Empty(t, VerifyWAFAttached(policies, ExpectedConfig{WAFPolicyAttached: true}))
+}
+
+func TestVerifyCustomDomainsFlagsUnapprovedCert(t *testing.T) {
+	pending := armcdn.DomainValidationStatePending
+	domains := []*armcdn.AFDDomain{
+		{Properties: &armcdn.AFDDomainProperties{HostName: strPtr("aks.example.com"), DomainValidationState: &pending}},
+	}
+	violations := VerifyCustomDomains(domains, ExpectedConfig{CustomDomains: []string{"aks.example.com"}})
+	assert.Len(t, violations, 1)
+}
@@ -0,0 +1,157 @@
+// Package gemdrift checks the Terraspace gem version actually installed on
+// a runner (from Gemfile.lock, or a live `gem list`/`terraspace version`
+// capture) against the version this repo pins in the Gemfile. Terraspace
+// minor upgrades have changed CLI output formats our parsers depend on, so
+// a silent drift here is a CI break waiting to happen — this check exists
+// to surface that drift with a clear remediation message instead of a
+// confusing downstream parse failure.
+package gemdrift
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PinnedGem is one `gem "name", "constraint"` line from the Gemfile.
+type PinnedGem struct {
+	Name       string
+	Constraint string
+}
+
+var gemfilePinPattern = regexp.MustCompile(`(?m)^\s*gem\s+"([^"]+)"\s*,\s*"([^"]+)"`)
+
+// ParseGemfilePins extracts every version-pinned gem declaration from a
+// Gemfile's contents. Gems declared without a version constraint are
+// omitted; there is nothing to check drift against.
+func ParseGemfilePins(data []byte) []PinnedGem {
+	var pins []PinnedGem
+	for _, m := range gemfilePinPattern.FindAllStringSubmatch(string(data), -1) {
+		pins = append(pins, PinnedGem{Name: m[1], Constraint: m[2]})
+	}
+	return pins
+}
+
+// LockedGem is one resolved `name (version)` entry from a Gemfile.lock's
+// GEM specs section.
+type LockedGem struct {
+	Name    string
+	Version string
+}
+
+var lockSpecPattern = regexp.MustCompile(`^\s{4}([a-zA-Z0-9_-]+) \(([^)]+)\)`)
+
+// ParseGemfileLock extracts every resolved gem and version from a
+// Gemfile.lock's GEM specs section.
+func ParseGemfileLock(data []byte) []LockedGem {
+	var locked []LockedGem
+	for _, line := range strings.Split(string(data), "\n") {
+		m := lockSpecPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		locked = append(locked, LockedGem{Name: m[1], Version: m[2]})
+	}
+	return locked
+}
+
+// Violation is a single pinned-vs-installed gem version mismatch.
+type Violation struct {
+	Message string
+}
+
+// CheckDrift compares each pinned gem's constraint against the resolved
+// version locked (or actually installed) on the runner, returning a
+// Violation for every gem that's missing or fails its constraint.
+func CheckDrift(pins []PinnedGem, locked []LockedGem) []Violation {
+	byName := make(map[string]string, len(locked))
+	for _, l := range locked {
+		byName[l.Name] = l.Version
+	}
+
+	var violations []Violation
+	for _, pin := range pins {
+		version, ok := byName[pin.Name]
+		if !ok {
+			violations = append(violations, Violation{Message: fmt.Sprintf(
+				"gem %q is pinned to %q but has no resolved version on this runner; run bundle install", pin.Name, pin.Constraint)})
+			continue
+		}
+		if satisfies, err := satisfiesConstraint(version, pin.Constraint); err != nil {
+			violations = append(violations, Violation{Message: fmt.Sprintf(
+				"gem %q: %s", pin.Name, err)})
+		} else if !satisfies {
+			violations = append(violations, Violation{Message: fmt.Sprintf(
+				"gem %q is installed at %q, which does not satisfy the pinned constraint %q; re-run bundle install or update the Gemfile pin", pin.Name, version, pin.Constraint)})
+		}
+	}
+	return violations
+}
+
+// satisfiesConstraint supports the two RubyGems constraint forms this
+// repo's Gemfile actually uses: an exact version ("2.2.3") and the
+// pessimistic operator ("~> 2.2.0"), which allows any version greater than
+// or equal to the given version but less than the next value obtained by
+// incrementing its second-to-last segment.
+func satisfiesConstraint(version, constraint string) (bool, error) {
+	constraint = strings.TrimSpace(constraint)
+	if strings.HasPrefix(constraint, "~>") {
+		floor := strings.TrimSpace(strings.TrimPrefix(constraint, "~>"))
+		return satisfiesPessimistic(version, floor)
+	}
+	return version == constraint, nil
+}
+
+func satisfiesPessimistic(version, floor string) (bool, error) {
+	v, err := parseVersion(version)
+	if err != nil {
+		return false, fmt.Errorf("installed version %q: %w", version, err)
+	}
+	f, err := parseVersion(floor)
+	if err != nil {
+		return false, fmt.Errorf("constraint floor %q: %w", floor, err)
+	}
+	if compareVersions(v, f) < 0 {
+		return false, nil
+	}
+
+	ceiling := append([]int{}, f...)
+	if len(ceiling) < 2 {
+		ceiling = append(ceiling, 0)
+	}
+	ceiling[len(ceiling)-2]++
+	for i := len(ceiling) - 1; i < len(ceiling); i++ {
+		ceiling[i] = 0
+	}
+	return compareVersions(v, ceiling) < 0, nil
+}
+
+func parseVersion(s string) ([]int, error) {
+	parts := strings.Split(s, ".")
+	nums := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("not a numeric version: %q", s)
+		}
+		nums[i] = n
+	}
+	return nums, nil
+}
+
+func compareVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var av, bv int
+		if i < len(a) {
+			av = a[i]
+		}
+		if i < len(b) {
+			bv = b[i]
+		}
+		if av != bv {
+			return av - bv
+		}
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package gemdrift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gemfile = `source "https://rubygems.org"
+
+gem "terraspace", "~> 2.2.0"
+gem "terraspace_plugin_aws"
+gem "rspec-terraspace", "1.4.0"
+`
+
+const gemfileLock = `GEM
+  remote: https://rubygems.org/
+  specs:
+    rspec-terraspace (1.4.0)
+    terraspace (2.2.3)
+    terraspace_plugin_aws (2.0.1)
+
+PLATFORMS
+  x86_64-linux
+
+DEPENDENCIES
+  terraspace (~> 2.2.0)
+`
+
+func TestParseGemfilePinsIgnoresUnpinnedGems(t *testing.T) {
+	pins := ParseGemfilePins([]byte(gemfile))
+	require.Len(t, pins, 2)
+	assert.Equal(t, PinnedGem{Name: "terraspace", Constraint: "~> 2.2.0"}, pins[0])
+	assert.Equal(t, PinnedGem{Name: "rspec-terraspace", Constraint: "1.4.0"}, pins[1])
+}
+
+func TestParseGemfileLockExtractsResolvedSpecs(t *testing.T) {
+	locked := ParseGemfileLock([]byte(gemfileLock))
+	require.Len(t, locked, 3)
+	assert.Contains(t, locked, LockedGem{Name: "terraspace", Version: "2.2.3"})
+}
+
+func TestCheckDriftPassesWhenWithinPessimisticConstraint(t *testing.T) {
+	pins := ParseGemfilePins([]byte(gemfile))
+	locked := ParseGemfileLock([]byte(gemfileLock))
+
+	assert.Empty(t, CheckDrift(pins, locked))
+}
+
+func TestCheckDriftFlagsMinorVersionDriftPastConstraint(t *testing.T) {
+	pins := []PinnedGem{{Name: "terraspace", Constraint: "~> 2.2.0"}}
+	locked := []LockedGem{{Name: "terraspace", Version: "2.3.0"}}
+
+	violations := CheckDrift(pins, locked)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "does not satisfy")
+}
+
+func TestCheckDriftFlagsMissingResolvedVersion(t *testing.T) {
+	pins := []PinnedGem{{Name: "terraspace", Constraint: "~> 2.2.0"}}
+
+	violations := CheckDrift(pins, nil)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "bundle install")
+}
+
+func TestCheckDriftFlagsExactVersionMismatch(t *testing.T) {
+	pins := []PinnedGem{{Name: "rspec-terraspace", Constraint: "1.4.0"}}
+	locked := []LockedGem{{Name: "rspec-terraspace", Version: "1.5.0"}}
+
+	violations := CheckDrift(pins, locked)
+	require.Len(t, violations, 1)
+}
+
+func TestCheckDriftAllowsPatchUpgradeWithinConstraint(t *testing.T) {
+	pins := []PinnedGem{{Name: "terraspace", Constraint: "~> 2.2.0"}}
+	locked := []LockedGem{{Name: "terraspace", Version: "2.2.9"}}
+
+	assert.Empty(t, CheckDrift(pins, locked))
+}
+
+func TestCheckDriftFlagsVersionBelowFloor(t *testing.T) {
+	pins := []PinnedGem{{Name: "terraspace", Constraint: "~> 2.2.0"}}
+	locked := []LockedGem{{Name: "terraspace", Version: "2.1.9"}}
+
+	violations := CheckDrift(pins, locked)
+	require.Len(t, violations, 1)
+}
@@ -0,0 +1,103 @@
+// Package goldenimage diffs a normalized export of the deployed test
+// environment against a stored baseline, catching configuration applied
+// outside Terraform — a portal edit, a policy remediation, a manual
+// az cli fix during an incident — that `terraform plan` alone won't show
+// for attributes Terraform doesn't manage or hasn't re-read since.
+package goldenimage
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// volatileKeys are ARM bookkeeping fields that change on every export
+// regardless of actual configuration drift, and must be stripped before
+// comparing two exports.
+var volatileKeys = map[string]bool{
+	"provisioningState": true,
+	"etag":              true,
+	"resourceGuid":      true,
+	"changedTime":       true,
+	"createdTime":       true,
+}
+
+// ExportedResource is one resource from the resources export API, before
+// normalization.
+type ExportedResource struct {
+	ID         string
+	Type       string
+	Properties map[string]any
+}
+
+// Baseline is a normalized export, keyed by resource ID, ready to diff or
+// store as the golden image.
+type Baseline map[string]ExportedResource
+
+// Normalize strips volatile fields from each resource's properties
+// (recursively) so two exports of an unchanged environment compare equal.
+func Normalize(resources []ExportedResource) Baseline {
+	baseline := make(Baseline, len(resources))
+	for _, r := range resources {
+		baseline[r.ID] = ExportedResource{ID: r.ID, Type: r.Type, Properties: stripVolatile(r.Properties)}
+	}
+	return baseline
+}
+
+func stripVolatile(props map[string]any) map[string]any {
+	out := make(map[string]any, len(props))
+	for k, v := range props {
+		if volatileKeys[k] {
+			continue
+		}
+		if nested, ok := v.(map[string]any); ok {
+			out[k] = stripVolatile(nested)
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// DriftKind classifies how a resource diverged from the baseline.
+type DriftKind string
+
+const (
+	DriftAdded   DriftKind = "added-outside-terraform"
+	DriftRemoved DriftKind = "removed-outside-terraform"
+	DriftChanged DriftKind = "changed-outside-terraform"
+)
+
+// Drift is a single resource that diverged between baseline and current.
+type Drift struct {
+	ResourceID string
+	Kind       DriftKind
+	Detail     string
+}
+
+// Diff compares a stored baseline against a freshly normalized current
+// export and returns every resource that was added, removed, or changed
+// outside of what Terraform applied, sorted by resource ID for stable
+// output.
+func Diff(baseline, current Baseline) []Drift {
+	var drifts []Drift
+
+	for id, base := range baseline {
+		cur, ok := current[id]
+		if !ok {
+			drifts = append(drifts, Drift{ResourceID: id, Kind: DriftRemoved, Detail: fmt.Sprintf("%s (%s) is in the baseline but no longer exists", id, base.Type)})
+			continue
+		}
+		if !reflect.DeepEqual(base.Properties, cur.Properties) {
+			drifts = append(drifts, Drift{ResourceID: id, Kind: DriftChanged, Detail: fmt.Sprintf("%s (%s) properties differ from the baseline", id, base.Type)})
+		}
+	}
+	for id, cur := range current {
+		if _, ok := baseline[id]; !ok {
+			drifts = append(drifts, Drift{ResourceID: id, Kind: DriftAdded, Detail: fmt.Sprintf("%s (%s) exists but is not in the baseline", id, cur.Type)})
+		}
+	}
+
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i].ResourceID < drifts[j].ResourceID })
+	return drifts
+}
@@ -0,0 +1,79 @@
+package goldenimage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeStripsVolatileFieldsRecursively(t *testing.T) {
+	baseline := Normalize([]ExportedResource{{
+		ID:   "res1",
+		Type: "Microsoft.Network/networkSecurityGroups",
+		Properties: map[string]any{
+			"provisioningState": "Succeeded",
+			"securityRules": map[string]any{
+				"etag": "abc123",
+				"name": "allow-https",
+			},
+		},
+	}})
+
+	require.Contains(t, baseline, "res1")
+	_, hasProvisioningState := baseline["res1"].Properties["provisioningState"]
+	assert.False(t, hasProvisioningState)
+
+	rules := baseline["res1"].Properties["securityRules"].(map[string]any)
+	_, hasEtag := rules["etag"]
+	assert.False(t, hasEtag)
+	assert.Equal(t, "allow-https", rules["name"])
+}
+
+func TestDiffIsEmptyForUnchangedEnvironment(t *testing.T) {
+	resources := []ExportedResource{{ID: "res1", Type: "Microsoft.Network/networkSecurityGroups", Properties: map[string]any{"name": "allow-https"}}}
+	baseline := Normalize(resources)
+	current := Normalize(resources)
+
+	assert.Empty(t, Diff(baseline, current))
+}
+
+func TestDiffFlagsResourceChangedOutsideTerraform(t *testing.T) {
+	baseline := Normalize([]ExportedResource{{ID: "res1", Type: "Microsoft.Network/networkSecurityGroups", Properties: map[string]any{"priority": 100}}})
+	current := Normalize([]ExportedResource{{ID: "res1", Type: "Microsoft.Network/networkSecurityGroups", Properties: map[string]any{"priority": 4096}}})
+
+	drifts := Diff(baseline, current)
+	require.Len(t, drifts, 1)
+	assert.Equal(t, DriftChanged, drifts[0].Kind)
+}
+
+func TestDiffFlagsResourceRemovedFromBaseline(t *testing.T) {
+	baseline := Normalize([]ExportedResource{{ID: "res1", Type: "Microsoft.Network/networkSecurityGroups"}})
+	current := Normalize(nil)
+
+	drifts := Diff(baseline, current)
+	require.Len(t, drifts, 1)
+	assert.Equal(t, DriftRemoved, drifts[0].Kind)
+}
+
+func TestDiffFlagsResourceAddedOutsideBaseline(t *testing.T) {
+	baseline := Normalize(nil)
+	current := Normalize([]ExportedResource{{ID: "res1", Type: "Microsoft.Network/networkSecurityGroups"}})
+
+	drifts := Diff(baseline, current)
+	require.Len(t, drifts, 1)
+	assert.Equal(t, DriftAdded, drifts[0].Kind)
+}
+
+func TestDiffSortsDriftsByResourceID(t *testing.T) {
+	baseline := Normalize(nil)
+	current := Normalize([]ExportedResource{
+		{ID: "res2", Type: "t"},
+		{ID: "res1", Type: "t"},
+	})
+
+	drifts := Diff(baseline, current)
+	require.Len(t, drifts, 2)
+	assert.Equal(t, "res1", drifts[0].ResourceID)
+	assert.Equal(t, "res2", drifts[1].ResourceID)
+}
@@ -0,0 +1,26 @@
+package imageseed
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerregistry/armcontainerregistry"
+)
+
+// PollingImporter adapts a real armcontainerregistry.RegistriesClient to the
+// Importer interface by polling the long-running import to completion
+// before returning.
+type PollingImporter struct {
+	Client *armcontainerregistry.RegistriesClient
+}
+
+func (p PollingImporter) BeginImportImage(ctx context.Context, resourceGroupName, registryName string, parameters armcontainerregistry.ImportImageParameters, options *armcontainerregistry.RegistriesClientBeginImportImageOptions) (*armcontainerregistry.RegistriesClientImportImageResponse, error) {
+	poller, err := p.Client.BeginImportImage(ctx, resourceGroupName, registryName, parameters, options)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := poller.PollUntilDone(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,37 @@
+// Package imageseed pre-seeds the test Azure Container Registry with the
+// public images the Kubernetes-level test suite depends on, so probes
+// against network-restricted (private) clusters don't fail trying to reach
+// Docker Hub over the internet.
+package imageseed
+
+// Image identifies a single public image that must be available in the
+// test ACR before verification runs.
+type Image struct {
+	// Source is the fully qualified source reference, e.g.
+	// "docker.io/library/nginx:1.27".
+	Source string
+	// Repository is the repository name the image is imported as within
+	// the target ACR, e.g. "library/nginx". Defaults to Source unchanged
+	// (registry host and any tag included) when empty.
+	Repository string
+	// Tag is the tag applied in the target ACR. Defaults to "latest" when
+	// empty; a tag in Source is not used for the default.
+	Tag string
+}
+
+// Manifest is the list of images required by the test suite.
+type Manifest struct {
+	Images []Image
+}
+
+// DefaultManifest returns the baseline set of images used by the
+// Kubernetes-level probes (nginx, busybox, curl).
+func DefaultManifest() Manifest {
+	return Manifest{
+		Images: []Image{
+			{Source: "docker.io/library/nginx:1.27", Repository: "library/nginx", Tag: "1.27"},
+			{Source: "docker.io/library/busybox:1.36", Repository: "library/busybox", Tag: "1.36"},
+			{Source: "docker.io/curlimages/curl:8.9.1", Repository: "curlimages/curl", Tag: "8.9.1"},
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package imageseed
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerregistry/armcontainerregistry"
+)
+
+// Importer is the subset of armcontainerregistry.RegistriesClient used by
+// Seed. It exists so tests can supply a fake without standing up a real
+// registry client.
+type Importer interface {
+	BeginImportImage(ctx context.Context, resourceGroupName, registryName string, parameters armcontainerregistry.ImportImageParameters, options *armcontainerregistry.RegistriesClientBeginImportImageOptions) (*armcontainerregistry.RegistriesClientImportImageResponse, error)
+}
+
+// Seed imports every image in manifest into the registryName ACR within
+// resourceGroupName, skipping the network path entirely by importing
+// registry-to-registry via the ARM control plane. It is safe to call
+// repeatedly; re-importing an existing tag is a no-op on the Azure side.
+func Seed(ctx context.Context, importer Importer, resourceGroupName, registryName string, manifest Manifest) error {
+	for _, img := range manifest.Images {
+		repo := img.Repository
+		if repo == "" {
+			repo = img.Source
+		}
+		tag := img.Tag
+		if tag == "" {
+			tag = "latest"
+		}
+		targetTag := fmt.Sprintf("%s:%s", repo, tag)
+		source := img.Source
+
+		params := armcontainerregistry.ImportImageParameters{
+			Source: &armcontainerregistry.ImportSource{
+				SourceImage: &source,
+			},
+			TargetTags: []*string{&targetTag},
+			Mode:       to(armcontainerregistry.ImportModeForce),
+		}
+
+		if _, err := importer.BeginImportImage(ctx, resourceGroupName, registryName, params, nil); err != nil {
+			return fmt.Errorf("imageseed: import %s into %s/%s: %w", img.Source, registryName, targetTag, err)
+		}
+	}
+	return nil
+}
+
+func to[T any](v T) *T { return &v }
@@ -0,0 +1,41 @@
+package imageseed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerregistry/armcontainerregistry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeImporter struct {
+	calls []armcontainerregistry.ImportImageParameters
+}
+
+func (f *fakeImporter) BeginImportImage(ctx context.Context, resourceGroupName, registryName string, parameters armcontainerregistry.ImportImageParameters, options *armcontainerregistry.RegistriesClientBeginImportImageOptions) (*armcontainerregistry.RegistriesClientImportImageResponse, error) {
+	f.calls = append(f.calls, parameters)
+	return &armcontainerregistry.RegistriesClientImportImageResponse{}, nil
+}
+
+func TestSeedImportsEveryImageInManifest(t *testing.T) {
+	fake := &fakeImporter{}
+	manifest := DefaultManifest()
+
+	err := Seed(context.Background(), fake, "rg-test", "devaksacrtest", manifest)
+	require.NoError(t, err)
+
+	require.Len(t, fake.calls, len(manifest.Images))
+	assert.Equal(t, "docker.io/library/nginx:1.27", *fake.calls[0].Source.SourceImage)
+	assert.Equal(t, "library/nginx:1.27", *fake.calls[0].TargetTags[0])
+}
+
+func TestSeedDefaultsTagToLatestWhenUnset(t *testing.T) {
+	fake := &fakeImporter{}
+	manifest := Manifest{Images: []Image{{Source: "docker.io/library/alpine"}}}
+
+	require.NoError(t, Seed(context.Background(), fake, "rg-test", "devaksacrtest", manifest))
+
+	require.Len(t, fake.calls, 1)
+	assert.Equal(t, "docker.io/library/alpine:latest", *fake.calls[0].TargetTags[0])
+}
@@ -0,0 +1,129 @@
+// Package k8sdeprecation checks the manifests and rendered Helm charts
+// the addons stack deploys for Kubernetes API versions removed at or
+// before the cluster's target version (a pluto-style check), so a
+// version upgrade doesn't surface as workloads failing to apply.
+package k8sdeprecation
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DeprecatedAPI is a Kubernetes apiVersion/kind pair removed starting at
+// RemovedInVersion, with the apiVersion that replaces it.
+type DeprecatedAPI struct {
+	APIVersion       string
+	Kind             string
+	RemovedInVersion string // e.g. "1.25"
+	Replacement      string // e.g. "policy/v1"
+}
+
+// DefaultRules is the maintained set of Kubernetes API removals this
+// scanner checks for. Update it as new versions remove more APIs.
+var DefaultRules = []DeprecatedAPI{
+	{APIVersion: "policy/v1beta1", Kind: "PodSecurityPolicy", RemovedInVersion: "1.25", Replacement: "removed outright; use Pod Security Admission"},
+	{APIVersion: "batch/v1beta1", Kind: "CronJob", RemovedInVersion: "1.25", Replacement: "batch/v1"},
+	{APIVersion: "autoscaling/v2beta1", Kind: "HorizontalPodAutoscaler", RemovedInVersion: "1.25", Replacement: "autoscaling/v2"},
+	{APIVersion: "extensions/v1beta1", Kind: "Ingress", RemovedInVersion: "1.22", Replacement: "networking.k8s.io/v1"},
+	{APIVersion: "networking.k8s.io/v1beta1", Kind: "Ingress", RemovedInVersion: "1.22", Replacement: "networking.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "", RemovedInVersion: "1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+}
+
+// Manifest is the minimal shape read from one YAML document.
+type Manifest struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+}
+
+// ParseManifests splits a multi-document YAML file (a raw manifest or
+// `helm template` output) into individual manifests, skipping empty
+// documents.
+func ParseManifests(data []byte) ([]Manifest, error) {
+	var manifests []Manifest
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	for {
+		var m Manifest
+		err := decoder.Decode(&m)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("k8sdeprecation: parse manifest: %w", err)
+		}
+		if m.APIVersion == "" && m.Kind == "" {
+			continue
+		}
+		manifests = append(manifests, m)
+	}
+	return manifests, nil
+}
+
+// Finding is a single manifest using an API removed at or before
+// targetVersion.
+type Finding struct {
+	Manifest Manifest
+	Rule     DeprecatedAPI
+}
+
+// Check returns a Finding for every manifest whose apiVersion/kind was
+// removed at or before targetVersion.
+func Check(manifests []Manifest, targetVersion string, rules []DeprecatedAPI) ([]Finding, error) {
+	targetMajor, targetMinor, err := parseMinorVersion(targetVersion)
+	if err != nil {
+		return nil, fmt.Errorf("k8sdeprecation: %w", err)
+	}
+
+	var findings []Finding
+	for _, m := range manifests {
+		for _, rule := range rules {
+			if m.APIVersion != rule.APIVersion {
+				continue
+			}
+			if rule.Kind != "" && m.Kind != rule.Kind {
+				continue
+			}
+			removedMajor, removedMinor, err := parseMinorVersion(rule.RemovedInVersion)
+			if err != nil {
+				return nil, fmt.Errorf("k8sdeprecation: rule %s/%s: %w", rule.APIVersion, rule.Kind, err)
+			}
+			if !before(targetMajor, targetMinor, removedMajor, removedMinor) {
+				findings = append(findings, Finding{Manifest: m, Rule: rule})
+			}
+		}
+	}
+	return findings, nil
+}
+
+// before reports whether (targetMajor, targetMinor) is strictly before
+// (removedMajor, removedMinor).
+func before(targetMajor, targetMinor, removedMajor, removedMinor int) bool {
+	if targetMajor != removedMajor {
+		return targetMajor < removedMajor
+	}
+	return targetMinor < removedMinor
+}
+
+func parseMinorVersion(v string) (major, minor int, err error) {
+	v = strings.TrimPrefix(v, "v")
+	parts := strings.SplitN(v, ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("invalid Kubernetes version %q", v)
+	}
+	major, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid Kubernetes version %q: %w", v, err)
+	}
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid Kubernetes version %q: %w", v, err)
+	}
+	return major, minor, nil
+}
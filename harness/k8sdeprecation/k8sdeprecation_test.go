@@ -0,0 +1,79 @@
+package k8sdeprecation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleManifests = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: checkout
+---
+apiVersion: policy/v1beta1
+kind: PodSecurityPolicy
+metadata:
+  name: restricted
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: nightly-report
+`
+
+func TestParseManifestsSplitsMultiDocYAML(t *testing.T) {
+	manifests, err := ParseManifests([]byte(sampleManifests))
+	require.NoError(t, err)
+	require.Len(t, manifests, 3)
+	assert.Equal(t, "Deployment", manifests[0].Kind)
+	assert.Equal(t, "PodSecurityPolicy", manifests[1].Kind)
+	assert.Equal(t, "nightly-report", manifests[2].Metadata.Name)
+}
+
+func TestParseManifestsSkipsEmptyDocuments(t *testing.T) {
+	manifests, err := ParseManifests([]byte("---\n---\napiVersion: v1\nkind: ConfigMap\n"))
+	require.NoError(t, err)
+	require.Len(t, manifests, 1)
+	assert.Equal(t, "ConfigMap", manifests[0].Kind)
+}
+
+func TestCheckFlagsRemovedAPIAtTargetVersion(t *testing.T) {
+	manifests, err := ParseManifests([]byte(sampleManifests))
+	require.NoError(t, err)
+
+	findings, err := Check(manifests, "1.28", DefaultRules)
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "PodSecurityPolicy", findings[0].Manifest.Kind)
+	assert.Equal(t, "1.25", findings[0].Rule.RemovedInVersion)
+}
+
+func TestCheckPassesWhenTargetVersionPredatesRemoval(t *testing.T) {
+	manifests, err := ParseManifests([]byte(sampleManifests))
+	require.NoError(t, err)
+
+	findings, err := Check(manifests, "1.24", DefaultRules)
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
+
+func TestCheckFlagsAtExactRemovalVersion(t *testing.T) {
+	manifests, err := ParseManifests([]byte(`apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: nightly-report
+`))
+	require.NoError(t, err)
+
+	findings, err := Check(manifests, "1.25", DefaultRules)
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+}
+
+func TestCheckReturnsErrorForInvalidTargetVersion(t *testing.T) {
+	_, err := Check(nil, "not-a-version", DefaultRules)
+	assert.Error(t, err)
+}
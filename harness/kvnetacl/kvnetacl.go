@@ -0,0 +1,114 @@
+// Package kvnetacl asserts a Key Vault's network ACLs match an env's
+// expected configuration: default action Deny, only the expected subnets
+// and IP ranges allowed, and the expected trusted-services bypass
+// setting. Expected values are always supplied by the caller (loaded from
+// this env's own config), never hardcoded here, so the same check works
+// unmodified across every environment's Key Vault.
+package kvnetacl
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/keyvault/armkeyvault"
+)
+
+// Expected is the network ACL configuration a Key Vault should have,
+// sourced from this env's own config rather than hardcoded.
+type Expected struct {
+	DefaultAction    armkeyvault.NetworkRuleAction
+	Bypass           armkeyvault.NetworkRuleBypassOptions
+	AllowedSubnetIDs []string
+	AllowedIPRanges  []string
+}
+
+// Violation is a single Key Vault network ACL mismatch.
+type Violation struct {
+	Message string
+}
+
+// Verify compares actual against expected, returning every mismatch (nil
+// if compliant). An empty AllowedSubnetIDs/AllowedIPRanges list in
+// expected means the vault must allow none, so any unexpected rule is
+// flagged.
+func Verify(actual *armkeyvault.NetworkRuleSet, expected Expected) []Violation {
+	if actual == nil {
+		return []Violation{{Message: "key vault has no network ACLs configured; expected a Deny-by-default rule set"}}
+	}
+
+	var violations []Violation
+
+	if derefAction(actual.DefaultAction) != expected.DefaultAction {
+		violations = append(violations, Violation{Message: fmt.Sprintf(
+			"network ACL default action is %q, want %q", derefAction(actual.DefaultAction), expected.DefaultAction)})
+	}
+	if derefBypass(actual.Bypass) != expected.Bypass {
+		violations = append(violations, Violation{Message: fmt.Sprintf(
+			"network ACL bypass is %q, want %q", derefBypass(actual.Bypass), expected.Bypass)})
+	}
+
+	violations = append(violations, diffSubnets(actual.VirtualNetworkRules, expected.AllowedSubnetIDs)...)
+	violations = append(violations, diffIPRanges(actual.IPRules, expected.AllowedIPRanges)...)
+
+	return violations
+}
+
+func diffSubnets(actual []*armkeyvault.VirtualNetworkRule, expected []string) []Violation {
+	want := toSet(expected)
+	got := make(map[string]bool, len(actual))
+	for _, rule := range actual {
+		if rule == nil || rule.ID == nil {
+			continue
+		}
+		got[*rule.ID] = true
+	}
+	return diffSets("subnet", got, want)
+}
+
+func diffIPRanges(actual []*armkeyvault.IPRule, expected []string) []Violation {
+	want := toSet(expected)
+	got := make(map[string]bool, len(actual))
+	for _, rule := range actual {
+		if rule == nil || rule.Value == nil {
+			continue
+		}
+		got[*rule.Value] = true
+	}
+	return diffSets("IP range", got, want)
+}
+
+func diffSets(kind string, got, want map[string]bool) []Violation {
+	var violations []Violation
+	for v := range got {
+		if !want[v] {
+			violations = append(violations, Violation{Message: fmt.Sprintf("network ACL allows %s %s, which is not in the expected list", kind, v)})
+		}
+	}
+	for v := range want {
+		if !got[v] {
+			violations = append(violations, Violation{Message: fmt.Sprintf("network ACL is missing expected %s %s", kind, v)})
+		}
+	}
+	return violations
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+func derefAction(a *armkeyvault.NetworkRuleAction) armkeyvault.NetworkRuleAction {
+	if a == nil {
+		return ""
+	}
+	return *a
+}
+
+func derefBypass(b *armkeyvault.NetworkRuleBypassOptions) armkeyvault.NetworkRuleBypassOptions {
+	if b == nil {
+		return ""
+	}
+	return *b
+}
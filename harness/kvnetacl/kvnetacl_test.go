@@ -0,0 +1,83 @@
+package kvnetacl
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/keyvault/armkeyvault"
+	"github.com/stretchr/testify/assert"
+)
+
+func actionPtr(a armkeyvault.NetworkRuleAction) *armkeyvault.NetworkRuleAction { return &a }
+func bypassPtr(b armkeyvault.NetworkRuleBypassOptions) *armkeyvault.NetworkRuleBypassOptions {
+	return &b
+}
+func strPtr(s string) *string { return &s }
+
+func TestVerifyFailsWithoutNetworkACLs(t *testing.T) {
+	violations := Verify(nil, Expected{})
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyFlagsNonDenyDefaultAction(t *testing.T) {
+	actual := &armkeyvault.NetworkRuleSet{
+		DefaultAction: actionPtr(armkeyvault.NetworkRuleActionAllow),
+		Bypass:        bypassPtr(armkeyvault.NetworkRuleBypassOptionsNone),
+	}
+	violations := Verify(actual, Expected{DefaultAction: armkeyvault.NetworkRuleActionDeny, Bypass: armkeyvault.NetworkRuleBypassOptionsNone})
+	assert.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "default action")
+}
+
+func TestVerifyFlagsUnexpectedBypass(t *testing.T) {
+	actual := &armkeyvault.NetworkRuleSet{
+		DefaultAction: actionPtr(armkeyvault.NetworkRuleActionDeny),
+		Bypass:        bypassPtr(armkeyvault.NetworkRuleBypassOptionsAzureServices),
+	}
+	violations := Verify(actual, Expected{DefaultAction: armkeyvault.NetworkRuleActionDeny, Bypass: armkeyvault.NetworkRuleBypassOptionsNone})
+	assert.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "bypass")
+}
+
+func TestVerifyFlagsUnexpectedSubnetAndMissingExpectedSubnet(t *testing.T) {
+	actual := &armkeyvault.NetworkRuleSet{
+		DefaultAction: actionPtr(armkeyvault.NetworkRuleActionDeny),
+		Bypass:        bypassPtr(armkeyvault.NetworkRuleBypassOptionsNone),
+		VirtualNetworkRules: []*armkeyvault.VirtualNetworkRule{
+			{ID: strPtr("/subscriptions/x/subnets/unexpected")},
+		},
+	}
+	violations := Verify(actual, Expected{
+		DefaultAction:    armkeyvault.NetworkRuleActionDeny,
+		Bypass:           armkeyvault.NetworkRuleBypassOptionsNone,
+		AllowedSubnetIDs: []string{"/subscriptions/x/subnets/aks"},
+	})
+	assert.Len(t, violations, 2)
+}
+
+func TestVerifyFlagsUnexpectedIPRange(t *testing.T) {
+	actual := &armkeyvault.NetworkRuleSet{
+		DefaultAction: actionPtr(armkeyvault.NetworkRuleActionDeny),
+		Bypass:        bypassPtr(armkeyvault.NetworkRuleBypassOptionsNone),
+		IPRules:       []*armkeyvault.IPRule{{Value: strPtr("0.0.0.0/0")}},
+	}
+	violations := Verify(actual, Expected{DefaultAction: armkeyvault.NetworkRuleActionDeny, Bypass: armkeyvault.NetworkRuleBypassOptionsNone})
+	assert.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "IP range")
+}
+
+func TestVerifyPassesWhenFullyCompliant(t *testing.T) {
+	actual := &armkeyvault.NetworkRuleSet{
+		DefaultAction:       actionPtr(armkeyvault.NetworkRuleActionDeny),
+		Bypass:              bypassPtr(armkeyvault.NetworkRuleBypassOptionsNone),
+		VirtualNetworkRules: []*armkeyvault.VirtualNetworkRule{{ID: strPtr("/subscriptions/x/subnets/aks")}},
+		IPRules:             []*armkeyvault.IPRule{{Value: strPtr("203.0.113.0/24")}},
+	}
+	expected := Expected{
+		DefaultAction:    armkeyvault.NetworkRuleActionDeny,
+		Bypass:           armkeyvault.NetworkRuleBypassOptionsNone,
+		AllowedSubnetIDs: []string{"/subscriptions/x/subnets/aks"},
+		AllowedIPRanges:  []string{"203.0.113.0/24"},
+	}
+
+	assert.Empty(t, Verify(actual, expected))
+}
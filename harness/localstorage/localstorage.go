@@ -0,0 +1,90 @@
+// Package localstorage validates node pools provisioned with local NVMe
+// or Azure Container Storage: the storage pool actually initializes, its
+// storage class provisions volumes on the expected media, and a
+// throughput smoke test clears a minimum baseline. It's gated behind the
+// feature flag that enables local NVMe/Container Storage on a node pool
+// in the first place — most environments don't use it, so these checks
+// have no meaning (and nothing to check) unless it's on.
+package localstorage
+
+import (
+	"context"
+	"fmt"
+)
+
+// FeatureFlag is the tfvars/stack flag gating Azure Container Storage or
+// local NVMe on a node pool.
+type FeatureFlag struct {
+	Enabled bool
+}
+
+// PoolStatus is the observed state of a storage pool created by Azure
+// Container Storage on a node pool.
+type PoolStatus struct {
+	Name  string
+	Ready bool
+	Media string // e.g. "NvmeDisk", "Ephemeral", "AzureDisk"
+}
+
+// Volume is a provisioned persistent volume backed by a storage class
+// this package checks.
+type Volume struct {
+	Name          string
+	StorageClass  string
+	ProvisionedOn string // media the volume actually landed on
+}
+
+// Prober checks the live storage pool and provisioned volumes, and runs
+// a throughput smoke test against a volume.
+type Prober interface {
+	PoolStatus(ctx context.Context, pool string) (PoolStatus, error)
+	VolumesFor(ctx context.Context, storageClass string) ([]Volume, error)
+	ThroughputMBps(ctx context.Context, volume string) (float64, error)
+}
+
+// Violation is a single storage check failure.
+type Violation struct {
+	Message string
+}
+
+// Verify runs every check against prober for pool/storageClass/volume,
+// requiring at least minThroughputMBps. Returns nil immediately, with no
+// violations, if flag is disabled — an environment without the feature
+// flag has no local NVMe to check.
+func Verify(ctx context.Context, prober Prober, flag FeatureFlag, pool, storageClass, expectedMedia, volume string, minThroughputMBps float64) []Violation {
+	if !flag.Enabled {
+		return nil
+	}
+
+	var violations []Violation
+
+	status, err := prober.PoolStatus(ctx, pool)
+	if err != nil {
+		return []Violation{{Message: fmt.Sprintf("get storage pool %s status: %v", pool, err)}}
+	}
+	if !status.Ready {
+		violations = append(violations, Violation{Message: fmt.Sprintf("storage pool %s did not become ready", pool)})
+	}
+	if status.Media != expectedMedia {
+		violations = append(violations, Violation{Message: fmt.Sprintf("storage pool %s is on media %s, want %s", pool, status.Media, expectedMedia)})
+	}
+
+	volumes, err := prober.VolumesFor(ctx, storageClass)
+	if err != nil {
+		violations = append(violations, Violation{Message: fmt.Sprintf("list volumes for storage class %s: %v", storageClass, err)})
+	}
+	for _, v := range volumes {
+		if v.ProvisionedOn != expectedMedia {
+			violations = append(violations, Violation{Message: fmt.Sprintf("volume %s provisioned on %s, want %s", v.Name, v.ProvisionedOn, expectedMedia)})
+		}
+	}
+
+	throughput, err := prober.ThroughputMBps(ctx, volume)
+	if err != nil {
+		violations = append(violations, Violation{Message: fmt.Sprintf("measure throughput on %s: %v", volume, err)})
+	} else if throughput < minThroughputMBps {
+		violations = append(violations, Violation{Message: fmt.Sprintf("volume %s measured %.1f MB/s, want at least %.1f MB/s", volume, throughput, minThroughputMBps)})
+	}
+
+	return violations
+}
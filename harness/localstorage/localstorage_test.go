@@ -0,0 +1,82 @@
+package localstorage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeProber struct {
+	status        PoolStatus
+	statusErr     error
+	volumes       []Volume
+	volumesErr    error
+	throughput    float64
+	throughputErr error
+}
+
+func (f fakeProber) PoolStatus(ctx context.Context, pool string) (PoolStatus, error) {
+	return f.status, f.statusErr
+}
+
+func (f fakeProber) VolumesFor(ctx context.Context, storageClass string) ([]Volume, error) {
+	return f.volumes, f.volumesErr
+}
+
+func (f fakeProber) ThroughputMBps(ctx context.Context, volume string) (float64, error) {
+	return f.throughput, f.throughputErr
+}
+
+func TestVerifyReturnsNoViolationsWhenFlagDisabled(t *testing.T) {
+	prober := fakeProber{statusErr: errors.New("should not be called")}
+	violations := Verify(context.Background(), prober, FeatureFlag{Enabled: false}, "nvme-pool", "nvme-sc", "NvmeDisk", "vol-0", 100)
+	assert.Empty(t, violations)
+}
+
+func TestVerifyPassesWhenEverythingHealthy(t *testing.T) {
+	prober := fakeProber{
+		status:     PoolStatus{Name: "nvme-pool", Ready: true, Media: "NvmeDisk"},
+		volumes:    []Volume{{Name: "vol-0", StorageClass: "nvme-sc", ProvisionedOn: "NvmeDisk"}},
+		throughput: 250,
+	}
+	violations := Verify(context.Background(), prober, FeatureFlag{Enabled: true}, "nvme-pool", "nvme-sc", "NvmeDisk", "vol-0", 100)
+	assert.Empty(t, violations)
+}
+
+func TestVerifyFlagsPoolNotReady(t *testing.T) {
+	prober := fakeProber{
+		status:     PoolStatus{Name: "nvme-pool", Ready: false, Media: "NvmeDisk"},
+		throughput: 250,
+	}
+	violations := Verify(context.Background(), prober, FeatureFlag{Enabled: true}, "nvme-pool", "nvme-sc", "NvmeDisk", "vol-0", 100)
+	assert.Contains(t, violations, Violation{Message: "storage pool nvme-pool did not become ready"})
+}
+
+func TestVerifyFlagsWrongMedia(t *testing.T) {
+	prober := fakeProber{
+		status:     PoolStatus{Name: "nvme-pool", Ready: true, Media: "AzureDisk"},
+		volumes:    []Volume{{Name: "vol-0", StorageClass: "nvme-sc", ProvisionedOn: "AzureDisk"}},
+		throughput: 250,
+	}
+	violations := Verify(context.Background(), prober, FeatureFlag{Enabled: true}, "nvme-pool", "nvme-sc", "NvmeDisk", "vol-0", 100)
+	assert.Len(t, violations, 2)
+}
+
+func TestVerifyFlagsThroughputBelowBaseline(t *testing.T) {
+	prober := fakeProber{
+		status:     PoolStatus{Name: "nvme-pool", Ready: true, Media: "NvmeDisk"},
+		volumes:    []Volume{{Name: "vol-0", StorageClass: "nvme-sc", ProvisionedOn: "NvmeDisk"}},
+		throughput: 40,
+	}
+	violations := Verify(context.Background(), prober, FeatureFlag{Enabled: true}, "nvme-pool", "nvme-sc", "NvmeDisk", "vol-0", 100)
+	assert.Contains(t, violations, Violation{Message: "volume vol-0 measured 40.0 MB/s, want at least 100.0 MB/s"})
+}
+
+func TestVerifyStopsEarlyOnPoolStatusError(t *testing.T) {
+	prober := fakeProber{statusErr: errors.New("pool not found")}
+	violations := Verify(context.Background(), prober, FeatureFlag{Enabled: true}, "nvme-pool", "nvme-sc", "NvmeDisk", "vol-0", 100)
+	assert.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "pool not found")
+}
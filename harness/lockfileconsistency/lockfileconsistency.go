@@ -0,0 +1,135 @@
+// Package lockfileconsistency parses every stack's `.terraform.lock.hcl`
+// and asserts provider versions and hashes agree across stacks, catching
+// the "works in core, breaks in aks" problem where one stack's lockfile
+// drifted from the rest after an independent `terraform init -upgrade`.
+package lockfileconsistency
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// ProviderLock is one `provider "source" { ... }` block from a
+// .terraform.lock.hcl file.
+type ProviderLock struct {
+	Source  string
+	Version string
+	Hashes  []string
+}
+
+// StackLock is the parsed lockfile for a single stack.
+type StackLock struct {
+	Stack     string
+	Providers []ProviderLock
+}
+
+// ParseLockFile parses one `.terraform.lock.hcl` file's contents.
+func ParseLockFile(stack string, data []byte) (StackLock, error) {
+	file, diags := hclparse.NewParser().ParseHCL(data, stack+"/.terraform.lock.hcl")
+	if diags.HasErrors() {
+		return StackLock{}, fmt.Errorf("lockfileconsistency: parse %s lockfile: %s", stack, diags.Error())
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return StackLock{}, fmt.Errorf("lockfileconsistency: unexpected body type for %s lockfile", stack)
+	}
+
+	lock := StackLock{Stack: stack}
+	for _, block := range body.Blocks {
+		if block.Type != "provider" || len(block.Labels) != 1 {
+			continue
+		}
+		pl := ProviderLock{Source: block.Labels[0]}
+
+		if attr, ok := block.Body.Attributes["version"]; ok {
+			pl.Version = evalString(attr)
+		}
+		if attr, ok := block.Body.Attributes["hashes"]; ok {
+			pl.Hashes = evalStringList(attr)
+			sort.Strings(pl.Hashes)
+		}
+		lock.Providers = append(lock.Providers, pl)
+	}
+	return lock, nil
+}
+
+func evalString(attr *hclsyntax.Attribute) string {
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || val.IsNull() {
+		return ""
+	}
+	return val.AsString()
+}
+
+func evalStringList(attr *hclsyntax.Attribute) []string {
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || val.IsNull() {
+		return nil
+	}
+	var out []string
+	for it := val.ElementIterator(); it.Next(); {
+		_, v := it.Element()
+		out = append(out, v.AsString())
+	}
+	return out
+}
+
+// Violation is a single provider version or hash mismatch across stacks.
+type Violation struct {
+	Message string
+}
+
+// VerifyConsistency checks that every provider appearing in more than one
+// stack's lockfile pins the same version and hash set everywhere.
+func VerifyConsistency(stacks []StackLock) []Violation {
+	type seenLock struct {
+		stack   string
+		version string
+		hashes  []string
+	}
+	bySource := map[string][]seenLock{}
+
+	for _, s := range stacks {
+		for _, p := range s.Providers {
+			bySource[p.Source] = append(bySource[p.Source], seenLock{stack: s.Stack, version: p.Version, hashes: p.Hashes})
+		}
+	}
+
+	sources := make([]string, 0, len(bySource))
+	for source := range bySource {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	var violations []Violation
+	for _, source := range sources {
+		seen := bySource[source]
+		first := seen[0]
+		for _, other := range seen[1:] {
+			if other.version != first.version {
+				violations = append(violations, Violation{Message: fmt.Sprintf("provider %s is pinned to %s in stack %q but %s in stack %q", source, first.version, first.stack, other.version, other.stack)})
+				continue
+			}
+			if !equalHashes(first.hashes, other.hashes) {
+				violations = append(violations, Violation{Message: fmt.Sprintf("provider %s has mismatched hashes between stack %q and stack %q despite matching version %s", source, first.stack, other.stack, first.version)})
+			}
+		}
+	}
+	return violations
+}
+
+func equalHashes(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
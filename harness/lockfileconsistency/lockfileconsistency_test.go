@@ -0,0 +1,82 @@
+package lockfileconsistency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const coreLock = `
+provider "registry.terraform.io/hashicorp/azurerm" {
+  version     = "3.75.0"
+  constraints = "~> 3.75"
+  hashes = [
+    "h1:aaa=",
+    "zh:bbb",
+  ]
+}
+`
+
+const aksLockMatching = `
+provider "registry.terraform.io/hashicorp/azurerm" {
+  version     = "3.75.0"
+  constraints = "~> 3.75"
+  hashes = [
+    "h1:aaa=",
+    "zh:bbb",
+  ]
+}
+`
+
+const aksLockDriftedVersion = `
+provider "registry.terraform.io/hashicorp/azurerm" {
+  version     = "3.80.0"
+  constraints = "~> 3.75"
+  hashes = [
+    "h1:ccc=",
+    "zh:ddd",
+  ]
+}
+`
+
+func TestParseLockFileReadsProviderVersionAndHashes(t *testing.T) {
+	lock, err := ParseLockFile("core", []byte(coreLock))
+	require.NoError(t, err)
+	require.Len(t, lock.Providers, 1)
+	assert.Equal(t, "registry.terraform.io/hashicorp/azurerm", lock.Providers[0].Source)
+	assert.Equal(t, "3.75.0", lock.Providers[0].Version)
+	assert.ElementsMatch(t, []string{"h1:aaa=", "zh:bbb"}, lock.Providers[0].Hashes)
+}
+
+func TestParseLockFileReturnsErrorForInvalidHCL(t *testing.T) {
+	_, err := ParseLockFile("core", []byte(`provider "x" { version = `))
+	assert.Error(t, err)
+}
+
+func TestVerifyConsistencyPassesWhenLockfilesMatch(t *testing.T) {
+	core, err := ParseLockFile("core", []byte(coreLock))
+	require.NoError(t, err)
+	aks, err := ParseLockFile("aks-cluster", []byte(aksLockMatching))
+	require.NoError(t, err)
+
+	assert.Empty(t, VerifyConsistency([]StackLock{core, aks}))
+}
+
+func TestVerifyConsistencyFlagsVersionDrift(t *testing.T) {
+	core, err := ParseLockFile("core", []byte(coreLock))
+	require.NoError(t, err)
+	aks, err := ParseLockFile("aks-cluster", []byte(aksLockDriftedVersion))
+	require.NoError(t, err)
+
+	violations := VerifyConsistency([]StackLock{core, aks})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "3.75.0")
+	assert.Contains(t, violations[0].Message, "3.80.0")
+}
+
+func TestVerifyConsistencyIgnoresProvidersUsedByOnlyOneStack(t *testing.T) {
+	core, err := ParseLockFile("core", []byte(coreLock))
+	require.NoError(t, err)
+	assert.Empty(t, VerifyConsistency([]StackLock{core}))
+}
@@ -0,0 +1,100 @@
+// Package logretention verifies Log Analytics workspace retention,
+// per-table retention overrides, and data export rules match the
+// compliance configuration expected for an environment. Mis-set retention
+// either breaks audit requirements or silently doubles ingestion cost.
+package logretention
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/operationalinsights/armoperationalinsights"
+)
+
+// ExpectedRetention is the compliance-mandated retention/export
+// configuration for a workspace in a given environment.
+type ExpectedRetention struct {
+	// WorkspaceRetentionDays is the default retention every table not
+	// listed in TableRetentionDays must inherit.
+	WorkspaceRetentionDays int32
+	// TableRetentionDays overrides retention for specific tables, e.g.
+	// {"SecurityEvent": 730} for a longer audit trail.
+	TableRetentionDays map[string]int32
+	// ExportTables must each have an enabled data export rule targeting
+	// ExportDestinationID.
+	ExportTables        []string
+	ExportDestinationID string
+}
+
+// Violation is a single retention or export mismatch.
+type Violation struct {
+	Message string
+}
+
+// VerifyWorkspaceRetention checks the workspace's default retention.
+func VerifyWorkspaceRetention(ws armoperationalinsights.Workspace, expected ExpectedRetention) []Violation {
+	if ws.Properties == nil || ws.Properties.RetentionInDays == nil {
+		return []Violation{{Message: "workspace has no retention configured"}}
+	}
+	if *ws.Properties.RetentionInDays != expected.WorkspaceRetentionDays {
+		return []Violation{{Message: fmt.Sprintf("workspace retention is %d days, expected %d", *ws.Properties.RetentionInDays, expected.WorkspaceRetentionDays)}}
+	}
+	return nil
+}
+
+// VerifyTableRetention checks every table listed in
+// expected.TableRetentionDays has that exact retention configured.
+func VerifyTableRetention(tables []*armoperationalinsights.Table, expected ExpectedRetention) []Violation {
+	byName := make(map[string]*armoperationalinsights.Table, len(tables))
+	for _, t := range tables {
+		if t.Name != nil {
+			byName[*t.Name] = t
+		}
+	}
+
+	var violations []Violation
+	for tableName, want := range expected.TableRetentionDays {
+		table, ok := byName[tableName]
+		if !ok {
+			violations = append(violations, Violation{Message: fmt.Sprintf("table %q not found", tableName)})
+			continue
+		}
+		if table.Properties == nil || table.Properties.RetentionInDays == nil {
+			violations = append(violations, Violation{Message: fmt.Sprintf("table %q has no retention override, expected %d days", tableName, want)})
+			continue
+		}
+		if *table.Properties.RetentionInDays != want {
+			violations = append(violations, Violation{Message: fmt.Sprintf("table %q retention is %d days, expected %d", tableName, *table.Properties.RetentionInDays, want)})
+		}
+	}
+	return violations
+}
+
+// VerifyDataExport checks every table in expected.ExportTables has an
+// enabled export rule targeting expected.ExportDestinationID.
+func VerifyDataExport(exports []*armoperationalinsights.DataExport, expected ExpectedRetention) []Violation {
+	exportedTo := make(map[string]bool)
+	for _, e := range exports {
+		if e.Properties == nil || e.Properties.Enable == nil || !*e.Properties.Enable {
+			continue
+		}
+		if e.Properties.Destination == nil || e.Properties.Destination.ResourceID == nil {
+			continue
+		}
+		if *e.Properties.Destination.ResourceID != expected.ExportDestinationID {
+			continue
+		}
+		for _, t := range e.Properties.TableNames {
+			if t != nil {
+				exportedTo[*t] = true
+			}
+		}
+	}
+
+	var violations []Violation
+	for _, table := range expected.ExportTables {
+		if !exportedTo[table] {
+			violations = append(violations, Violation{Message: fmt.Sprintf("table %q has no enabled export rule to %s", table, expected.ExportDestinationID)})
+		}
+	}
+	return violations
+}
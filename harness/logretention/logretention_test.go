@@ -0,0 +1,94 @@
+package logretention
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/operationalinsights/armoperationalinsights"
+	"github.com/stretchr/testify/assert"
+)
+
+func strPtr(s string) *string { return &s }
+func i32Ptr(i int32) *int32   { return &i }
+func boolPtr(b bool) *bool    { return &b }
+
+func workspace(retentionDays int32) armoperationalinsights.Workspace {
+	return armoperationalinsights.Workspace{
+		Properties: &armoperationalinsights.WorkspaceProperties{
+			RetentionInDays: i32Ptr(retentionDays),
+		},
+	}
+}
+
+func table(name string, retentionDays int32) *armoperationalinsights.Table {
+	return &armoperationalinsights.Table{
+		Name: strPtr(name),
+		Properties: &armoperationalinsights.TableProperties{
+			RetentionInDays: i32Ptr(retentionDays),
+		},
+	}
+}
+
+func dataExport(destinationID string, enabled bool, tableNames ...string) *armoperationalinsights.DataExport {
+	names := make([]*string, len(tableNames))
+	for i, n := range tableNames {
+		names[i] = strPtr(n)
+	}
+	return &armoperationalinsights.DataExport{
+		Properties: &armoperationalinsights.DataExportProperties{
+			Enable:     boolPtr(enabled),
+			TableNames: names,
+			Destination: &armoperationalinsights.Destination{
+				ResourceID: strPtr(destinationID),
+			},
+		},
+	}
+}
+
+func TestVerifyWorkspaceRetentionPassesOnMatch(t *testing.T) {
+	expected := ExpectedRetention{WorkspaceRetentionDays: 90}
+	assert.Empty(t, VerifyWorkspaceRetention(workspace(90), expected))
+}
+
+func TestVerifyWorkspaceRetentionFlagsMismatch(t *testing.T) {
+	expected := ExpectedRetention{WorkspaceRetentionDays: 90}
+	violations := VerifyWorkspaceRetention(workspace(30), expected)
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyTableRetentionFlagsMissingOverride(t *testing.T) {
+	expected := ExpectedRetention{TableRetentionDays: map[string]int32{"SecurityEvent": 730}}
+	violations := VerifyTableRetention([]*armoperationalinsights.Table{table("SecurityEvent", 90)}, expected)
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyTableRetentionPassesOnMatch(t *testing.T) {
+	expected := ExpectedRetention{TableRetentionDays: map[string]int32{"SecurityEvent": 730}}
+	violations := VerifyTableRetention([]*armoperationalinsights.Table{table("SecurityEvent", 730)}, expected)
+	assert.Empty(t, violations)
+}
+
+func TestVerifyDataExportFlagsMissingRule(t *testing.T) {
+	expected := ExpectedRetention{
+		ExportTables:        []string{"SecurityEvent"},
+		ExportDestinationID: "/subscriptions/x/eventhub1",
+	}
+	assert.Len(t, VerifyDataExport(nil, expected), 1)
+}
+
+func TestVerifyDataExportIgnoresDisabledRules(t *testing.T) {
+	expected := ExpectedRetention{
+		ExportTables:        []string{"SecurityEvent"},
+		ExportDestinationID: "/subscriptions/x/eventhub1",
+	}
+	exports := []*armoperationalinsights.DataExport{dataExport("/subscriptions/x/eventhub1", false, "SecurityEvent")}
+	assert.Len(t, VerifyDataExport(exports, expected), 1)
+}
+
+func TestVerifyDataExportPassesOnEnabledMatchingRule(t *testing.T) {
+	expected := ExpectedRetention{
+		ExportTables:        []string{"SecurityEvent"},
+		ExportDestinationID: "/subscriptions/x/eventhub1",
+	}
+	exports := []*armoperationalinsights.DataExport{dataExport("/subscriptions/x/eventhub1", true, "SecurityEvent")}
+	assert.Empty(t, VerifyDataExport(exports, expected))
+}
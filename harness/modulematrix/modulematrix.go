@@ -0,0 +1,86 @@
+// Package modulematrix builds each stack's plan against its currently
+// pinned module version and against the module's latest tagged release,
+// then diffs the two, so a module upgrade PR ships with an automatically
+// generated impact assessment instead of a bare version bump.
+package modulematrix
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/planparser"
+)
+
+// Planner produces a plan for a stack pinned to a specific module version.
+// This package ships no implementation of it; tests supply a fake.
+type Planner interface {
+	Plan(ctx context.Context, stack, moduleVersion string) (planparser.Plan, error)
+}
+
+// Result is a single stack's compatibility matrix row.
+type Result struct {
+	Stack         string
+	PinnedVersion string
+	LatestVersion string
+	Diff          []planparser.ResourceChange
+}
+
+// Compatible reports whether the pinned and latest module versions produce
+// an identical plan for this stack.
+func (r Result) Compatible() bool {
+	return len(r.Diff) == 0
+}
+
+// BuildMatrix plans every stack against pinnedVersion and latestVersion and
+// returns the diff for each.
+func BuildMatrix(ctx context.Context, planner Planner, stacks []string, pinnedVersion, latestVersion string) ([]Result, error) {
+	results := make([]Result, 0, len(stacks))
+	for _, stack := range stacks {
+		pinnedPlan, err := planner.Plan(ctx, stack, pinnedVersion)
+		if err != nil {
+			return nil, fmt.Errorf("modulematrix: plan %s at %s: %w", stack, pinnedVersion, err)
+		}
+		latestPlan, err := planner.Plan(ctx, stack, latestVersion)
+		if err != nil {
+			return nil, fmt.Errorf("modulematrix: plan %s at %s: %w", stack, latestVersion, err)
+		}
+		results = append(results, Result{
+			Stack:         stack,
+			PinnedVersion: pinnedVersion,
+			LatestVersion: latestVersion,
+			Diff:          Diff(pinnedPlan, latestPlan),
+		})
+	}
+	return results, nil
+}
+
+// Diff returns every resource change whose planned action or after-state
+// differs between pinnedPlan and latestPlan, including resources present
+// in only one of the two plans.
+func Diff(pinnedPlan, latestPlan planparser.Plan) []planparser.ResourceChange {
+	latestByAddr := make(map[string]planparser.ResourceChange, len(latestPlan.ResourceChanges))
+	for _, rc := range latestPlan.ResourceChanges {
+		latestByAddr[rc.Address] = rc
+	}
+
+	var diffs []planparser.ResourceChange
+	seen := make(map[string]bool, len(pinnedPlan.ResourceChanges))
+	for _, pinned := range pinnedPlan.ResourceChanges {
+		seen[pinned.Address] = true
+		latest, ok := latestByAddr[pinned.Address]
+		if !ok {
+			diffs = append(diffs, pinned)
+			continue
+		}
+		if !reflect.DeepEqual(pinned.Actions, latest.Actions) || !reflect.DeepEqual(pinned.After, latest.After) {
+			diffs = append(diffs, latest)
+		}
+	}
+	for _, latest := range latestPlan.ResourceChanges {
+		if !seen[latest.Address] {
+			diffs = append(diffs, latest)
+		}
+	}
+	return diffs
+}
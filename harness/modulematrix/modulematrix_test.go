@@ -0,0 +1,64 @@
+package modulematrix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/planparser"
+)
+
+type fakePlanner struct {
+	plans map[string]planparser.Plan // keyed by stack+"@"+version
+}
+
+func (f *fakePlanner) Plan(ctx context.Context, stack, moduleVersion string) (planparser.Plan, error) {
+	return f.plans[stack+"@"+moduleVersion], nil
+}
+
+func TestDiffFlagsChangedAttribute(t *testing.T) {
+	pinned := planparser.Plan{ResourceChanges: []planparser.ResourceChange{
+		{Address: "azurerm_kubernetes_cluster.this", After: map[string]any{"sku_tier": "Free"}},
+	}}
+	latest := planparser.Plan{ResourceChanges: []planparser.ResourceChange{
+		{Address: "azurerm_kubernetes_cluster.this", After: map[string]any{"sku_tier": "Standard"}},
+	}}
+
+	diff := Diff(pinned, latest)
+	require.Len(t, diff, 1)
+	assert.Equal(t, "azurerm_kubernetes_cluster.this", diff[0].Address)
+}
+
+func TestDiffFlagsResourceOnlyInLatest(t *testing.T) {
+	pinned := planparser.Plan{}
+	latest := planparser.Plan{ResourceChanges: []planparser.ResourceChange{
+		{Address: "azurerm_kubernetes_cluster_node_pool.new"},
+	}}
+
+	diff := Diff(pinned, latest)
+	require.Len(t, diff, 1)
+	assert.Equal(t, "azurerm_kubernetes_cluster_node_pool.new", diff[0].Address)
+}
+
+func TestDiffEmptyWhenPlansMatch(t *testing.T) {
+	plan := planparser.Plan{ResourceChanges: []planparser.ResourceChange{
+		{Address: "azurerm_kubernetes_cluster.this", After: map[string]any{"sku_tier": "Free"}},
+	}}
+	assert.Empty(t, Diff(plan, plan))
+}
+
+func TestBuildMatrixReportsCompatibility(t *testing.T) {
+	planner := &fakePlanner{plans: map[string]planparser.Plan{
+		"aks-cluster@v1.0.0": {ResourceChanges: []planparser.ResourceChange{{Address: "a", After: map[string]any{"x": 1}}}},
+		"aks-cluster@v2.0.0": {ResourceChanges: []planparser.ResourceChange{{Address: "a", After: map[string]any{"x": 2}}}},
+		"core@v1.0.0":        {},
+		"core@v2.0.0":        {},
+	}}
+
+	results, err := BuildMatrix(context.Background(), planner, []string{"aks-cluster", "core"}, "v1.0.0", "v2.0.0")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.False(t, results[0].Compatible())
+	assert.True(t, results[1].Compatible())
+}
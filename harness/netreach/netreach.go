@@ -0,0 +1,80 @@
+// Package netreach decides whether the test process has line-of-sight to
+// a private AKS API server and, based on that, which access strategy the
+// Kubernetes assertions elsewhere in this harness should use: a direct
+// client-go connection when running on a self-hosted runner inside the
+// VNet, or a run-command/jumpbox hop when running from outside it. Tests
+// pick the strategy through this package instead of hardcoding one, so
+// the same assertions run unmodified in both places.
+package netreach
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// Strategy is how Kubernetes assertions should reach the private API
+// server.
+type Strategy string
+
+const (
+	// StrategyDirect connects to the private API server directly via
+	// client-go, available when the test process is already inside the
+	// VNet (or a peered one).
+	StrategyDirect Strategy = "direct"
+	// StrategyJumpbox hops through Azure Run Command on a VM inside the
+	// VNet, used when the test process has no line-of-sight of its own.
+	StrategyJumpbox Strategy = "jumpbox"
+)
+
+// Dialer matches net.Dialer's DialContext signature so a fake can stand
+// in for it in tests without opening a real socket.
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// Options configures Detect. Timeout defaults to a short value suited to
+// a local TCP probe.
+type Options struct {
+	Timeout time.Duration
+}
+
+// Detect probes apiServerHost:443 and returns StrategyDirect if the dial
+// succeeds within opts.Timeout, StrategyJumpbox otherwise. A failed dial
+// is treated as "no line-of-sight" rather than an error: from outside the
+// VNet, connection refused/timeout is the expected, correct outcome.
+func Detect(ctx context.Context, dialer Dialer, apiServerHost string, opts Options) Strategy {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = 3 * time.Second
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := dialer.DialContext(dialCtx, "tcp", net.JoinHostPort(apiServerHost, "443"))
+	if err != nil {
+		return StrategyJumpbox
+	}
+	conn.Close()
+	return StrategyDirect
+}
+
+// Plan is the resolved access strategy plus the human-readable reason it
+// was chosen, suitable for logging alongside test output.
+type Plan struct {
+	Strategy Strategy
+	Reason   string
+}
+
+// Resolve wraps Detect with a Plan explaining the choice.
+func Resolve(ctx context.Context, dialer Dialer, apiServerHost string, opts Options) Plan {
+	strategy := Detect(ctx, dialer, apiServerHost, opts)
+	switch strategy {
+	case StrategyDirect:
+		return Plan{Strategy: strategy, Reason: fmt.Sprintf("dialed %s:443 directly, running in-VNet", apiServerHost)}
+	default:
+		return Plan{Strategy: strategy, Reason: fmt.Sprintf("could not dial %s:443 directly, falling back to jumpbox/run-command", apiServerHost)}
+	}
+}
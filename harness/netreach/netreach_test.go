@@ -0,0 +1,59 @@
+package netreach
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDialer struct {
+	err  error
+	conn net.Conn
+}
+
+func (f *fakeDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.conn, nil
+}
+
+type fakeConn struct{ net.Conn }
+
+func (fakeConn) Close() error { return nil }
+
+func TestDetectReturnsDirectWhenDialSucceeds(t *testing.T) {
+	dialer := &fakeDialer{conn: fakeConn{}}
+	strategy := Detect(context.Background(), dialer, "10.0.0.4", Options{Timeout: time.Second})
+	assert.Equal(t, StrategyDirect, strategy)
+}
+
+func TestDetectReturnsJumpboxWhenDialFails(t *testing.T) {
+	dialer := &fakeDialer{err: errors.New("connection refused")}
+	strategy := Detect(context.Background(), dialer, "10.0.0.4", Options{Timeout: time.Second})
+	assert.Equal(t, StrategyJumpbox, strategy)
+}
+
+func TestDetectDefaultsTimeoutWhenUnset(t *testing.T) {
+	dialer := &fakeDialer{conn: fakeConn{}}
+	strategy := Detect(context.Background(), dialer, "10.0.0.4", Options{})
+	assert.Equal(t, StrategyDirect, strategy)
+}
+
+func TestResolveExplainsDirectChoice(t *testing.T) {
+	dialer := &fakeDialer{conn: fakeConn{}}
+	plan := Resolve(context.Background(), dialer, "10.0.0.4", Options{Timeout: time.Second})
+	assert.Equal(t, StrategyDirect, plan.Strategy)
+	assert.Contains(t, plan.Reason, "in-VNet")
+}
+
+func TestResolveExplainsJumpboxChoice(t *testing.T) {
+	dialer := &fakeDialer{err: errors.New("timeout")}
+	plan := Resolve(context.Background(), dialer, "10.0.0.4", Options{Timeout: time.Second})
+	assert.Equal(t, StrategyJumpbox, plan.Strategy)
+	assert.Contains(t, plan.Reason, "jumpbox")
+}
@@ -0,0 +1,96 @@
+// Package nodebench complements harness/conformance's control-plane checks
+// with node-level CIS checks, by running kube-bench as a Job on the test
+// cluster and parsing its results.
+package nodebench
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Status mirrors kube-bench's per-check result.
+type Status string
+
+const (
+	Pass Status = "PASS"
+	Fail Status = "FAIL"
+	Warn Status = "WARN"
+	Info Status = "INFO"
+)
+
+// Finding is one kube-bench check result, flattened out of its
+// controls/groups/tests hierarchy.
+type Finding struct {
+	ID     string
+	Text   string
+	Status Status
+}
+
+// kubeBenchReport mirrors the subset of kube-bench's `--json` output this
+// package consumes.
+type kubeBenchReport struct {
+	Controls []struct {
+		Tests []struct {
+			Results []struct {
+				TestNumber string `json:"test_number"`
+				TestDesc   string `json:"test_desc"`
+				Status     string `json:"status"`
+			} `json:"results"`
+		} `json:"tests"`
+	} `json:"Controls"`
+}
+
+// ParseJSON parses kube-bench's `--json` output into a flat list of
+// Findings.
+func ParseJSON(data []byte) ([]Finding, error) {
+	var report kubeBenchReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("nodebench: parse kube-bench output: %w", err)
+	}
+
+	var findings []Finding
+	for _, control := range report.Controls {
+		for _, test := range control.Tests {
+			for _, result := range test.Results {
+				findings = append(findings, Finding{
+					ID:     result.TestNumber,
+					Text:   result.TestDesc,
+					Status: Status(result.Status),
+				})
+			}
+		}
+	}
+	return findings, nil
+}
+
+// Runner deploys and runs kube-bench (or kubescape) as a Job on the test
+// cluster through a run-command wrapper and returns its raw JSON output.
+type Runner interface {
+	RunKubeBench(ctx context.Context) ([]byte, error)
+}
+
+// Gate fails with the offending findings if any FAIL finding's ID is not
+// present in allowlist, so a known, tracked gap doesn't block every run.
+func Gate(findings []Finding, allowlist []string) error {
+	allowed := make(map[string]bool, len(allowlist))
+	for _, id := range allowlist {
+		allowed[id] = true
+	}
+
+	var blocking []Finding
+	for _, f := range findings {
+		if f.Status == Fail && !allowed[f.ID] {
+			blocking = append(blocking, f)
+		}
+	}
+	if len(blocking) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("nodebench: %d unallowed critical finding(s):", len(blocking))
+	for _, f := range blocking {
+		msg += fmt.Sprintf("\n  [%s] %s", f.ID, f.Text)
+	}
+	return fmt.Errorf("%s", msg)
+}
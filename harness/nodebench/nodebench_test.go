@@ -0,0 +1,39 @@
+package nodebench
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleKubeBenchOutput = `{
+  "Controls": [
+    {
+      "tests": [
+        {
+          "results": [
+            {"test_number": "1.1.1", "test_desc": "Ensure API server pod specification file permissions", "status": "PASS"},
+            {"test_number": "1.2.6", "test_desc": "Ensure anonymous-auth is disabled", "status": "FAIL"}
+          ]
+        }
+      ]
+    }
+  ]
+}`
+
+func TestParseJSONFlattensControls(t *testing.T) {
+	findings, err := ParseJSON([]byte(sampleKubeBenchOutput))
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	assert.Equal(t, "1.2.6", findings[1].ID)
+	assert.Equal(t, Fail, findings[1].Status)
+}
+
+func TestGateFailsOnUnallowedCriticalFinding(t *testing.T) {
+	findings, err := ParseJSON([]byte(sampleKubeBenchOutput))
+	require.NoError(t, err)
+
+	assert.Error(t, Gate(findings, nil))
+	assert.NoError(t, Gate(findings, []string{"1.2.6"}))
+}
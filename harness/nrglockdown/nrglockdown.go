@@ -0,0 +1,54 @@
+// Package nrglockdown verifies the AKS node resource group's lockdown:
+// a deny assignment restricting write access exists at its scope, and
+// the deploy identity actually can't modify resources inside it, by
+// attempting a no-op tagging call and expecting it to be denied.
+package nrglockdown
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
+)
+
+// Violation is a single lockdown gap.
+type Violation struct {
+	Message string
+}
+
+// VerifyDenyAssignment checks a deny assignment exists at scope, applies
+// to child scopes, and isn't scoped down with exclusions that would let
+// the deploy identity slip through.
+func VerifyDenyAssignment(assignments []*armauthorization.DenyAssignment, scope string) []Violation {
+	for _, da := range assignments {
+		if da.Properties == nil || da.Properties.Scope == nil || *da.Properties.Scope != scope {
+			continue
+		}
+		var violations []Violation
+		if da.Properties.DoNotApplyToChildScopes != nil && *da.Properties.DoNotApplyToChildScopes {
+			violations = append(violations, Violation{Message: fmt.Sprintf("deny assignment at scope %q does not apply to child scopes", scope)})
+		}
+		if len(da.Properties.ExcludePrincipals) > 0 {
+			violations = append(violations, Violation{Message: fmt.Sprintf("deny assignment at scope %q excludes %d principal(s) from the lockdown", scope, len(da.Properties.ExcludePrincipals))})
+		}
+		return violations
+	}
+	return []Violation{{Message: fmt.Sprintf("no deny assignment found at scope %q", scope)}}
+}
+
+// Tagger attempts to tag a resource, typically the deploy identity's own
+// client used to drive `terraform apply`.
+type Tagger interface {
+	TagResource(ctx context.Context, resourceID string, tags map[string]string) error
+}
+
+// VerifyDeployIdentityDenied attempts a no-op tagging call against
+// resourceID as the deploy identity and returns a Violation if it
+// unexpectedly succeeds - the lockdown isn't actually stopping writes.
+func VerifyDeployIdentityDenied(ctx context.Context, tagger Tagger, resourceID string) []Violation {
+	err := tagger.TagResource(ctx, resourceID, map[string]string{"nrglockdown-probe": "true"})
+	if err == nil {
+		return []Violation{{Message: fmt.Sprintf("deploy identity was able to tag %q despite the node resource group lockdown", resourceID)}}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package nrglockdown
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+func boolPtr(b bool) *bool    { return &b }
+
+const nrgScope = "/subscriptions/x/resourceGroups/MC_rg_aks_eastus2"
+
+func TestVerifyDenyAssignmentFlagsMissing(t *testing.T) {
+	violations := VerifyDenyAssignment(nil, nrgScope)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "no deny assignment found")
+}
+
+func TestVerifyDenyAssignmentFlagsDoesNotApplyToChildScopes(t *testing.T) {
+	assignments := []*armauthorization.DenyAssignment{
+		{Properties: &armauthorization.DenyAssignmentProperties{Scope: strPtr(nrgScope), DoNotApplyToChildScopes: boolPtr(true)}},
+	}
+	violations := VerifyDenyAssignment(assignments, nrgScope)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "child scopes")
+}
+
+func TestVerifyDenyAssignmentFlagsExcludedPrincipals(t *testing.T) {
+	assignments := []*armauthorization.DenyAssignment{
+		{Properties: &armauthorization.DenyAssignmentProperties{
+			Scope:             strPtr(nrgScope),
+			ExcludePrincipals: []*armauthorization.Principal{{ID: strPtr("some-principal")}},
+		}},
+	}
+	violations := VerifyDenyAssignment(assignments, nrgScope)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "excludes")
+}
+
+func TestVerifyDenyAssignmentPassesForCleanLockdown(t *testing.T) {
+	assignments := []*armauthorization.DenyAssignment{
+		{Properties: &armauthorization.DenyAssignmentProperties{Scope: strPtr(nrgScope), DoNotApplyToChildScopes: boolPtr(false)}},
+	}
+	assert.Empty(t, VerifyDenyAssignment(assignments, nrgScope))
+}
+
+type fakeTagger struct {
+	err error
+}
+
+func (f *fakeTagger) TagResource(ctx context.Context, resourceID string, tags map[string]string) error {
+	return f.err
+}
+
+func TestVerifyDeployIdentityDeniedPassesWhenTagCallDenied(t *testing.T) {
+	tagger := &fakeTagger{err: errors.New("AuthorizationFailed: the deploy identity does not have authorization")}
+	assert.Empty(t, VerifyDeployIdentityDenied(context.Background(), tagger, nrgScope+"/providers/Microsoft.Compute/virtualMachineScaleSets/aks-nodes"))
+}
+
+func TestVerifyDeployIdentityDeniedFlagsUnexpectedSuccess(t *testing.T) {
+	tagger := &fakeTagger{}
+	violations := VerifyDeployIdentityDenied(context.Background(), tagger, nrgScope+"/providers/Microsoft.Compute/virtualMachineScaleSets/aks-nodes")
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "despite the node resource group lockdown")
+}
@@ -0,0 +1,117 @@
+// Package orchstatus tracks progress through a multi-hour orchestration
+// (a scenario suite, a canary run) and serves it over HTTP as JSON, so an
+// operator can check current stage, per-stack status, and ETA without
+// tailing CI logs. There's no other gRPC surface anywhere in this repo,
+// so this sticks to net/http + JSON to match everything else here rather
+// than introducing a new RPC framework for one endpoint.
+package orchstatus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// StackStatus is one stack's current state within the orchestration.
+type StackStatus string
+
+const (
+	StackPending StackStatus = "pending"
+	StackRunning StackStatus = "running"
+	StackPassed  StackStatus = "passed"
+	StackFailed  StackStatus = "failed"
+)
+
+// Snapshot is the orchestration's state at a point in time.
+type Snapshot struct {
+	Stage     string                 `json:"stage"`
+	StartedAt time.Time              `json:"started_at"`
+	Stacks    map[string]StackStatus `json:"stacks"`
+	ETA       *time.Time             `json:"eta,omitempty"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// Tracker records orchestration progress and is safe for concurrent use
+// by whatever goroutines are driving each stack.
+type Tracker struct {
+	mu       sync.RWMutex
+	snapshot Snapshot
+}
+
+// NewTracker returns a Tracker for an orchestration that started at
+// startedAt, covering the given stacks (all StackPending initially).
+func NewTracker(startedAt time.Time, stacks []string) *Tracker {
+	statuses := make(map[string]StackStatus, len(stacks))
+	for _, s := range stacks {
+		statuses[s] = StackPending
+	}
+	return &Tracker{snapshot: Snapshot{StartedAt: startedAt, Stacks: statuses, UpdatedAt: startedAt}}
+}
+
+// SetStage records the orchestration's current named stage (e.g. "apply",
+// "verify", "teardown").
+func (t *Tracker) SetStage(stage string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.snapshot.Stage = stage
+	t.snapshot.UpdatedAt = now
+}
+
+// SetStackStatus records status for a single stack.
+func (t *Tracker) SetStackStatus(stack string, status StackStatus, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.snapshot.Stacks[stack] = status
+	t.snapshot.UpdatedAt = now
+}
+
+// SetETA records the orchestration's current estimated completion time.
+func (t *Tracker) SetETA(eta time.Time, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.snapshot.ETA = &eta
+	t.snapshot.UpdatedAt = now
+}
+
+// Snapshot returns a copy of the tracker's current state.
+func (t *Tracker) Snapshot() Snapshot {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	stacks := make(map[string]StackStatus, len(t.snapshot.Stacks))
+	for k, v := range t.snapshot.Stacks {
+		stacks[k] = v
+	}
+	snap := t.snapshot
+	snap.Stacks = stacks
+	return snap
+}
+
+// Handler serves the tracker's current Snapshot as JSON on every request,
+// suitable for mounting at e.g. "/status".
+func (t *Tracker) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(t.Snapshot())
+	})
+}
+
+// BlobWriter persists a Snapshot somewhere durable (e.g. an Azure Blob
+// Storage container), so an operator can check progress even if the
+// orchestrator's local status endpoint isn't reachable from where they
+// are.
+type BlobWriter interface {
+	Upload(ctx context.Context, blobName string, data []byte) error
+}
+
+// WriteThrough marshals the tracker's current Snapshot and uploads it to
+// writer under blobName, for callers that want a durable copy alongside
+// the live HTTP endpoint.
+func (t *Tracker) WriteThrough(ctx context.Context, writer BlobWriter, blobName string) error {
+	data, err := json.Marshal(t.Snapshot())
+	if err != nil {
+		return err
+	}
+	return writer.Upload(ctx, blobName, data)
+}
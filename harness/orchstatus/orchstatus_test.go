@@ -0,0 +1,72 @@
+package orchstatus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetStackStatusUpdatesSnapshot(t *testing.T) {
+	now := time.Now()
+	tracker := NewTracker(now, []string{"core", "aks"})
+
+	tracker.SetStackStatus("aks", StackRunning, now.Add(time.Minute))
+	snap := tracker.Snapshot()
+	assert.Equal(t, StackPending, snap.Stacks["core"])
+	assert.Equal(t, StackRunning, snap.Stacks["aks"])
+}
+
+func TestHandlerServesCurrentSnapshotAsJSON(t *testing.T) {
+	now := time.Now()
+	tracker := NewTracker(now, []string{"core"})
+	tracker.SetStage("apply", now)
+
+	server := httptest.NewServer(tracker.Handler())
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var snap Snapshot
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&snap))
+	assert.Equal(t, "apply", snap.Stage)
+}
+
+type fakeBlobWriter struct {
+	blobName string
+	data     []byte
+}
+
+func (f *fakeBlobWriter) Upload(ctx context.Context, blobName string, data []byte) error {
+	f.blobName = blobName
+	f.data = data
+	return nil
+}
+
+func TestWriteThroughUploadsMarshaledSnapshot(t *testing.T) {
+	now := time.Now()
+	tracker := NewTracker(now, []string{"core"})
+	tracker.SetStage("verify", now)
+
+	writer := &fakeBlobWriter{}
+	require.NoError(t, tracker.WriteThrough(context.Background(), writer, "status/run-42.json"))
+
+	assert.Equal(t, "status/run-42.json", writer.blobName)
+	assert.Contains(t, string(writer.data), "verify")
+}
+
+func TestSnapshotIsIndependentFromLaterUpdates(t *testing.T) {
+	now := time.Now()
+	tracker := NewTracker(now, []string{"core"})
+
+	snap := tracker.Snapshot()
+	tracker.SetStackStatus("core", StackPassed, now.Add(time.Minute))
+
+	assert.Equal(t, StackPending, snap.Stacks["core"])
+}
@@ -0,0 +1,93 @@
+// Package ossku asserts each node pool is running the OS SKU (AzureLinux
+// vs Ubuntu) and version channel pinned for it in tfvars, and that a pool
+// migrated between SKUs still passes a basic workload smoke test before
+// the migration is considered done.
+package ossku
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v5"
+)
+
+// ExpectedPool is the OS SKU and node image channel a pool is pinned to.
+type ExpectedPool struct {
+	Name           string
+	OSSKU          armcontainerservice.OSSKU
+	VersionChannel string // e.g. "AKSAzureLinuxV3", must be a prefix of NodeImageVersion
+}
+
+// Violation is a single pool OS SKU or version mismatch.
+type Violation struct {
+	Message string
+}
+
+// VerifyOSSKU checks every expected pool exists and runs the pinned OS
+// SKU and, if VersionChannel is set, a node image from that channel.
+func VerifyOSSKU(pools []*armcontainerservice.ManagedClusterAgentPoolProfile, expected []ExpectedPool) []Violation {
+	byName := make(map[string]*armcontainerservice.ManagedClusterAgentPoolProfile, len(pools))
+	for _, p := range pools {
+		if p.Name != nil {
+			byName[*p.Name] = p
+		}
+	}
+
+	var violations []Violation
+	for _, exp := range expected {
+		pool, ok := byName[exp.Name]
+		if !ok {
+			violations = append(violations, Violation{Message: fmt.Sprintf("node pool %q not found", exp.Name)})
+			continue
+		}
+		if pool.OSSKU == nil || *pool.OSSKU != exp.OSSKU {
+			violations = append(violations, Violation{Message: fmt.Sprintf("node pool %q has OS SKU %s, expected %s", exp.Name, osSKUString(pool.OSSKU), exp.OSSKU)})
+		}
+		if exp.VersionChannel != "" {
+			image := ""
+			if pool.NodeImageVersion != nil {
+				image = *pool.NodeImageVersion
+			}
+			if !strings.HasPrefix(image, exp.VersionChannel) {
+				violations = append(violations, Violation{Message: fmt.Sprintf("node pool %q node image %q is not on the %q channel", exp.Name, image, exp.VersionChannel)})
+			}
+		}
+	}
+	return violations
+}
+
+// SmokeTester runs a minimal workload against a node pool and reports
+// whether it scheduled and became ready, typically a probe pod pinned to
+// the pool via nodeSelector.
+type SmokeTester interface {
+	RunSmokeTest(poolName string) error
+}
+
+// VerifyMigration runs the workload smoke test against every pool that
+// changed OS SKU between before and after, failing any pool whose smoke
+// test doesn't pass post-migration.
+func VerifyMigration(tester SmokeTester, before, after []ExpectedPool) []Violation {
+	beforeSKU := make(map[string]armcontainerservice.OSSKU, len(before))
+	for _, p := range before {
+		beforeSKU[p.Name] = p.OSSKU
+	}
+
+	var violations []Violation
+	for _, p := range after {
+		prior, existed := beforeSKU[p.Name]
+		if !existed || prior == p.OSSKU {
+			continue
+		}
+		if err := tester.RunSmokeTest(p.Name); err != nil {
+			violations = append(violations, Violation{Message: fmt.Sprintf("node pool %q failed workload smoke test after migrating from %s to %s: %v", p.Name, prior, p.OSSKU, err)})
+		}
+	}
+	return violations
+}
+
+func osSKUString(sku *armcontainerservice.OSSKU) string {
+	if sku == nil {
+		return "<unset>"
+	}
+	return string(*sku)
+}
@@ -0,0 +1,80 @@
+package ossku
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+
+func agentPool(name string, sku armcontainerservice.OSSKU, nodeImage string) *armcontainerservice.ManagedClusterAgentPoolProfile {
+	return &armcontainerservice.ManagedClusterAgentPoolProfile{
+		Name:             strPtr(name),
+		OSSKU:            &sku,
+		NodeImageVersion: strPtr(nodeImage),
+	}
+}
+
+func TestVerifyOSSKUFlagsMissingPool(t *testing.T) {
+	violations := VerifyOSSKU(nil, []ExpectedPool{{Name: "system", OSSKU: armcontainerservice.OSSKUAzureLinux}})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "not found")
+}
+
+func TestVerifyOSSKUFlagsWrongSKU(t *testing.T) {
+	pools := []*armcontainerservice.ManagedClusterAgentPoolProfile{agentPool("system", armcontainerservice.OSSKUUbuntu, "AKSUbuntu-2204gen2containerd-202601.01.0")}
+	violations := VerifyOSSKU(pools, []ExpectedPool{{Name: "system", OSSKU: armcontainerservice.OSSKUAzureLinux}})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "expected AzureLinux")
+}
+
+func TestVerifyOSSKUFlagsWrongVersionChannel(t *testing.T) {
+	pools := []*armcontainerservice.ManagedClusterAgentPoolProfile{agentPool("system", armcontainerservice.OSSKUAzureLinux, "AKSUbuntu-2204gen2containerd-202601.01.0")}
+	violations := VerifyOSSKU(pools, []ExpectedPool{{Name: "system", OSSKU: armcontainerservice.OSSKUAzureLinux, VersionChannel: "AKSAzureLinuxV3"}})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "channel")
+}
+
+func TestVerifyOSSKUPassesOnMatch(t *testing.T) {
+	pools := []*armcontainerservice.ManagedClusterAgentPoolProfile{agentPool("system", armcontainerservice.OSSKUAzureLinux, "AKSAzureLinuxV3gen2-202601.01.0")}
+	violations := VerifyOSSKU(pools, []ExpectedPool{{Name: "system", OSSKU: armcontainerservice.OSSKUAzureLinux, VersionChannel: "AKSAzureLinuxV3"}})
+	assert.Empty(t, violations)
+}
+
+type fakeSmokeTester struct {
+	fail map[string]bool
+}
+
+func (f *fakeSmokeTester) RunSmokeTest(poolName string) error {
+	if f.fail[poolName] {
+		return errors.New("pod never became ready")
+	}
+	return nil
+}
+
+func TestVerifyMigrationSkipsPoolsThatDidNotChangeSKU(t *testing.T) {
+	tester := &fakeSmokeTester{fail: map[string]bool{"system": true}}
+	before := []ExpectedPool{{Name: "system", OSSKU: armcontainerservice.OSSKUAzureLinux}}
+	after := []ExpectedPool{{Name: "system", OSSKU: armcontainerservice.OSSKUAzureLinux}}
+	assert.Empty(t, VerifyMigration(tester, before, after))
+}
+
+func TestVerifyMigrationFlagsFailedSmokeTestAfterSKUChange(t *testing.T) {
+	tester := &fakeSmokeTester{fail: map[string]bool{"userpool": true}}
+	before := []ExpectedPool{{Name: "userpool", OSSKU: armcontainerservice.OSSKUUbuntu}}
+	after := []ExpectedPool{{Name: "userpool", OSSKU: armcontainerservice.OSSKUAzureLinux}}
+	violations := VerifyMigration(tester, before, after)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "userpool")
+}
+
+func TestVerifyMigrationPassesWhenSmokeTestSucceeds(t *testing.T) {
+	tester := &fakeSmokeTester{}
+	before := []ExpectedPool{{Name: "userpool", OSSKU: armcontainerservice.OSSKUUbuntu}}
+	after := []ExpectedPool{{Name: "userpool", OSSKU: armcontainerservice.OSSKUAzureLinux}}
+	assert.Empty(t, VerifyMigration(tester, before, after))
+}
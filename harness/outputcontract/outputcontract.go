@@ -0,0 +1,108 @@
+// Package outputcontract validates a stack's `terraform output -json`
+// against the contract its consumers were written against: which outputs
+// must exist, what type they are, and, where the value has an expected
+// shape (a resource ID, an FQDN), a format pattern it must match. This
+// catches an output rename or type change breaking a downstream stack
+// before that stack's own apply fails on a missing reference.
+package outputcontract
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// Type is the Terraform output type this package checks against.
+type Type string
+
+const (
+	TypeString Type = "string"
+	TypeNumber Type = "number"
+	TypeBool   Type = "bool"
+	TypeList   Type = "list"
+	TypeMap    Type = "map"
+)
+
+// Contract describes one output a consumer depends on.
+type Contract struct {
+	Name    string
+	Type    Type
+	Format  *regexp.Regexp // optional, only checked for TypeString
+	Because string         // which consumer stack depends on this, for violation messages
+}
+
+// Violation is a single output missing, mistyped, or malformed.
+type Violation struct {
+	Message string
+}
+
+// rawOutput mirrors one entry of `terraform output -json`.
+type rawOutput struct {
+	Value     json.RawMessage `json:"value"`
+	Type      json.RawMessage `json:"type"`
+	Sensitive bool            `json:"sensitive"`
+}
+
+// Outputs is a parsed `terraform output -json` document.
+type Outputs map[string]rawOutput
+
+// Parse parses `terraform output -json` output.
+func Parse(data []byte) (Outputs, error) {
+	var outputs Outputs
+	if err := json.Unmarshal(data, &outputs); err != nil {
+		return nil, fmt.Errorf("outputcontract: parse output JSON: %w", err)
+	}
+	return outputs, nil
+}
+
+// Verify checks every contract has a matching output of the right type
+// and, for strings with a Format set, a value matching that pattern.
+func Verify(outputs Outputs, contracts []Contract) []Violation {
+	var violations []Violation
+	for _, c := range contracts {
+		out, ok := outputs[c.Name]
+		if !ok {
+			violations = append(violations, Violation{Message: fmt.Sprintf("output %q is missing, required by %s", c.Name, c.Because)})
+			continue
+		}
+
+		if !matchesType(out.Value, c.Type) {
+			violations = append(violations, Violation{Message: fmt.Sprintf("output %q does not match expected type %q, required by %s", c.Name, c.Type, c.Because)})
+			continue
+		}
+
+		if c.Type == TypeString && c.Format != nil {
+			var value string
+			if err := json.Unmarshal(out.Value, &value); err == nil && !c.Format.MatchString(value) {
+				violations = append(violations, Violation{Message: fmt.Sprintf("output %q value %q does not match expected format, required by %s", c.Name, value, c.Because)})
+			}
+		}
+	}
+	return violations
+}
+
+func matchesType(raw json.RawMessage, want Type) bool {
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return false
+	}
+	switch want {
+	case TypeString:
+		_, ok := v.(string)
+		return ok
+	case TypeNumber:
+		_, ok := v.(float64)
+		return ok
+	case TypeBool:
+		_, ok := v.(bool)
+		return ok
+	case TypeList:
+		_, ok := v.([]any)
+		return ok
+	case TypeMap:
+		_, ok := v.(map[string]any)
+		return ok
+	default:
+		return false
+	}
+}
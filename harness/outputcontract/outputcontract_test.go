@@ -0,0 +1,57 @@
+package outputcontract
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAndVerifyPassesForMatchingContract(t *testing.T) {
+	outputs, err := Parse([]byte(`{
+		"aks_cluster_id": {"value": "/subscriptions/x/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks", "type": "string"},
+		"node_count": {"value": 3, "type": "number"}
+	}`))
+	require.NoError(t, err)
+
+	contracts := []Contract{
+		{Name: "aks_cluster_id", Type: TypeString, Format: regexp.MustCompile(`^/subscriptions/.+/managedClusters/.+$`), Because: "addons stack"},
+		{Name: "node_count", Type: TypeNumber, Because: "capacity stack"},
+	}
+	assert.Empty(t, Verify(outputs, contracts))
+}
+
+func TestVerifyFlagsMissingOutput(t *testing.T) {
+	outputs, err := Parse([]byte(`{}`))
+	require.NoError(t, err)
+
+	violations := Verify(outputs, []Contract{{Name: "aks_cluster_id", Type: TypeString, Because: "addons stack"}})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "addons stack")
+}
+
+func TestVerifyFlagsTypeMismatch(t *testing.T) {
+	outputs, err := Parse([]byte(`{"node_count": {"value": "three", "type": "string"}}`))
+	require.NoError(t, err)
+
+	violations := Verify(outputs, []Contract{{Name: "node_count", Type: TypeNumber, Because: "capacity stack"}})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "expected type")
+}
+
+func TestVerifyFlagsFormatMismatch(t *testing.T) {
+	outputs, err := Parse([]byte(`{"aks_cluster_id": {"value": "not-a-resource-id", "type": "string"}}`))
+	require.NoError(t, err)
+
+	violations := Verify(outputs, []Contract{
+		{Name: "aks_cluster_id", Type: TypeString, Format: regexp.MustCompile(`^/subscriptions/`), Because: "addons stack"},
+	})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "does not match expected format")
+}
+
+func TestParseReturnsErrorForInvalidJSON(t *testing.T) {
+	_, err := Parse([]byte(`not json`))
+	assert.Error(t, err)
+}
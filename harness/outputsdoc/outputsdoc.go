@@ -0,0 +1,77 @@
+// Package outputsdoc renders a stack's output contract (see
+// harness/outputcontract) plus its live `terraform output -json` values
+// into a consumer-facing reference — the endpoint FQDNs, resource IDs and
+// identity client IDs platform teams depend on, without making them read
+// Terraform source to find out what a stack exposes.
+package outputsdoc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/outputcontract"
+)
+
+// Field is one documented output: its contract plus, if present in the
+// live outputs, its current value.
+type Field struct {
+	Name    string
+	Type    outputcontract.Type
+	Because string
+	Value   any  // nil if the output is missing from the live outputs
+	Present bool // whether Value came from a live output, vs. being absent
+}
+
+// Doc is the rendered reference for one stack in one environment.
+type Doc struct {
+	Stack  string
+	Env    string
+	Fields []Field
+}
+
+// Build joins contracts with outputs' live values, sorted by output name
+// so JSON/Markdown renders are stable across runs.
+func Build(stack, env string, contracts []outputcontract.Contract, outputs outputcontract.Outputs) Doc {
+	doc := Doc{Stack: stack, Env: env}
+	for _, c := range contracts {
+		field := Field{Name: c.Name, Type: c.Type, Because: c.Because}
+		if raw, ok := outputs[c.Name]; ok {
+			var value any
+			if err := json.Unmarshal(raw.Value, &value); err == nil {
+				field.Value = value
+				field.Present = true
+			}
+		}
+		doc.Fields = append(doc.Fields, field)
+	}
+	sort.Slice(doc.Fields, func(i, j int) bool { return doc.Fields[i].Name < doc.Fields[j].Name })
+	return doc
+}
+
+// RenderJSON marshals doc as an indented JSON document.
+func RenderJSON(doc Doc) ([]byte, error) {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("outputsdoc: render JSON: %w", err)
+	}
+	return data, nil
+}
+
+// RenderMarkdown renders doc as a Markdown table of output name, type,
+// current value and which consumer depends on it.
+func RenderMarkdown(doc Doc) string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# %s (%s) outputs\n\n", doc.Stack, doc.Env)
+	fmt.Fprintf(&b, "| Output | Type | Value | Consumer |\n")
+	fmt.Fprintf(&b, "| --- | --- | --- | --- |\n")
+	for _, f := range doc.Fields {
+		value := "_missing_"
+		if f.Present {
+			value = fmt.Sprintf("`%v`", f.Value)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", f.Name, f.Type, value, f.Because)
+	}
+	return b.String()
+}
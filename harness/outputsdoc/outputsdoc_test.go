@@ -0,0 +1,62 @@
+package outputsdoc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/outputcontract"
+)
+
+func TestBuildJoinsContractsWithLiveValues(t *testing.T) {
+	contracts := []outputcontract.Contract{
+		{Name: "cluster_fqdn", Type: outputcontract.TypeString, Because: "app-platform stack"},
+		{Name: "identity_client_id", Type: outputcontract.TypeString, Because: "workload identity federation"},
+	}
+	outputs, err := outputcontract.Parse([]byte(`{"cluster_fqdn": {"value": "aks.example.com", "type": "string"}}`))
+	assert.NoError(t, err)
+
+	doc := Build("aks-cluster", "prod", contracts, outputs)
+
+	assert.Equal(t, "aks-cluster", doc.Stack)
+	assert.Equal(t, "prod", doc.Env)
+	assert.Equal(t, []Field{
+		{Name: "cluster_fqdn", Type: outputcontract.TypeString, Because: "app-platform stack", Value: "aks.example.com", Present: true},
+		{Name: "identity_client_id", Type: outputcontract.TypeString, Because: "workload identity federation"},
+	}, doc.Fields)
+}
+
+func TestBuildSortsFieldsByName(t *testing.T) {
+	contracts := []outputcontract.Contract{
+		{Name: "z_output", Type: outputcontract.TypeString},
+		{Name: "a_output", Type: outputcontract.TypeString},
+	}
+	doc := Build("stack", "dev", contracts, outputcontract.Outputs{})
+
+	assert.Equal(t, "a_output", doc.Fields[0].Name)
+	assert.Equal(t, "z_output", doc.Fields[1].Name)
+}
+
+func TestRenderJSONRoundTrips(t *testing.T) {
+	doc := Build("aks-cluster", "prod", []outputcontract.Contract{{Name: "cluster_fqdn", Type: outputcontract.TypeString}}, outputcontract.Outputs{})
+
+	data, err := RenderJSON(doc)
+	assert.NoError(t, err)
+
+	var decoded Doc
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, doc, decoded)
+}
+
+func TestRenderMarkdownIncludesFieldsAndMissingMarker(t *testing.T) {
+	contracts := []outputcontract.Contract{
+		{Name: "cluster_fqdn", Type: outputcontract.TypeString, Because: "app-platform stack"},
+	}
+	doc := Build("aks-cluster", "prod", contracts, outputcontract.Outputs{})
+
+	md := RenderMarkdown(doc)
+	assert.Contains(t, md, "# aks-cluster (prod) outputs")
+	assert.Contains(t, md, "cluster_fqdn")
+	assert.Contains(t, md, "_missing_")
+	assert.Contains(t, md, "app-platform stack")
+}
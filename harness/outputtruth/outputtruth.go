@@ -0,0 +1,84 @@
+// Package outputtruth cross-verifies Terraform outputs that carry a
+// resource ID against the live Azure resource: it fetches the resource
+// generically by ID and checks it actually exists and that the
+// properties the plan claimed still match. This catches outputs that
+// reference a stale or miscomposed ID — a risk the hand-split parsing used
+// elsewhere in these assertions makes easy to introduce (see
+// harness/resourceid) — that `terraform plan` alone can't surface, since
+// the plan only knows what it intends to create, not what's actually
+// there.
+package outputtruth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+)
+
+// Getter fetches a resource generically by its ARM ID, e.g. via
+// armresources.Client.GetByID.
+type Getter interface {
+	GetByID(ctx context.Context, resourceID, apiVersion string) (armresources.GenericResource, error)
+}
+
+// Expectation is what one output claimed about a resource, checked
+// against its live state. Properties compares only top-level keys of the
+// live resource's Properties map; nested mismatches are out of scope.
+type Expectation struct {
+	OutputName string
+	ResourceID string
+	APIVersion string
+	Properties map[string]any
+}
+
+// Violation is a single output that didn't truth-check against the live
+// resource.
+type Violation struct {
+	Message string
+}
+
+// Verify fetches every expectation's ResourceID via getter and reports a
+// Violation for each output whose resource is missing or whose properties
+// don't match what the plan claimed.
+func Verify(ctx context.Context, getter Getter, expectations []Expectation) []Violation {
+	var violations []Violation
+	for _, exp := range expectations {
+		resource, err := getter.GetByID(ctx, exp.ResourceID, exp.APIVersion)
+		if err != nil {
+			violations = append(violations, Violation{Message: fmt.Sprintf(
+				"output %q references resource ID %s, which could not be fetched (stale or miscomposed ID?): %v",
+				exp.OutputName, exp.ResourceID, err)})
+			continue
+		}
+		violations = append(violations, verifyProperties(exp, resource)...)
+	}
+	return violations
+}
+
+func verifyProperties(exp Expectation, resource armresources.GenericResource) []Violation {
+	if len(exp.Properties) == 0 {
+		return nil
+	}
+
+	live, ok := resource.Properties.(map[string]any)
+	if !ok {
+		return []Violation{{Message: fmt.Sprintf(
+			"output %q: resource %s has no readable properties to compare against", exp.OutputName, exp.ResourceID)}}
+	}
+
+	var violations []Violation
+	for key, want := range exp.Properties {
+		got, present := live[key]
+		if !present {
+			violations = append(violations, Violation{Message: fmt.Sprintf(
+				"output %q: resource %s has no property %q (plan expected %v)", exp.OutputName, exp.ResourceID, key, want)})
+			continue
+		}
+		if got != want {
+			violations = append(violations, Violation{Message: fmt.Sprintf(
+				"output %q: resource %s property %q is %v, plan expected %v", exp.OutputName, exp.ResourceID, key, got, want)})
+		}
+	}
+	return violations
+}
@@ -0,0 +1,101 @@
+package outputtruth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeGetter struct {
+	resources map[string]armresources.GenericResource
+	err       error
+}
+
+func (f *fakeGetter) GetByID(ctx context.Context, resourceID, apiVersion string) (armresources.GenericResource, error) {
+	if f.err != nil {
+		return armresources.GenericResource{}, f.err
+	}
+	r, ok := f.resources[resourceID]
+	if !ok {
+		return armresources.GenericResource{}, errors.New("not found")
+	}
+	return r, nil
+}
+
+func TestVerifyPassesWhenResourceExistsAndPropertiesMatch(t *testing.T) {
+	getter := &fakeGetter{resources: map[string]armresources.GenericResource{
+		"/subscriptions/x/rg1/providers/Microsoft.Cdn/profiles/p1": {
+			Properties: map[string]any{"sku": "Standard_Microsoft"},
+		},
+	}}
+
+	violations := Verify(context.Background(), getter, []Expectation{{
+		OutputName: "cdn_profile_id",
+		ResourceID: "/subscriptions/x/rg1/providers/Microsoft.Cdn/profiles/p1",
+		APIVersion: "2023-05-01",
+		Properties: map[string]any{"sku": "Standard_Microsoft"},
+	}})
+
+	assert.Empty(t, violations)
+}
+
+func TestVerifyFlagsMissingResource(t *testing.T) {
+	getter := &fakeGetter{resources: map[string]armresources.GenericResource{}}
+
+	violations := Verify(context.Background(), getter, []Expectation{{
+		OutputName: "cdn_profile_id",
+		ResourceID: "/subscriptions/x/rg1/providers/Microsoft.Cdn/profiles/stale",
+	}})
+
+	assert.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "stale or miscomposed")
+}
+
+func TestVerifyFlagsPropertyMismatch(t *testing.T) {
+	getter := &fakeGetter{resources: map[string]armresources.GenericResource{
+		"/subscriptions/x/rg1/providers/Microsoft.Cdn/profiles/p1": {
+			Properties: map[string]any{"sku": "Standard_Akamai"},
+		},
+	}}
+
+	violations := Verify(context.Background(), getter, []Expectation{{
+		OutputName: "cdn_profile_id",
+		ResourceID: "/subscriptions/x/rg1/providers/Microsoft.Cdn/profiles/p1",
+		Properties: map[string]any{"sku": "Standard_Microsoft"},
+	}})
+
+	assert.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "Standard_Akamai")
+}
+
+func TestVerifyFlagsMissingPropertyKey(t *testing.T) {
+	getter := &fakeGetter{resources: map[string]armresources.GenericResource{
+		"/subscriptions/x/rg1/providers/Microsoft.Cdn/profiles/p1": {
+			Properties: map[string]any{},
+		},
+	}}
+
+	violations := Verify(context.Background(), getter, []Expectation{{
+		OutputName: "cdn_profile_id",
+		ResourceID: "/subscriptions/x/rg1/providers/Microsoft.Cdn/profiles/p1",
+		Properties: map[string]any{"sku": "Standard_Microsoft"},
+	}})
+
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifySkipsPropertyCheckWhenNoneExpected(t *testing.T) {
+	getter := &fakeGetter{resources: map[string]armresources.GenericResource{
+		"/subscriptions/x/rg1/providers/Microsoft.Cdn/profiles/p1": {},
+	}}
+
+	violations := Verify(context.Background(), getter, []Expectation{{
+		OutputName: "cdn_profile_id",
+		ResourceID: "/subscriptions/x/rg1/providers/Microsoft.Cdn/profiles/p1",
+	}})
+
+	assert.Empty(t, violations)
+}
@@ -0,0 +1,64 @@
+// Package patchcurrency flags node pools running a node image more than N
+// releases behind the latest available, surfacing patch debt in the
+// scheduled drift/canary report before it becomes an unpatched CVE.
+package patchcurrency
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// releaseDate matches the AKS node image naming convention, e.g.
+// "AKSUbuntu-2204gen2containerd-202508.09.0", capturing the YYYYMM.DD
+// release stamp.
+var releaseDate = regexp.MustCompile(`(\d{6})\.(\d{2})\b`)
+
+// Finding reports a node pool whose image is behind the latest available.
+type Finding struct {
+	AgentPoolName  string
+	CurrentImage   string
+	LatestImage    string
+	ReleasesBehind int
+}
+
+// Check compares currentImage against latestImage for agentPoolName,
+// returning a Finding if currentImage is more than maxReleasesBehind
+// AKS monthly releases old. AKS ships a node image release roughly every
+// month, so "releases behind" here is simply months behind.
+func Check(agentPoolName, currentImage, latestImage string, maxReleasesBehind int) (*Finding, error) {
+	currentMonths, err := releaseMonths(currentImage)
+	if err != nil {
+		return nil, fmt.Errorf("patchcurrency: parse current image %q: %w", currentImage, err)
+	}
+	latestMonths, err := releaseMonths(latestImage)
+	if err != nil {
+		return nil, fmt.Errorf("patchcurrency: parse latest image %q: %w", latestImage, err)
+	}
+
+	behind := latestMonths - currentMonths
+	if behind <= maxReleasesBehind {
+		return nil, nil
+	}
+	return &Finding{
+		AgentPoolName:  agentPoolName,
+		CurrentImage:   currentImage,
+		LatestImage:    latestImage,
+		ReleasesBehind: behind,
+	}, nil
+}
+
+// releaseMonths converts an image's YYYYMM release stamp into an absolute
+// month count, so subtracting two gives "months behind" directly.
+func releaseMonths(image string) (int, error) {
+	m := releaseDate.FindStringSubmatch(image)
+	if m == nil {
+		return 0, fmt.Errorf("no release date found")
+	}
+	yyyymm, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, err
+	}
+	year, month := yyyymm/100, yyyymm%100
+	return year*12 + month, nil
+}
@@ -0,0 +1,26 @@
+package patchcurrency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckFlagsImageMoreThanMaxReleasesBehind(t *testing.T) {
+	finding, err := Check("system", "AKSUbuntu-2204gen2containerd-202502.09.0", "AKSUbuntu-2204gen2containerd-202508.09.0", 2)
+	require.NoError(t, err)
+	require.NotNil(t, finding)
+	assert.Equal(t, 6, finding.ReleasesBehind)
+}
+
+func TestCheckPassesWithinTolerance(t *testing.T) {
+	finding, err := Check("system", "AKSUbuntu-2204gen2containerd-202507.09.0", "AKSUbuntu-2204gen2containerd-202508.09.0", 2)
+	require.NoError(t, err)
+	assert.Nil(t, finding)
+}
+
+func TestCheckErrorsOnUnparsableImage(t *testing.T) {
+	_, err := Check("system", "not-a-valid-image", "AKSUbuntu-2204gen2containerd-202508.09.0", 2)
+	assert.Error(t, err)
+}
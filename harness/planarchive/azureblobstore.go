@@ -0,0 +1,67 @@
+package planarchive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+)
+
+// AzureBlobStore implements BlobStore against a single blob container using
+// the azblob SDK.
+type AzureBlobStore struct {
+	client    *azblob.Client
+	container string
+}
+
+// NewAzureBlobStore wraps an already-constructed azblob.Client scoped to
+// containerName.
+func NewAzureBlobStore(client *azblob.Client, containerName string) *AzureBlobStore {
+	return &AzureBlobStore{client: client, container: containerName}
+}
+
+// Upload implements BlobStore.
+func (s *AzureBlobStore) Upload(ctx context.Context, blobName string, data []byte) error {
+	_, err := s.client.UploadBuffer(ctx, s.container, blobName, data, nil)
+	if err != nil {
+		return fmt.Errorf("planarchive: azure upload %s: %w", blobName, err)
+	}
+	return nil
+}
+
+// Download implements BlobStore.
+func (s *AzureBlobStore) Download(ctx context.Context, blobName string) ([]byte, error) {
+	resp, err := s.client.DownloadStream(ctx, s.container, blobName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("planarchive: azure download %s: %w", blobName, err)
+	}
+	defer resp.Body.Close()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, resp.Body); err != nil {
+		return nil, fmt.Errorf("planarchive: read %s: %w", blobName, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// List implements BlobStore, returning every blob name under prefix.
+func (s *AzureBlobStore) List(ctx context.Context, prefix string) ([]string, error) {
+	var names []string
+	pager := s.client.NewListBlobsFlatPager(s.container, &azblob.ListBlobsFlatOptions{
+		Prefix: &prefix,
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("planarchive: list blobs under %s: %w", prefix, err)
+		}
+		for _, item := range page.Segment.BlobItems {
+			if item.Name != nil {
+				names = append(names, *item.Name)
+			}
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,110 @@
+// Package planarchive persists every CI `terraform show -json` plan to blob
+// storage, keyed by commit/stack/env, so past plans can be pulled back up
+// for incident retrospectives instead of only living in an expired CI log.
+package planarchive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Key identifies a single archived plan.
+type Key struct {
+	Commit string
+	Stack  string
+	Env    string
+}
+
+// BlobName returns the deterministic blob path for k: "<stack>/<env>/<commit>.json.gz".
+func (k Key) BlobName() string {
+	return fmt.Sprintf("%s/%s/%s.json.gz", k.Stack, k.Env, k.Commit)
+}
+
+// ParseBlobName reverses BlobName, recovering the Key it was written with.
+func ParseBlobName(name string) (Key, error) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 3 {
+		return Key{}, fmt.Errorf("planarchive: %q is not a stack/env/commit.json.gz blob name", name)
+	}
+	commit := strings.TrimSuffix(parts[2], ".json.gz")
+	if commit == parts[2] {
+		return Key{}, fmt.Errorf("planarchive: %q does not end in .json.gz", name)
+	}
+	return Key{Stack: parts[0], Env: parts[1], Commit: commit}, nil
+}
+
+// BlobStore is the minimal blob container operation this package needs,
+// satisfied in production by AzureBlobStore and by a fake in tests.
+type BlobStore interface {
+	Upload(ctx context.Context, blobName string, data []byte) error
+	Download(ctx context.Context, blobName string) ([]byte, error)
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// Archive gzip-compresses planJSON and uploads it to store under key's blob
+// name.
+func Archive(ctx context.Context, store BlobStore, key Key, planJSON []byte) error {
+	compressed, err := compress(planJSON)
+	if err != nil {
+		return fmt.Errorf("planarchive: compress plan for %s: %w", key.BlobName(), err)
+	}
+	if err := store.Upload(ctx, key.BlobName(), compressed); err != nil {
+		return fmt.Errorf("planarchive: upload %s: %w", key.BlobName(), err)
+	}
+	return nil
+}
+
+// Fetch downloads and decompresses the plan JSON archived under key.
+func Fetch(ctx context.Context, store BlobStore, key Key) ([]byte, error) {
+	compressed, err := store.Download(ctx, key.BlobName())
+	if err != nil {
+		return nil, fmt.Errorf("planarchive: download %s: %w", key.BlobName(), err)
+	}
+	planJSON, err := decompress(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("planarchive: decompress %s: %w", key.BlobName(), err)
+	}
+	return planJSON, nil
+}
+
+// List returns every archived Key for the given stack and env.
+func List(ctx context.Context, store BlobStore, stack, env string) ([]Key, error) {
+	names, err := store.List(ctx, fmt.Sprintf("%s/%s/", stack, env))
+	if err != nil {
+		return nil, fmt.Errorf("planarchive: list %s/%s: %w", stack, env, err)
+	}
+	keys := make([]Key, 0, len(names))
+	for _, name := range names {
+		key, err := ParseBlobName(name)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
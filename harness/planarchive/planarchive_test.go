@@ -0,0 +1,71 @@
+package planarchive
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	blobs map[string][]byte
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{blobs: map[string][]byte{}}
+}
+
+func (s *fakeStore) Upload(ctx context.Context, blobName string, data []byte) error {
+	s.blobs[blobName] = append([]byte(nil), data...)
+	return nil
+}
+
+func (s *fakeStore) Download(ctx context.Context, blobName string) ([]byte, error) {
+	data, ok := s.blobs[blobName]
+	if !ok {
+		return nil, assert.AnError
+	}
+	return data, nil
+}
+
+func (s *fakeStore) List(ctx context.Context, prefix string) ([]string, error) {
+	var names []string
+	for name := range s.blobs {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func TestKeyBlobNameRoundTrips(t *testing.T) {
+	key := Key{Commit: "abc123", Stack: "aks", Env: "prod"}
+	parsed, err := ParseBlobName(key.BlobName())
+	require.NoError(t, err)
+	assert.Equal(t, key, parsed)
+}
+
+func TestArchiveAndFetchRoundTrip(t *testing.T) {
+	store := newFakeStore()
+	key := Key{Commit: "abc123", Stack: "aks", Env: "prod"}
+	planJSON := []byte(`{"resource_changes":[]}`)
+
+	require.NoError(t, Archive(context.Background(), store, key, planJSON))
+
+	fetched, err := Fetch(context.Background(), store, key)
+	require.NoError(t, err)
+	assert.Equal(t, planJSON, fetched)
+}
+
+func TestListReturnsKeysUnderStackAndEnv(t *testing.T) {
+	store := newFakeStore()
+	require.NoError(t, Archive(context.Background(), store, Key{Commit: "c1", Stack: "aks", Env: "prod"}, []byte("{}")))
+	require.NoError(t, Archive(context.Background(), store, Key{Commit: "c2", Stack: "aks", Env: "prod"}, []byte("{}")))
+	require.NoError(t, Archive(context.Background(), store, Key{Commit: "c3", Stack: "aks", Env: "dev"}, []byte("{}")))
+
+	keys, err := List(context.Background(), store, "aks", "prod")
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+}
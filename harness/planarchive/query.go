@@ -0,0 +1,57 @@
+package planarchive
+
+import (
+	"reflect"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/planparser"
+)
+
+// Snapshot pairs an archived plan with the commit it was generated from.
+// History passed to FindAttributeChanges should be ordered oldest-first.
+type Snapshot struct {
+	Commit string
+	Plan   planparser.Plan
+}
+
+// AttributeChange records a single observed transition of one resource
+// attribute from OldValue to NewValue, first seen at Commit.
+type AttributeChange struct {
+	Commit   string
+	OldValue interface{}
+	NewValue interface{}
+}
+
+// FindAttributeChanges walks history in order and returns every commit at
+// which address's attribute value differs from its value in the previous
+// snapshot that mentioned it. Snapshots where address isn't present (the
+// resource hadn't been created yet, or was outside the plan) are skipped
+// rather than treated as a change.
+func FindAttributeChanges(history []Snapshot, address, attribute string) []AttributeChange {
+	var changes []AttributeChange
+	var last interface{}
+	haveLast := false
+
+	for _, snap := range history {
+		value, found := attributeValue(snap.Plan, address, attribute)
+		if !found {
+			continue
+		}
+		if haveLast && !reflect.DeepEqual(last, value) {
+			changes = append(changes, AttributeChange{Commit: snap.Commit, OldValue: last, NewValue: value})
+		}
+		last = value
+		haveLast = true
+	}
+	return changes
+}
+
+func attributeValue(plan planparser.Plan, address, attribute string) (interface{}, bool) {
+	for _, rc := range plan.ResourceChanges {
+		if rc.Address != address {
+			continue
+		}
+		v, ok := rc.After[attribute]
+		return v, ok
+	}
+	return nil, false
+}
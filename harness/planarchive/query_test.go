@@ -0,0 +1,54 @@
+package planarchive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/planparser"
+)
+
+func planWithSKU(sku string) planparser.Plan {
+	return planparser.Plan{ResourceChanges: []planparser.ResourceChange{
+		{
+			Address: "azurerm_kubernetes_cluster.this",
+			Type:    "azurerm_kubernetes_cluster",
+			Name:    "this",
+			After:   map[string]any{"sku_tier": sku},
+		},
+	}}
+}
+
+func TestFindAttributeChangesTracksTransitions(t *testing.T) {
+	history := []Snapshot{
+		{Commit: "c1", Plan: planWithSKU("Free")},
+		{Commit: "c2", Plan: planWithSKU("Free")},
+		{Commit: "c3", Plan: planWithSKU("Standard")},
+	}
+
+	changes := FindAttributeChanges(history, "azurerm_kubernetes_cluster.this", "sku_tier")
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "c3", changes[0].Commit)
+	assert.Equal(t, "Free", changes[0].OldValue)
+	assert.Equal(t, "Standard", changes[0].NewValue)
+}
+
+func TestFindAttributeChangesSkipsSnapshotsMissingResource(t *testing.T) {
+	history := []Snapshot{
+		{Commit: "c1", Plan: planparser.Plan{}},
+		{Commit: "c2", Plan: planWithSKU("Free")},
+		{Commit: "c3", Plan: planWithSKU("Standard")},
+	}
+
+	changes := FindAttributeChanges(history, "azurerm_kubernetes_cluster.this", "sku_tier")
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "c3", changes[0].Commit)
+}
+
+func TestFindAttributeChangesEmptyWhenNoChange(t *testing.T) {
+	history := []Snapshot{
+		{Commit: "c1", Plan: planWithSKU("Standard")},
+		{Commit: "c2", Plan: planWithSKU("Standard")},
+	}
+
+	assert.Empty(t, FindAttributeChanges(history, "azurerm_kubernetes_cluster.this", "sku_tier"))
+}
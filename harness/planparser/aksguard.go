@@ -0,0 +1,33 @@
+package planparser
+
+import "fmt"
+
+// guardedTypes are the AKS resource types whose replacement destroys
+// running workloads, regardless of environment.
+var guardedTypes = map[string]bool{
+	"azurerm_kubernetes_cluster":           true,
+	"azurerm_kubernetes_cluster_node_pool": true,
+}
+
+// GuardAKSReplacements fails with the offending resource address and
+// attribute diff if plan would force replacement of an AKS cluster or node
+// pool resource. Unlike riskscore's general classification, this is a hard
+// gate with no override: an AKS replacement is never an acceptable
+// plan-mode outcome across any environment.
+func GuardAKSReplacements(plan Plan) error {
+	var violations []ResourceChange
+	for _, rc := range plan.ResourceChanges {
+		if guardedTypes[rc.Type] && rc.IsReplace() {
+			violations = append(violations, rc)
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("planparser: %d AKS resource(s) would be replaced, destroying workloads:", len(violations))
+	for _, rc := range violations {
+		msg += fmt.Sprintf("\n  %s (%s) replace paths: %v", rc.Address, rc.Type, rc.ReplacePaths)
+	}
+	return fmt.Errorf("%s", msg)
+}
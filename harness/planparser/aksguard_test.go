@@ -0,0 +1,35 @@
+package planparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuardAKSReplacementsFailsOnClusterReplace(t *testing.T) {
+	plan, err := Parse([]byte(`{
+      "resource_changes": [
+        {"address": "azurerm_kubernetes_cluster.this", "type": "azurerm_kubernetes_cluster", "name": "this",
+         "change": {"actions": ["delete", "create"], "replace_paths": [["sku_tier"]]}}
+      ]
+    }`))
+	require.NoError(t, err)
+
+	err = GuardAKSReplacements(plan)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "azurerm_kubernetes_cluster.this")
+	assert.Contains(t, err.Error(), "sku_tier")
+}
+
+func TestGuardAKSReplacementsPassesOnUpdate(t *testing.T) {
+	plan, err := Parse([]byte(`{
+      "resource_changes": [
+        {"address": "azurerm_kubernetes_cluster.this", "type": "azurerm_kubernetes_cluster", "name": "this",
+         "change": {"actions": ["update"]}}
+      ]
+    }`))
+	require.NoError(t, err)
+
+	assert.NoError(t, GuardAKSReplacements(plan))
+}
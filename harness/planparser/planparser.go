@@ -0,0 +1,129 @@
+// Package planparser parses the JSON produced by `terraform show -json
+// <planfile>` into structured resource changes, so downstream checks
+// (riskscore, replacement guards, output contract tests) work against Go
+// structs instead of re-parsing JSON ad hoc.
+package planparser
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Action is one of Terraform's plan change actions.
+type Action string
+
+const (
+	ActionNoOp    Action = "no-op"
+	ActionCreate  Action = "create"
+	ActionUpdate  Action = "update"
+	ActionDelete  Action = "delete"
+	ActionReplace Action = "replace" // never set by Parse: a replacement is a delete+create pair (see IsReplace)
+	ActionRead    Action = "read"
+)
+
+// ResourceChange is a single planned change to a resource address.
+type ResourceChange struct {
+	Address      string
+	Type         string
+	Name         string
+	ModuleAddr   string
+	Actions      []Action
+	Before       map[string]any
+	After        map[string]any
+	ReplacePaths [][]any
+}
+
+// IsReplace reports whether this change replaces the resource
+// (delete-then-create or create-then-delete).
+func (c ResourceChange) IsReplace() bool {
+	return containsAction(c.Actions, ActionDelete) && containsAction(c.Actions, ActionCreate)
+}
+
+// IsDelete reports whether this change deletes the resource outright
+// (delete without a matching create).
+func (c ResourceChange) IsDelete() bool {
+	return containsAction(c.Actions, ActionDelete) && !containsAction(c.Actions, ActionCreate)
+}
+
+func containsAction(actions []Action, target Action) bool {
+	for _, a := range actions {
+		if a == target {
+			return true
+		}
+	}
+	return false
+}
+
+// rawPlan mirrors the subset of `terraform show -json` output this package
+// consumes.
+type rawPlan struct {
+	ResourceChanges []struct {
+		Address    string `json:"address"`
+		Type       string `json:"type"`
+		Name       string `json:"name"`
+		ModuleAddr string `json:"module_address"`
+		Change     struct {
+			Actions      []string        `json:"actions"`
+			Before       json.RawMessage `json:"before"`
+			After        json.RawMessage `json:"after"`
+			ReplacePaths [][]any         `json:"replace_paths"`
+		} `json:"change"`
+	} `json:"resource_changes"`
+}
+
+// Plan is the parsed set of resource changes in a Terraform plan.
+type Plan struct {
+	ResourceChanges []ResourceChange
+}
+
+// Parse parses raw `terraform show -json` output.
+func Parse(data []byte) (Plan, error) {
+	var raw rawPlan
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Plan{}, fmt.Errorf("planparser: parse plan JSON: %w", err)
+	}
+
+	var plan Plan
+	for _, rc := range raw.ResourceChanges {
+		change := ResourceChange{
+			Address:      rc.Address,
+			Type:         rc.Type,
+			Name:         rc.Name,
+			ModuleAddr:   rc.ModuleAddr,
+			ReplacePaths: rc.Change.ReplacePaths,
+		}
+		for _, a := range rc.Change.Actions {
+			change.Actions = append(change.Actions, Action(a))
+		}
+		if len(rc.Change.Before) > 0 {
+			_ = json.Unmarshal(rc.Change.Before, &change.Before)
+		}
+		if len(rc.Change.After) > 0 {
+			_ = json.Unmarshal(rc.Change.After, &change.After)
+		}
+		plan.ResourceChanges = append(plan.ResourceChanges, change)
+	}
+	return plan, nil
+}
+
+// Replacements returns every resource change that replaces its resource.
+func (p Plan) Replacements() []ResourceChange {
+	var out []ResourceChange
+	for _, rc := range p.ResourceChanges {
+		if rc.IsReplace() {
+			out = append(out, rc)
+		}
+	}
+	return out
+}
+
+// ByType returns every resource change whose Type matches resourceType.
+func (p Plan) ByType(resourceType string) []ResourceChange {
+	var out []ResourceChange
+	for _, rc := range p.ResourceChanges {
+		if rc.Type == resourceType {
+			out = append(out, rc)
+		}
+	}
+	return out
+}
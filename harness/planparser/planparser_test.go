@@ -0,0 +1,54 @@
+package planparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const samplePlan = `{
+  "resource_changes": [
+    {
+      "address": "azurerm_kubernetes_cluster.this",
+      "type": "azurerm_kubernetes_cluster",
+      "name": "this",
+      "module_address": "",
+      "change": {
+        "actions": ["delete", "create"],
+        "before": {"kubernetes_version": "1.29"},
+        "after": {"kubernetes_version": "1.30"},
+        "replace_paths": [["kubernetes_version"]]
+      }
+    },
+    {
+      "address": "azurerm_resource_group.this",
+      "type": "azurerm_resource_group",
+      "name": "this",
+      "module_address": "",
+      "change": {"actions": ["update"]}
+    },
+    {
+      "address": "azurerm_dns_zone.old",
+      "type": "azurerm_dns_zone",
+      "name": "old",
+      "module_address": "",
+      "change": {"actions": ["delete"]}
+    }
+  ]
+}`
+
+func TestParseFlagsReplacementsAndDeletes(t *testing.T) {
+	plan, err := Parse([]byte(samplePlan))
+	require.NoError(t, err)
+	require.Len(t, plan.ResourceChanges, 3)
+
+	replacements := plan.Replacements()
+	require.Len(t, replacements, 1)
+	assert.Equal(t, "azurerm_kubernetes_cluster.this", replacements[0].Address)
+	assert.True(t, replacements[0].IsReplace())
+
+	dnsChanges := plan.ByType("azurerm_dns_zone")
+	require.Len(t, dnsChanges, 1)
+	assert.True(t, dnsChanges[0].IsDelete())
+}
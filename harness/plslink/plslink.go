@@ -0,0 +1,107 @@
+// Package plslink tests Private Link Service exposure: that a PLS is
+// configured with a dedicated NAT subnet, the expected visibility/
+// auto-approval subscription lists, and that a private endpoint connection
+// from a consumer VNet fixture can be approved. Traffic over the approved
+// connection is not checked by this package.
+package plslink
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v6"
+)
+
+// ExpectedConfig is what a PLS deployment is expected to look like.
+type ExpectedConfig struct {
+	// NATSubnetID is the resource ID of the subnet the PLS's IP
+	// configurations must live in.
+	NATSubnetID string
+	// VisibilitySubscriptions is the allow-list of subscription IDs
+	// permitted to see the PLS in "list available private link services".
+	VisibilitySubscriptions []string
+	// AutoApproveSubscriptions is the subset of VisibilitySubscriptions
+	// whose connection requests are auto-approved.
+	AutoApproveSubscriptions []string
+}
+
+// VerifyConfig compares a deployed PLS against the expected configuration
+// and returns every mismatch found (nil if fully compliant).
+func VerifyConfig(pls armnetwork.PrivateLinkService, expected ExpectedConfig) []string {
+	var mismatches []string
+	props := pls.Properties
+	if props == nil {
+		return []string{"private link service has no properties"}
+	}
+
+	if !hasIPConfigInSubnet(props.IPConfigurations, expected.NATSubnetID) {
+		mismatches = append(mismatches, fmt.Sprintf("no IP configuration found in NAT subnet %s", expected.NATSubnetID))
+	}
+	var visibility, autoApproval []*string
+	if props.Visibility != nil {
+		visibility = props.Visibility.Subscriptions
+	}
+	if props.AutoApproval != nil {
+		autoApproval = props.AutoApproval.Subscriptions
+	}
+	if !sameSet(stringsOf(visibility), expected.VisibilitySubscriptions) {
+		mismatches = append(mismatches, "visibility subscription list does not match expected")
+	}
+	if !sameSet(stringsOf(autoApproval), expected.AutoApproveSubscriptions) {
+		mismatches = append(mismatches, "auto-approval subscription list does not match expected")
+	}
+	return mismatches
+}
+
+func hasIPConfigInSubnet(configs []*armnetwork.PrivateLinkServiceIPConfiguration, subnetID string) bool {
+	for _, cfg := range configs {
+		if cfg == nil || cfg.Properties == nil || cfg.Properties.Subnet == nil || cfg.Properties.Subnet.ID == nil {
+			continue
+		}
+		if *cfg.Properties.Subnet.ID == subnetID {
+			return true
+		}
+	}
+	return false
+}
+
+func stringsOf(ptrs []*string) []string {
+	var out []string
+	for _, s := range ptrs {
+		if s != nil {
+			out = append(out, *s)
+		}
+	}
+	return out
+}
+
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]bool, len(a))
+	for _, v := range a {
+		seen[v] = true
+	}
+	for _, v := range b {
+		if !seen[v] {
+			return false
+		}
+	}
+	return true
+}
+
+// Approver is the subset of the private endpoint connection client used to
+// approve a consumer's connection request.
+type Approver interface {
+	ApproveConnection(ctx context.Context, resourceGroup, plsName, connectionName string) error
+}
+
+// ApproveConsumerConnection approves the named pending connection so the
+// consumer VNet fixture's private endpoint can start passing traffic.
+func ApproveConsumerConnection(ctx context.Context, approver Approver, resourceGroup, plsName, connectionName string) error {
+	if err := approver.ApproveConnection(ctx, resourceGroup, plsName, connectionName); err != nil {
+		return fmt.Errorf("plslink: approve connection %s on %s: %w", connectionName, plsName, err)
+	}
+	return nil
+}
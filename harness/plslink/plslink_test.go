@@ -0,0 +1,67 @@
+package plslink
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestVerifyConfigPassesForCompliantPLS(t *testing.T) {
+	pls := armnetwork.PrivateLinkService{
+		Properties: &armnetwork.PrivateLinkServiceProperties{
+			IPConfigurations: []*armnetwork.PrivateLinkServiceIPConfiguration{
+				{Properties: &armnetwork.PrivateLinkServiceIPConfigurationProperties{
+					Subnet: &armnetwork.Subnet{ID: strPtr("/subnets/nat")},
+				}},
+			},
+			Visibility:   &armnetwork.PrivateLinkServicePropertiesVisibility{Subscriptions: []*string{strPtr("sub-a")}},
+			AutoApproval: &armnetwork.PrivateLinkServicePropertiesAutoApproval{Subscriptions: []*string{strPtr("sub-a")}},
+		},
+	}
+
+	mismatches := VerifyConfig(pls, ExpectedConfig{
+		NATSubnetID:              "/subnets/nat",
+		VisibilitySubscriptions:  []string{"sub-a"},
+		AutoApproveSubscriptions: []string{"sub-a"},
+	})
+
+	assert.Empty(t, mismatches)
+}
+
+func TestVerifyConfigFlagsWrongSubnetAndVisibility(t *testing.T) {
+	pls := armnetwork.PrivateLinkService{
+		Properties: &armnetwork.PrivateLinkServiceProperties{
+			IPConfigurations: []*armnetwork.PrivateLinkServiceIPConfiguration{
+				{Properties: &armnetwork.PrivateLinkServiceIPConfigurationProperties{
+					Subnet: &armnetwork.Subnet{ID: strPtr("/subnets/other")},
+				}},
+			},
+			Visibility: &armnetwork.PrivateLinkServicePropertiesVisibility{Subscriptions: []*string{strPtr("sub-b")}},
+		},
+	}
+
+	mismatches := VerifyConfig(pls, ExpectedConfig{
+		NATSubnetID:             "/subnets/nat",
+		VisibilitySubscriptions: []string{"sub-a"},
+	})
+
+	assert.Len(t, mismatches, 2)
+}
+
+type fakeApprover struct{ approved string }
+
+func (f *fakeApprover) ApproveConnection(ctx context.Context, resourceGroup, plsName, connectionName string) error {
+	f.approved = connectionName
+	return nil
+}
+
+func TestApproveConsumerConnection(t *testing.T) {
+	fake := &fakeApprover{}
+	require.NoError(t, ApproveConsumerConnection(context.Background(), fake, "rg", "pls", "conn-1"))
+	assert.Equal(t, "conn-1", fake.approved)
+}
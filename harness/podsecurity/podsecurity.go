@@ -0,0 +1,105 @@
+// Package podsecurity asserts the Pod Security Standards admission level
+// configured per namespace and confirms it's actually enforced: a
+// privileged test pod must be rejected in restricted namespaces and
+// permitted only where documented as an exception.
+package podsecurity
+
+import "fmt"
+
+// Level is a Pod Security Standards enforcement level.
+type Level string
+
+const (
+	LevelPrivileged Level = "privileged"
+	LevelBaseline   Level = "baseline"
+	LevelRestricted Level = "restricted"
+)
+
+// EnforceLabel is the namespace label Kubernetes' built-in Pod Security
+// admission controller reads.
+const EnforceLabel = "pod-security.kubernetes.io/enforce"
+
+// ExpectedNamespace is the PSS level a namespace must enforce, and whether
+// a privileged test pod is documented to be allowed there.
+type ExpectedNamespace struct {
+	Name            string
+	Level           Level
+	AllowPrivileged bool
+}
+
+// NamespaceLabels is a namespace's observed labels, as read from the
+// Kubernetes API.
+type NamespaceLabels struct {
+	Name   string
+	Labels map[string]string
+}
+
+// AdmissionResult is whether a privileged test pod was admitted when a
+// probe applied one to Namespace.
+type AdmissionResult struct {
+	Namespace string
+	Admitted  bool
+}
+
+// Result is the outcome for a single namespace.
+type Result struct {
+	Namespace string
+	Pass      bool
+	Detail    string
+}
+
+// Report is the outcome of checking every expected namespace.
+type Report struct {
+	Results []Result
+}
+
+// Failures returns only the namespaces that failed.
+func (r Report) Failures() []Result {
+	var failures []Result
+	for _, res := range r.Results {
+		if !res.Pass {
+			failures = append(failures, res)
+		}
+	}
+	return failures
+}
+
+// Check verifies each ExpectedNamespace's enforce label matches observed
+// labels, and that the observed privileged-pod admission result matches
+// AllowPrivileged.
+func Check(expected []ExpectedNamespace, labels []NamespaceLabels, admission []AdmissionResult) Report {
+	labelsByNS := make(map[string]NamespaceLabels, len(labels))
+	for _, l := range labels {
+		labelsByNS[l.Name] = l
+	}
+	admissionByNS := make(map[string]AdmissionResult, len(admission))
+	for _, a := range admission {
+		admissionByNS[a.Namespace] = a
+	}
+
+	var results []Result
+	for _, exp := range expected {
+		ns, ok := labelsByNS[exp.Name]
+		if !ok {
+			results = append(results, Result{Namespace: exp.Name, Pass: false, Detail: "namespace not found"})
+			continue
+		}
+		if got := ns.Labels[EnforceLabel]; got != string(exp.Level) {
+			results = append(results, Result{Namespace: exp.Name, Pass: false, Detail: fmt.Sprintf("%s=%q, expected %q", EnforceLabel, got, exp.Level)})
+			continue
+		}
+
+		adm, ok := admissionByNS[exp.Name]
+		if !ok {
+			results = append(results, Result{Namespace: exp.Name, Pass: false, Detail: "no privileged-pod admission probe recorded"})
+			continue
+		}
+		if adm.Admitted != exp.AllowPrivileged {
+			results = append(results, Result{Namespace: exp.Name, Pass: false, Detail: fmt.Sprintf("privileged pod admitted=%v, expected %v", adm.Admitted, exp.AllowPrivileged)})
+			continue
+		}
+
+		results = append(results, Result{Namespace: exp.Name, Pass: true})
+	}
+	return Report{Results: results}
+}
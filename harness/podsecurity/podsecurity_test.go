@@ -0,0 +1,50 @@
+package podsecurity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPassesForCompliantRestrictedNamespace(t *testing.T) {
+	expected := []ExpectedNamespace{{Name: "workloads", Level: LevelRestricted, AllowPrivileged: false}}
+	labels := []NamespaceLabels{{Name: "workloads", Labels: map[string]string{EnforceLabel: "restricted"}}}
+	admission := []AdmissionResult{{Namespace: "workloads", Admitted: false}}
+
+	report := Check(expected, labels, admission)
+	assert.Empty(t, report.Failures())
+}
+
+func TestCheckFlagsWrongEnforceLabel(t *testing.T) {
+	expected := []ExpectedNamespace{{Name: "workloads", Level: LevelRestricted}}
+	labels := []NamespaceLabels{{Name: "workloads", Labels: map[string]string{EnforceLabel: "baseline"}}}
+
+	report := Check(expected, labels, nil)
+	failures := report.Failures()
+	assert.Len(t, failures, 1)
+	assert.Contains(t, failures[0].Detail, "baseline")
+}
+
+func TestCheckFlagsPrivilegedPodAdmittedUnexpectedly(t *testing.T) {
+	expected := []ExpectedNamespace{{Name: "workloads", Level: LevelRestricted, AllowPrivileged: false}}
+	labels := []NamespaceLabels{{Name: "workloads", Labels: map[string]string{EnforceLabel: "restricted"}}}
+	admission := []AdmissionResult{{Namespace: "workloads", Admitted: true}}
+
+	report := Check(expected, labels, admission)
+	assert.Len(t, report.Failures(), 1)
+}
+
+func TestCheckAllowsPrivilegedPodInDocumentedExceptionNamespace(t *testing.T) {
+	expected := []ExpectedNamespace{{Name: "kube-system", Level: LevelPrivileged, AllowPrivileged: true}}
+	labels := []NamespaceLabels{{Name: "kube-system", Labels: map[string]string{EnforceLabel: "privileged"}}}
+	admission := []AdmissionResult{{Namespace: "kube-system", Admitted: true}}
+
+	report := Check(expected, labels, admission)
+	assert.Empty(t, report.Failures())
+}
+
+func TestCheckFlagsMissingNamespace(t *testing.T) {
+	expected := []ExpectedNamespace{{Name: "missing", Level: LevelRestricted}}
+	report := Check(expected, nil, nil)
+	assert.Len(t, report.Failures(), 1)
+}
@@ -0,0 +1,106 @@
+// Package poolrotation rehearses the documented blue/green node pool
+// rotation procedure in automation: apply the tfvars change that creates
+// the new pool, cordon/drain and delete the old one while a continuous
+// probe samples the workload, and assert the probe never observed a
+// failure during the rotation window. It's opt-in — the drill mutates a
+// real environment's node pools — so callers gate it behind a test tier
+// or explicit flag rather than running it on every commit.
+package poolrotation
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Applier applies the tfvars change that adds the new node pool, e.g.
+// runner.Runner.Up.
+type Applier interface {
+	Apply(stack string) error
+}
+
+// Drainer cordons and drains the old node pool's nodes, then deletes the
+// pool itself. Implementations typically wrap a client-go Kubernetes
+// client for the cordon/drain step and an AKS ARM client for the delete.
+type Drainer interface {
+	CordonAndDrain(ctx context.Context, nodePool string) error
+	Delete(ctx context.Context, nodePool string) error
+}
+
+// Prober exercises the workload once to confirm it's actually serving
+// traffic, not just that Kubernetes reports it healthy.
+type Prober interface {
+	Attempt(ctx context.Context) error
+}
+
+// Result records the outcome of every step of the rotation, including
+// every probe failure observed during the drain/delete window.
+type Result struct {
+	CreateApplyErr error
+	DrainErr       error
+	DeleteErr      error
+	ProbeFailures  []error
+}
+
+// Passed reports whether the new pool applied cleanly, the old pool
+// drained and deleted without error, and not a single probe attempt
+// failed during the rotation window.
+func (r Result) Passed() bool {
+	return r.CreateApplyErr == nil && r.DrainErr == nil && r.DeleteErr == nil && len(r.ProbeFailures) == 0
+}
+
+// Run applies the new-pool tfvars change for stack via applier, then
+// starts prober sampling every probeInterval for the duration of
+// cordoning/draining and deleting oldNodePool via drainer. Every failed
+// probe attempt during that window is recorded in Result.ProbeFailures
+// rather than aborting the rotation, so a single blip doesn't mask
+// whether later probes recovered or the drain kept failing.
+//
+// The probe loop stops as soon as the drain and delete steps both
+// return, whether or not they succeeded, so a stuck drain doesn't leave
+// the drill running forever.
+func Run(ctx context.Context, applier Applier, drainer Drainer, prober Prober, stack, oldNodePool string, probeInterval time.Duration) Result {
+	var result Result
+
+	if err := applier.Apply(stack); err != nil {
+		result.CreateApplyErr = fmt.Errorf("poolrotation: apply new node pool for %s: %w", stack, err)
+		return result
+	}
+
+	probeCtx, stopProbing := context.WithCancel(ctx)
+	defer stopProbing()
+
+	done := make(chan []error)
+	go func() {
+		var failures []error
+		ticker := time.NewTicker(probeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-probeCtx.Done():
+				done <- failures
+				return
+			case <-ticker.C:
+				// Attempt runs against ctx, not probeCtx: probeCtx is only
+				// used to stop scheduling new probes once the drain/delete
+				// steps finish, and must never cancel a probe already in
+				// flight, or the drill's own teardown would masquerade as
+				// a probe failure.
+				if err := prober.Attempt(ctx); err != nil {
+					failures = append(failures, err)
+				}
+			}
+		}
+	}()
+
+	if err := drainer.CordonAndDrain(ctx, oldNodePool); err != nil {
+		result.DrainErr = fmt.Errorf("poolrotation: cordon/drain node pool %s: %w", oldNodePool, err)
+	} else if err := drainer.Delete(ctx, oldNodePool); err != nil {
+		result.DeleteErr = fmt.Errorf("poolrotation: delete node pool %s: %w", oldNodePool, err)
+	}
+
+	stopProbing()
+	result.ProbeFailures = <-done
+
+	return result
+}
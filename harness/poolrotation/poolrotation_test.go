@@ -0,0 +1,122 @@
+package poolrotation
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeApplier struct{ err error }
+
+func (f *fakeApplier) Apply(stack string) error { return f.err }
+
+type fakeDrainer struct {
+	drainErr  error
+	deleteErr error
+	drainDur  time.Duration
+}
+
+func (f *fakeDrainer) CordonAndDrain(ctx context.Context, nodePool string) error {
+	time.Sleep(f.drainDur)
+	return f.drainErr
+}
+
+func (f *fakeDrainer) Delete(ctx context.Context, nodePool string) error { return f.deleteErr }
+
+type fakeProber struct {
+	mu    sync.Mutex
+	errs  []error
+	calls int
+}
+
+func (f *fakeProber) Attempt(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var err error
+	if f.calls < len(f.errs) {
+		err = f.errs[f.calls]
+	}
+	f.calls++
+	return err
+}
+
+func TestRunPassesWhenRotationAndProbesSucceed(t *testing.T) {
+	applier := &fakeApplier{}
+	drainer := &fakeDrainer{drainDur: 20 * time.Millisecond}
+	prober := &fakeProber{}
+
+	result := Run(context.Background(), applier, drainer, prober, "aks", "pool-blue", time.Millisecond)
+
+	assert.True(t, result.Passed())
+	assert.Empty(t, result.ProbeFailures)
+}
+
+func TestRunFailsWhenApplyFails(t *testing.T) {
+	applier := &fakeApplier{err: errors.New("apply failed")}
+	drainer := &fakeDrainer{}
+	prober := &fakeProber{}
+
+	result := Run(context.Background(), applier, drainer, prober, "aks", "pool-blue", time.Millisecond)
+
+	assert.False(t, result.Passed())
+	assert.Error(t, result.CreateApplyErr)
+}
+
+func TestRunFailsWhenDrainFails(t *testing.T) {
+	applier := &fakeApplier{}
+	drainer := &fakeDrainer{drainErr: errors.New("drain failed")}
+	prober := &fakeProber{}
+
+	result := Run(context.Background(), applier, drainer, prober, "aks", "pool-blue", time.Millisecond)
+
+	assert.False(t, result.Passed())
+	assert.Error(t, result.DrainErr)
+}
+
+func TestRunRecordsDowntimeObservedDuringRotation(t *testing.T) {
+	applier := &fakeApplier{}
+	drainer := &fakeDrainer{drainDur: 30 * time.Millisecond}
+	prober := &fakeProber{errs: []error{nil, errors.New("connection reset"), nil}}
+
+	result := Run(context.Background(), applier, drainer, prober, "aks", "pool-blue", 5*time.Millisecond)
+
+	assert.False(t, result.Passed())
+	assert.NotEmpty(t, result.ProbeFailures)
+}
+
+type slowProber struct {
+	sleep time.Duration
+}
+
+// Attempt reports ctx's cancellation error (nil if ctx was never
+// canceled), so tests can tell whether Run tore down the probe loop out
+// from under an in-flight attempt.
+func (p *slowProber) Attempt(ctx context.Context) error {
+	time.Sleep(p.sleep)
+	return ctx.Err()
+}
+
+func TestRunDoesNotCancelInFlightProbeWhenRotationFinishesFirst(t *testing.T) {
+	applier := &fakeApplier{}
+	drainer := &fakeDrainer{}
+	prober := &slowProber{sleep: 30 * time.Millisecond}
+
+	result := Run(context.Background(), applier, drainer, prober, "aks", "pool-blue", time.Millisecond)
+
+	assert.True(t, result.Passed())
+	assert.Empty(t, result.ProbeFailures)
+}
+
+func TestRunDoesNotDeleteOldPoolWhenDrainFails(t *testing.T) {
+	applier := &fakeApplier{}
+	drainer := &fakeDrainer{drainErr: errors.New("drain failed")}
+	prober := &fakeProber{}
+
+	result := Run(context.Background(), applier, drainer, prober, "aks", "pool-blue", time.Millisecond)
+
+	assert.NoError(t, result.DeleteErr)
+}
@@ -0,0 +1,95 @@
+// Package poolseparation asserts the cluster's node pool topology this
+// module depends on: a dedicated system pool carrying the
+// CriticalAddonsOnly taint, at least one user pool for application
+// workloads, and no test workload actually scheduled onto the system pool.
+// The topology has regressed before (a system pool losing its taint after
+// an in-place edit), so it's checked in code rather than only by review.
+package poolseparation
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v5"
+)
+
+// CriticalAddonsOnlyTaint is the taint every system pool must carry so the
+// scheduler keeps application workloads off it.
+const CriticalAddonsOnlyTaint = "CriticalAddonsOnly=true:NoSchedule"
+
+// Violation is a single node pool topology mismatch.
+type Violation struct {
+	Message string
+}
+
+// VerifyTopology checks pools for exactly the shape this module expects:
+// every system-mode pool tainted with CriticalAddonsOnlyTaint, and at least
+// one user-mode pool to actually host workloads.
+func VerifyTopology(pools []*armcontainerservice.ManagedClusterAgentPoolProfile) []Violation {
+	var violations []Violation
+	var systemPools, userPools int
+
+	for _, pool := range pools {
+		if pool.Mode == nil {
+			violations = append(violations, Violation{Message: fmt.Sprintf("agent pool %s has no mode set", name(pool))})
+			continue
+		}
+		switch *pool.Mode {
+		case armcontainerservice.AgentPoolModeSystem:
+			systemPools++
+			if !hasTaint(pool.NodeTaints, CriticalAddonsOnlyTaint) {
+				violations = append(violations, Violation{Message: fmt.Sprintf("system pool %s is missing the %s taint", name(pool), CriticalAddonsOnlyTaint)})
+			}
+		case armcontainerservice.AgentPoolModeUser:
+			userPools++
+		}
+	}
+
+	if systemPools == 0 {
+		violations = append(violations, Violation{Message: "cluster has no dedicated system node pool"})
+	}
+	if userPools == 0 {
+		violations = append(violations, Violation{Message: "cluster has no user node pool for application workloads"})
+	}
+	return violations
+}
+
+// PodPlacement is a single scheduled pod's node pool assignment, as read
+// from `kubectl get pods -o wide` or the Kubernetes API.
+type PodPlacement struct {
+	PodName      string
+	NodePoolName string
+}
+
+// VerifyWorkloadPlacement checks that no test workload landed on any of
+// systemPoolNames, catching a permissive scheduler config or a missing
+// nodeSelector/toleration on the workload itself.
+func VerifyWorkloadPlacement(placements []PodPlacement, systemPoolNames []string) []Violation {
+	systemPools := make(map[string]bool, len(systemPoolNames))
+	for _, name := range systemPoolNames {
+		systemPools[name] = true
+	}
+
+	var violations []Violation
+	for _, p := range placements {
+		if systemPools[p.NodePoolName] {
+			violations = append(violations, Violation{Message: fmt.Sprintf("pod %s is scheduled on system pool %s", p.PodName, p.NodePoolName)})
+		}
+	}
+	return violations
+}
+
+func hasTaint(taints []*string, want string) bool {
+	for _, t := range taints {
+		if t != nil && *t == want {
+			return true
+		}
+	}
+	return false
+}
+
+func name(pool *armcontainerservice.ManagedClusterAgentPoolProfile) string {
+	if pool.Name == nil {
+		return "<unnamed>"
+	}
+	return *pool.Name
+}
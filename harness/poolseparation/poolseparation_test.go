@@ -0,0 +1,60 @@
+package poolseparation
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func strPtr(s string) *string { return &s }
+
+func systemPool(name string, tainted bool) *armcontainerservice.ManagedClusterAgentPoolProfile {
+	mode := armcontainerservice.AgentPoolModeSystem
+	var taints []*string
+	if tainted {
+		taints = []*string{strPtr(CriticalAddonsOnlyTaint)}
+	}
+	return &armcontainerservice.ManagedClusterAgentPoolProfile{Name: strPtr(name), Mode: &mode, NodeTaints: taints}
+}
+
+func userPool(name string) *armcontainerservice.ManagedClusterAgentPoolProfile {
+	mode := armcontainerservice.AgentPoolModeUser
+	return &armcontainerservice.ManagedClusterAgentPoolProfile{Name: strPtr(name), Mode: &mode}
+}
+
+func TestVerifyTopologyPassesForCompliantCluster(t *testing.T) {
+	pools := []*armcontainerservice.ManagedClusterAgentPoolProfile{
+		systemPool("system", true),
+		userPool("user"),
+	}
+	assert.Empty(t, VerifyTopology(pools))
+}
+
+func TestVerifyTopologyFlagsMissingTaint(t *testing.T) {
+	pools := []*armcontainerservice.ManagedClusterAgentPoolProfile{
+		systemPool("system", false),
+		userPool("user"),
+	}
+	violations := VerifyTopology(pools)
+	assert.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "missing the")
+}
+
+func TestVerifyTopologyFlagsMissingSystemOrUserPool(t *testing.T) {
+	violations := VerifyTopology([]*armcontainerservice.ManagedClusterAgentPoolProfile{userPool("user")})
+	assert.Len(t, violations, 1)
+
+	violations = VerifyTopology([]*armcontainerservice.ManagedClusterAgentPoolProfile{systemPool("system", true)})
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyWorkloadPlacementFlagsPodsOnSystemPool(t *testing.T) {
+	placements := []PodPlacement{
+		{PodName: "app-1", NodePoolName: "user"},
+		{PodName: "leaked-1", NodePoolName: "system"},
+	}
+	violations := VerifyWorkloadPlacement(placements, []string{"system"})
+	assert.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "leaked-1")
+}
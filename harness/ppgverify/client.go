@@ -0,0 +1,26 @@
+package ppgverify
+
+import "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+
+// FromVirtualMachine reads a VM's actual proximity placement group and
+// dedicated host assignment off its armcompute representation.
+func FromVirtualMachine(vm armcompute.VirtualMachine) Placement {
+	p := Placement{VMName: derefStr(vm.Name)}
+	if vm.Properties == nil {
+		return p
+	}
+	if vm.Properties.ProximityPlacementGroup != nil {
+		p.ProximityPlacementGroupID = derefStr(vm.Properties.ProximityPlacementGroup.ID)
+	}
+	if vm.Properties.Host != nil {
+		p.DedicatedHostID = derefStr(vm.Properties.Host.ID)
+	}
+	return p
+}
+
+func derefStr(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,54 @@
+// Package ppgverify verifies that node pool VMs configured for a
+// proximity placement group or dedicated host actually landed there.
+// Terraform accepting a proximity_placement_group_id or a host_group_id
+// only proves the request was sent; it doesn't prove Azure honored it for
+// every instance, so this package reads back each VM's actual assignment
+// from the compute SDK and flags any that drifted.
+package ppgverify
+
+import "fmt"
+
+// Placement is one node pool VM's actual co-location assignment, read back
+// from armcompute.
+type Placement struct {
+	VMName                    string
+	ProximityPlacementGroupID string
+	DedicatedHostID           string
+}
+
+// Violation is a single VM whose placement doesn't match what the
+// environment expects.
+type Violation struct {
+	Message string
+}
+
+// Report is the outcome of checking every VM's placement against the
+// environment's expectations.
+type Report struct {
+	Placements []Placement
+	Violations []Violation
+}
+
+// Passed reports whether every VM landed in the expected proximity
+// placement group / dedicated host.
+func (r Report) Passed() bool {
+	return len(r.Violations) == 0
+}
+
+// Verify checks every placement against expectedPPGID and expectedHostID.
+// An empty expected value means that dimension isn't in use for this
+// environment and is skipped.
+func Verify(placements []Placement, expectedPPGID, expectedHostID string) Report {
+	report := Report{Placements: placements}
+	for _, p := range placements {
+		if expectedPPGID != "" && p.ProximityPlacementGroupID != expectedPPGID {
+			report.Violations = append(report.Violations, Violation{Message: fmt.Sprintf(
+				"vm %s has proximity placement group %q, want %q", p.VMName, p.ProximityPlacementGroupID, expectedPPGID)})
+		}
+		if expectedHostID != "" && p.DedicatedHostID != expectedHostID {
+			report.Violations = append(report.Violations, Violation{Message: fmt.Sprintf(
+				"vm %s has dedicated host %q, want %q", p.VMName, p.DedicatedHostID, expectedHostID)})
+		}
+	}
+	return report
+}
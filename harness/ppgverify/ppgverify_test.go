@@ -0,0 +1,63 @@
+package ppgverify
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyPassesWhenAllVMsInExpectedPPG(t *testing.T) {
+	placements := []Placement{
+		{VMName: "aks-node-0", ProximityPlacementGroupID: "/ppg/latency"},
+		{VMName: "aks-node-1", ProximityPlacementGroupID: "/ppg/latency"},
+	}
+	report := Verify(placements, "/ppg/latency", "")
+	assert.True(t, report.Passed())
+}
+
+func TestVerifyFlagsVMOutsideExpectedPPG(t *testing.T) {
+	placements := []Placement{
+		{VMName: "aks-node-0", ProximityPlacementGroupID: "/ppg/latency"},
+		{VMName: "aks-node-1", ProximityPlacementGroupID: ""},
+	}
+	report := Verify(placements, "/ppg/latency", "")
+	require.Len(t, report.Violations, 1)
+	assert.Contains(t, report.Violations[0].Message, "aks-node-1")
+}
+
+func TestVerifyFlagsVMOnWrongDedicatedHost(t *testing.T) {
+	placements := []Placement{{VMName: "aks-node-0", DedicatedHostID: "/hosts/other"}}
+	report := Verify(placements, "", "/hosts/expected")
+	require.Len(t, report.Violations, 1)
+	assert.Contains(t, report.Violations[0].Message, "dedicated host")
+}
+
+func TestVerifySkipsDimensionsWithNoExpectation(t *testing.T) {
+	placements := []Placement{{VMName: "aks-node-0"}}
+	report := Verify(placements, "", "")
+	assert.True(t, report.Passed())
+}
+
+func TestFromVirtualMachineExtractsPlacementAndHost(t *testing.T) {
+	name := "aks-node-0"
+	ppgID := "/ppg/latency"
+	hostID := "/hosts/expected"
+	vm := armcompute.VirtualMachine{
+		Name: &name,
+		Properties: &armcompute.VirtualMachineProperties{
+			ProximityPlacementGroup: &armcompute.SubResource{ID: &ppgID},
+			Host:                    &armcompute.SubResource{ID: &hostID},
+		},
+	}
+
+	placement := FromVirtualMachine(vm)
+	assert.Equal(t, Placement{VMName: name, ProximityPlacementGroupID: ppgID, DedicatedHostID: hostID}, placement)
+}
+
+func TestFromVirtualMachineHandlesNilProperties(t *testing.T) {
+	name := "aks-node-0"
+	placement := FromVirtualMachine(armcompute.VirtualMachine{Name: &name})
+	assert.Equal(t, Placement{VMName: name}, placement)
+}
@@ -0,0 +1,112 @@
+// Package probespec loads a declarative probes.yaml describing
+// connectivity checks (source, target, protocol, expected result) and
+// executes them through a pluggable Backend, so network engineers can
+// add or change a check by editing YAML instead of writing Go.
+//
+// A Backend hides how a probe actually reaches its target: run-command
+// against a jumpbox VM, an in-cluster Kubernetes Job, or a direct dial
+// from the test process (see harness/netreach for choosing between
+// direct and jumpbox access to the API server itself). probespec only
+// owns parsing the spec and assembling the resulting matrix.
+package probespec
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Protocol is the connectivity check a probe performs.
+type Protocol string
+
+const (
+	ProtocolHTTP Protocol = "http"
+	ProtocolTCP  Protocol = "tcp"
+	ProtocolDNS  Protocol = "dns"
+)
+
+// Expect is the outcome a probe should observe.
+type Expect string
+
+const (
+	ExpectSucceed Expect = "succeed"
+	ExpectFail    Expect = "fail"
+)
+
+// Probe is a single declared connectivity check.
+type Probe struct {
+	Name     string   `yaml:"name"`
+	Source   string   `yaml:"source"` // where the probe runs from, e.g. "jumpbox", "in-cluster"
+	Target   string   `yaml:"target"` // host:port, URL, or FQDN depending on Protocol
+	Protocol Protocol `yaml:"protocol"`
+	Expect   Expect   `yaml:"expect"`
+}
+
+// Spec is a parsed probes.yaml for one environment.
+type Spec struct {
+	Env    string  `yaml:"-"`
+	Probes []Probe `yaml:"probes"`
+}
+
+// Parse parses the contents of a probes.yaml for env.
+func Parse(env string, data []byte) (Spec, error) {
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return Spec{}, fmt.Errorf("probespec: parse probes.yaml for %s: %w", env, err)
+	}
+	spec.Env = env
+	for i, p := range spec.Probes {
+		if p.Name == "" {
+			return Spec{}, fmt.Errorf("probespec: probe %d in %s has no name", i, env)
+		}
+	}
+	return spec, nil
+}
+
+// Backend executes a single probe and reports whether the connectivity
+// check itself succeeded (not whether that outcome matched Expect —
+// Run compares the two).
+type Backend interface {
+	Execute(ctx context.Context, probe Probe) error
+}
+
+// Outcome is one probe's result against its declared Expect.
+type Outcome struct {
+	Probe  string
+	Pass   bool
+	Detail string
+}
+
+// Run executes every probe in spec against backend and returns the
+// resulting matrix, one Outcome per probe, in declaration order.
+func Run(ctx context.Context, backend Backend, spec Spec) []Outcome {
+	outcomes := make([]Outcome, 0, len(spec.Probes))
+	for _, p := range spec.Probes {
+		err := backend.Execute(ctx, p)
+		succeeded := err == nil
+		wantSucceed := p.Expect != ExpectFail
+
+		outcome := Outcome{Probe: p.Name, Pass: succeeded == wantSucceed}
+		switch {
+		case !succeeded && wantSucceed:
+			outcome.Detail = fmt.Sprintf("expected to succeed but failed: %v", err)
+		case succeeded && !wantSucceed:
+			outcome.Detail = "expected to fail but succeeded"
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes
+}
+
+// Failures filters outcomes down to the probes that didn't match their
+// declared Expect.
+func Failures(outcomes []Outcome) []Outcome {
+	var failures []Outcome
+	for _, o := range outcomes {
+		if !o.Pass {
+			failures = append(failures, o)
+		}
+	}
+	return failures
+}
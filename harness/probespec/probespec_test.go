@@ -0,0 +1,82 @@
+package probespec
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixture = `
+probes:
+  - name: hub-to-aks-api
+    source: jumpbox
+    target: aks-priv.example.com:443
+    protocol: tcp
+    expect: succeed
+  - name: internet-to-aks-api
+    source: internet
+    target: aks-priv.example.com:443
+    protocol: tcp
+    expect: fail
+`
+
+func TestParseReadsProbes(t *testing.T) {
+	spec, err := Parse("dev", []byte(fixture))
+	require.NoError(t, err)
+	require.Len(t, spec.Probes, 2)
+	assert.Equal(t, "dev", spec.Env)
+	assert.Equal(t, ProtocolTCP, spec.Probes[0].Protocol)
+	assert.Equal(t, ExpectFail, spec.Probes[1].Expect)
+}
+
+func TestParseRejectsUnnamedProbe(t *testing.T) {
+	_, err := Parse("dev", []byte("probes:\n  - target: x\n"))
+	assert.ErrorContains(t, err, "no name")
+}
+
+// fakeBackend defaults to matching each probe's Expect (succeed probes
+// succeed, fail probes fail) unless overridden in errsByProbe.
+type fakeBackend struct {
+	errsByProbe map[string]error
+}
+
+func (f fakeBackend) Execute(ctx context.Context, probe Probe) error {
+	if err, ok := f.errsByProbe[probe.Name]; ok {
+		return err
+	}
+	if probe.Expect == ExpectFail {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestRunPassesWhenOutcomeMatchesExpect(t *testing.T) {
+	spec, err := Parse("dev", []byte(fixture))
+	require.NoError(t, err)
+
+	outcomes := Run(context.Background(), fakeBackend{}, spec)
+	assert.Empty(t, Failures(outcomes))
+}
+
+func TestRunFlagsUnexpectedFailure(t *testing.T) {
+	spec, err := Parse("dev", []byte(fixture))
+	require.NoError(t, err)
+	backend := fakeBackend{errsByProbe: map[string]error{"hub-to-aks-api": errors.New("dial timeout")}}
+
+	failures := Failures(Run(context.Background(), backend, spec))
+	require.Len(t, failures, 1)
+	assert.Equal(t, "hub-to-aks-api", failures[0].Probe)
+}
+
+func TestRunFlagsUnexpectedSuccess(t *testing.T) {
+	spec, err := Parse("dev", []byte(fixture))
+	require.NoError(t, err)
+	backend := fakeBackend{errsByProbe: map[string]error{"internet-to-aks-api": nil}}
+
+	failures := Failures(Run(context.Background(), backend, spec))
+	require.Len(t, failures, 1)
+	assert.Equal(t, "internet-to-aks-api", failures[0].Probe)
+}
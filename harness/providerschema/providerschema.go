@@ -0,0 +1,128 @@
+// Package providerschema validates planned resource attributes against the
+// azurerm provider's own schema (from `terraform providers schema -json`)
+// plus stricter rules this repo layers on top (e.g. SKU allow-lists), so
+// validation rules live next to Go tests instead of scattered across
+// tfvars and variable "validation" blocks.
+package providerschema
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/planparser"
+)
+
+// rawSchema mirrors the subset of `terraform providers schema -json`
+// output this package consumes.
+type rawSchema struct {
+	ProviderSchemas map[string]struct {
+		ResourceSchemas map[string]struct {
+			Block struct {
+				Attributes map[string]struct {
+					Required bool `json:"required"`
+					Computed bool `json:"computed"`
+				} `json:"attributes"`
+			} `json:"block"`
+		} `json:"resource_schemas"`
+	} `json:"provider_schemas"`
+}
+
+// Schema is the parsed provider schema, indexed by resource type.
+type Schema struct {
+	resources map[string]map[string]bool // resource type -> attribute name -> required
+}
+
+// Parse parses `terraform providers schema -json` output for the given
+// provider source address (e.g. "registry.terraform.io/hashicorp/azurerm").
+func Parse(data []byte, providerSource string) (Schema, error) {
+	var raw rawSchema
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Schema{}, fmt.Errorf("providerschema: parse schema JSON: %w", err)
+	}
+
+	provider, ok := raw.ProviderSchemas[providerSource]
+	if !ok {
+		return Schema{}, fmt.Errorf("providerschema: no schema found for provider %q", providerSource)
+	}
+
+	schema := Schema{resources: make(map[string]map[string]bool)}
+	for resourceType, rs := range provider.ResourceSchemas {
+		attrs := make(map[string]bool, len(rs.Block.Attributes))
+		for name, attr := range rs.Block.Attributes {
+			attrs[name] = attr.Required
+		}
+		schema.resources[resourceType] = attrs
+	}
+	return schema, nil
+}
+
+// EnumRule restricts an attribute to a fixed allow-list of values, stricter
+// than anything the provider schema itself expresses.
+type EnumRule struct {
+	ResourceType string
+	Attribute    string
+	Allowed      []string
+}
+
+// Violation is a single schema or rule violation found in a plan.
+type Violation struct {
+	Address string
+	Message string
+}
+
+// ValidateUnknownAttributes flags any attribute set on a resource in plan
+// that the provider schema doesn't recognize for that resource type,
+// catching typos and attributes removed by a provider upgrade.
+func (s Schema) ValidateUnknownAttributes(plan planparser.Plan) []Violation {
+	var violations []Violation
+	for _, rc := range plan.ResourceChanges {
+		attrs, ok := s.resources[rc.Type]
+		if !ok {
+			continue
+		}
+		for name := range rc.After {
+			if _, known := attrs[name]; !known {
+				violations = append(violations, Violation{
+					Address: rc.Address,
+					Message: fmt.Sprintf("attribute %q is not defined in the %s provider schema", name, rc.Type),
+				})
+			}
+		}
+	}
+	return violations
+}
+
+// ValidateEnums checks every EnumRule against plan, flagging any resource
+// whose attribute value falls outside the allow-list.
+func ValidateEnums(plan planparser.Plan, rules []EnumRule) []Violation {
+	var violations []Violation
+	for _, rc := range plan.ResourceChanges {
+		for _, rule := range rules {
+			if rule.ResourceType != rc.Type {
+				continue
+			}
+			value, ok := rc.After[rule.Attribute]
+			if !ok {
+				continue
+			}
+			str, ok := value.(string)
+			if !ok || contains(rule.Allowed, str) {
+				continue
+			}
+			violations = append(violations, Violation{
+				Address: rc.Address,
+				Message: fmt.Sprintf("%s=%q is not in the allow-list %v", rule.Attribute, str, rule.Allowed),
+			})
+		}
+	}
+	return violations
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
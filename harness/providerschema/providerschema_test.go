@@ -0,0 +1,59 @@
+package providerschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/planparser"
+)
+
+const sampleSchema = `{
+  "provider_schemas": {
+    "registry.terraform.io/hashicorp/azurerm": {
+      "resource_schemas": {
+        "azurerm_kubernetes_cluster": {
+          "block": {
+            "attributes": {
+              "name": {"required": true},
+              "sku_tier": {"required": false}
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+func TestValidateUnknownAttributesFlagsTypos(t *testing.T) {
+	schema, err := Parse([]byte(sampleSchema), "registry.terraform.io/hashicorp/azurerm")
+	require.NoError(t, err)
+
+	plan, err := planparser.Parse([]byte(`{
+      "resource_changes": [
+        {"address": "azurerm_kubernetes_cluster.this", "type": "azurerm_kubernetes_cluster", "name": "this",
+         "change": {"actions": ["create"], "after": {"name": "aks", "sku_teir": "Standard"}}}
+      ]
+    }`))
+	require.NoError(t, err)
+
+	violations := schema.ValidateUnknownAttributes(plan)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "sku_teir")
+}
+
+func TestValidateEnumsFlagsDisallowedSKU(t *testing.T) {
+	plan, err := planparser.Parse([]byte(`{
+      "resource_changes": [
+        {"address": "azurerm_kubernetes_cluster.this", "type": "azurerm_kubernetes_cluster", "name": "this",
+         "change": {"actions": ["create"], "after": {"sku_tier": "Premium"}}}
+      ]
+    }`))
+	require.NoError(t, err)
+
+	violations := ValidateEnums(plan, []EnumRule{{
+		ResourceType: "azurerm_kubernetes_cluster", Attribute: "sku_tier", Allowed: []string{"Free", "Standard"},
+	}})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "Premium")
+}
@@ -0,0 +1,96 @@
+// Package rbacconverge wraps identity-dependent probes (an AcrPull image
+// pull, a Key Vault CSI secret mount) in a poll loop that tolerates RBAC
+// role assignment propagation delay, which can take minutes after a role
+// assignment is created. Without this, those probes are flaky right after
+// deploy for reasons that have nothing to do with the configuration being
+// wrong. Reporting distinguishes an immediate pass from one that only
+// succeeded after retries, so recurring propagation delay stays visible
+// even once it stops failing outright.
+package rbacconverge
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Probe is a single identity-dependent operation to retry, e.g. pulling
+// an image via AcrPull or mounting a Key Vault secret via workload
+// identity.
+type Probe interface {
+	Attempt(ctx context.Context) error
+}
+
+// Options configures Await. PollInterval, Now and Sleep default to
+// production values when left zero; tests override Now/Sleep to run the
+// polling loop without real delays.
+type Options struct {
+	Timeout      time.Duration
+	PollInterval time.Duration
+	Now          func() time.Time
+	Sleep        func(time.Duration)
+}
+
+// Result reports how a probe converged.
+type Result struct {
+	Succeeded       bool
+	Attempts        int
+	Elapsed         time.Duration
+	EventualSuccess bool // succeeded, but only after at least one retry
+	LastErr         error
+}
+
+// Await retries probe until it succeeds or opts.Timeout elapses,
+// returning a Result that flags whether success came immediately or only
+// after RBAC propagation caught up.
+func Await(ctx context.Context, probe Probe, opts Options) Result {
+	now := opts.Now
+	if now == nil {
+		now = time.Now
+	}
+	sleep := opts.Sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	pollInterval := opts.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 15 * time.Second
+	}
+
+	start := now()
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		lastErr = probe.Attempt(ctx)
+		if lastErr == nil {
+			return Result{
+				Succeeded:       true,
+				Attempts:        attempt,
+				Elapsed:         now().Sub(start),
+				EventualSuccess: attempt > 1,
+			}
+		}
+		if now().Sub(start) >= opts.Timeout {
+			return Result{Succeeded: false, Attempts: attempt, Elapsed: now().Sub(start), LastErr: lastErr}
+		}
+
+		select {
+		case <-ctx.Done():
+			return Result{Succeeded: false, Attempts: attempt, Elapsed: now().Sub(start), LastErr: ctx.Err()}
+		default:
+		}
+		sleep(pollInterval)
+	}
+}
+
+// Report renders a one-line summary suitable for test output or a canary
+// report, calling out eventual success distinctly from an immediate pass.
+func Report(name string, r Result) string {
+	switch {
+	case !r.Succeeded:
+		return fmt.Sprintf("%s: FAILED after %d attempt(s) over %s: %v", name, r.Attempts, r.Elapsed.Round(time.Second), r.LastErr)
+	case r.EventualSuccess:
+		return fmt.Sprintf("%s: passed after %d attempt(s) over %s (RBAC propagation delay observed)", name, r.Attempts, r.Elapsed.Round(time.Second))
+	default:
+		return fmt.Sprintf("%s: passed on first attempt", name)
+	}
+}
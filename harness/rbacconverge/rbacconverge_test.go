@@ -0,0 +1,83 @@
+package rbacconverge
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeProbe struct {
+	failCount int
+	calls     int
+}
+
+func (f *fakeProbe) Attempt(ctx context.Context) error {
+	f.calls++
+	if f.calls <= f.failCount {
+		return errors.New("AuthorizationFailed: role assignment not yet propagated")
+	}
+	return nil
+}
+
+func fakeClock(start time.Time) (now func() time.Time, sleep func(time.Duration)) {
+	current := start
+	now = func() time.Time { return current }
+	sleep = func(d time.Duration) { current = current.Add(d) }
+	return
+}
+
+func TestAwaitSucceedsImmediately(t *testing.T) {
+	probe := &fakeProbe{}
+	now, sleep := fakeClock(time.Unix(0, 0))
+	result := Await(context.Background(), probe, Options{Timeout: time.Minute, PollInterval: time.Second, Now: now, Sleep: sleep})
+
+	assert.True(t, result.Succeeded)
+	assert.False(t, result.EventualSuccess)
+	assert.Equal(t, 1, result.Attempts)
+}
+
+func TestAwaitSucceedsAfterRetriesAndFlagsEventualSuccess(t *testing.T) {
+	probe := &fakeProbe{failCount: 3}
+	now, sleep := fakeClock(time.Unix(0, 0))
+	result := Await(context.Background(), probe, Options{Timeout: time.Minute, PollInterval: 10 * time.Second, Now: now, Sleep: sleep})
+
+	assert.True(t, result.Succeeded)
+	assert.True(t, result.EventualSuccess)
+	assert.Equal(t, 4, result.Attempts)
+}
+
+func TestAwaitFailsAfterTimeout(t *testing.T) {
+	probe := &fakeProbe{failCount: 100}
+	now, sleep := fakeClock(time.Unix(0, 0))
+	result := Await(context.Background(), probe, Options{Timeout: 30 * time.Second, PollInterval: 10 * time.Second, Now: now, Sleep: sleep})
+
+	assert.False(t, result.Succeeded)
+	assert.Error(t, result.LastErr)
+}
+
+func TestAwaitReturnsPromptlyWhenContextIsCanceled(t *testing.T) {
+	probe := &fakeProbe{failCount: 100}
+	now, sleep := fakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := Await(ctx, probe, Options{Timeout: time.Hour, PollInterval: 10 * time.Second, Now: now, Sleep: sleep})
+
+	assert.False(t, result.Succeeded)
+	assert.ErrorIs(t, result.LastErr, context.Canceled)
+	assert.Equal(t, 1, result.Attempts)
+}
+
+func TestReportDistinguishesImmediateFromEventualSuccess(t *testing.T) {
+	immediate := Report("acrpull-probe", Result{Succeeded: true, Attempts: 1})
+	assert.Contains(t, immediate, "first attempt")
+
+	eventual := Report("acrpull-probe", Result{Succeeded: true, Attempts: 3, EventualSuccess: true, Elapsed: 30 * time.Second})
+	assert.Contains(t, eventual, "propagation delay")
+
+	failed := Report("acrpull-probe", Result{Succeeded: false, Attempts: 5, LastErr: errors.New("denied")})
+	assert.Contains(t, failed, "FAILED")
+}
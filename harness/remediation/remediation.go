@@ -0,0 +1,59 @@
+// Package remediation appends concrete, actionable next steps to failure
+// output for the handful of failure signatures that recur often enough
+// to have a known fix: soft-deleted Key Vault name collisions, a
+// resource provider that was never registered on the subscription, RBAC
+// role assignments that haven't propagated yet, and quota exhaustion.
+// Suggest and Annotate work on raw CLI output and can be used alongside
+// errtaxonomy.Classify; no runner or reporter in this repository calls
+// them, so failure output is not annotated automatically.
+package remediation
+
+import "regexp"
+
+// signature pairs a regexp matched against raw CLI output with the
+// remediation text to surface when it matches. Order matters: the first
+// match wins, so more specific patterns are listed before general ones.
+type signature struct {
+	pattern *regexp.Regexp
+	advice  string
+}
+
+var signatures = []signature{
+	{
+		regexp.MustCompile(`(?i)soft.?deleted|VaultAlreadyExists`),
+		"a Key Vault with this name was soft-deleted rather than purged; either `az keyvault purge --name <vault>` or recover it with `terraform import`/a purge-protection-aware retry before re-applying",
+	},
+	{
+		regexp.MustCompile(`(?i)MissingSubscriptionRegistration`),
+		"the resource provider used by this resource isn't registered on the subscription; run `az provider register --namespace <Microsoft.Xxx>` and wait for RegistrationState to reach Registered before retrying",
+	},
+	{
+		regexp.MustCompile(`(?i)AuthorizationFailed.*does not have authorization|does not have authorization to perform action`),
+		"the role assignment exists but hasn't propagated through AAD yet; wait a few minutes and retry rather than changing the assignment",
+	},
+	{
+		regexp.MustCompile(`(?i)QuotaExceeded|exceeded.*quota|too many.*(cores|resources)`),
+		"the target region/SKU is out of quota; request a quota increase for the subscription or retry against a region with headroom",
+	},
+}
+
+// Suggest returns the remediation advice for the first signature matching
+// output, and whether any signature matched at all.
+func Suggest(output string) (string, bool) {
+	for _, sig := range signatures {
+		if sig.pattern.MatchString(output) {
+			return sig.advice, true
+		}
+	}
+	return "", false
+}
+
+// Annotate appends remediation advice to message when output matches a
+// known signature, leaving message unchanged otherwise.
+func Annotate(message, output string) string {
+	advice, ok := Suggest(output)
+	if !ok {
+		return message
+	}
+	return message + "\nremediation: " + advice
+}
@@ -0,0 +1,47 @@
+package remediation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestMatchesSoftDeletedKeyVault(t *testing.T) {
+	advice, ok := Suggest(`Error: creating Key Vault: (soft-deleted vault exists) VaultAlreadyExists`)
+	assert.True(t, ok)
+	assert.Contains(t, advice, "purge")
+}
+
+func TestSuggestMatchesMissingProviderRegistration(t *testing.T) {
+	advice, ok := Suggest(`Error: MissingSubscriptionRegistration: The subscription is not registered for Microsoft.ContainerService`)
+	assert.True(t, ok)
+	assert.Contains(t, advice, "az provider register")
+}
+
+func TestSuggestMatchesRBACPropagationDelay(t *testing.T) {
+	advice, ok := Suggest(`Error: AuthorizationFailed: The client does not have authorization to perform action`)
+	assert.True(t, ok)
+	assert.Contains(t, advice, "propagated")
+}
+
+func TestSuggestMatchesQuotaExceeded(t *testing.T) {
+	advice, ok := Suggest(`Error: creating Managed Cluster: QuotaExceeded`)
+	assert.True(t, ok)
+	assert.Contains(t, advice, "quota increase")
+}
+
+func TestSuggestReturnsFalseForUnknownOutput(t *testing.T) {
+	_, ok := Suggest(`Error: something completely unrelated happened`)
+	assert.False(t, ok)
+}
+
+func TestAnnotateAppendsAdviceWhenMatched(t *testing.T) {
+	result := Annotate("apply failed", `Error: QuotaExceeded`)
+	assert.Contains(t, result, "apply failed")
+	assert.Contains(t, result, "remediation:")
+}
+
+func TestAnnotateLeavesMessageUnchangedWhenNoMatch(t *testing.T) {
+	result := Annotate("apply failed", `Error: unrelated failure`)
+	assert.Equal(t, "apply failed", result)
+}
@@ -0,0 +1,54 @@
+// Package resourceid provides typed accessors over an ARM resource ID,
+// wrapping azcore/arm.ParseResourceID instead of hand-splitting the ID
+// string on "/". At the time this was written, none of these assertions
+// actually hand-split a resource ID (a grep of harness/ turns up no such
+// code), so there's nothing here to migrate — this package exists so any
+// future check that needs subscription, resource group, provider, name,
+// or parent out of a resource ID reaches for a parser instead of
+// reintroducing that anti-pattern.
+package resourceid
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+)
+
+// ID is a parsed ARM resource ID with the fields assertions in this repo
+// actually need.
+type ID struct {
+	SubscriptionID    string
+	ResourceGroupName string
+	Provider          string
+	ResourceType      string
+	Name              string
+	Parent            *ID
+}
+
+// Parse parses raw into an ID, returning an error if raw isn't a
+// well-formed ARM resource ID.
+func Parse(raw string) (ID, error) {
+	parsed, err := arm.ParseResourceID(raw)
+	if err != nil {
+		return ID{}, fmt.Errorf("resourceid: parse %q: %w", raw, err)
+	}
+	return fromARM(parsed), nil
+}
+
+func fromARM(parsed *arm.ResourceID) ID {
+	if parsed == nil {
+		return ID{}
+	}
+	id := ID{
+		SubscriptionID:    parsed.SubscriptionID,
+		ResourceGroupName: parsed.ResourceGroupName,
+		Provider:          parsed.ResourceType.Namespace,
+		ResourceType:      parsed.ResourceType.Type,
+		Name:              parsed.Name,
+	}
+	if parsed.Parent != nil {
+		parent := fromARM(parsed.Parent)
+		id.Parent = &parent
+	}
+	return id
+}
@@ -0,0 +1,41 @@
+package resourceid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExtractsSubscriptionResourceGroupProviderAndName(t *testing.T) {
+	id, err := Parse("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Cdn/profiles/p1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "00000000-0000-0000-0000-000000000000", id.SubscriptionID)
+	assert.Equal(t, "rg1", id.ResourceGroupName)
+	assert.Equal(t, "Microsoft.Cdn", id.Provider)
+	assert.Equal(t, "profiles", id.ResourceType)
+	assert.Equal(t, "p1", id.Name)
+}
+
+func TestParseExposesParentForNestedResource(t *testing.T) {
+	id, err := Parse("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/subnet1")
+	require.NoError(t, err)
+
+	require.NotNil(t, id.Parent)
+	assert.Equal(t, "vnet1", id.Parent.Name)
+	assert.Equal(t, "subnet1", id.Name)
+}
+
+func TestParseErrorsOnMalformedID(t *testing.T) {
+	_, err := Parse("not-a-resource-id")
+	assert.Error(t, err)
+}
+
+func TestParseHandlesResourceGroupOnlyID(t *testing.T) {
+	id, err := Parse("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "rg1", id.ResourceGroupName)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000000", id.SubscriptionID)
+}
@@ -0,0 +1,138 @@
+// Package riskscore classifies a Terraform plan's changes into risk tiers
+// (replace on stateful resources, deletion of DNS zones/Key Vaults, identity
+// changes) so plan-mode tests can fail on high-risk changes without an
+// explicit override, and so the score can be surfaced in a PR comment.
+package riskscore
+
+import (
+	"fmt"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/planparser"
+)
+
+// Tier is how dangerous a single flagged change is judged to be.
+type Tier int
+
+const (
+	TierLow Tier = iota
+	TierMedium
+	TierHigh
+)
+
+func (t Tier) String() string {
+	switch t {
+	case TierHigh:
+		return "high"
+	case TierMedium:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// statefulTypes are replaced-in-place resources whose replacement destroys
+// data or running workloads.
+var statefulTypes = map[string]bool{
+	"azurerm_kubernetes_cluster":           true,
+	"azurerm_kubernetes_cluster_node_pool": true,
+	"azurerm_postgresql_flexible_server":   true,
+	"azurerm_mssql_database":               true,
+	"azurerm_storage_account":              true,
+}
+
+// deleteSensitiveTypes are resources whose outright deletion is treated as
+// high risk regardless of replacement.
+var deleteSensitiveTypes = map[string]bool{
+	"azurerm_dns_zone":         true,
+	"azurerm_private_dns_zone": true,
+	"azurerm_key_vault":        true,
+}
+
+// Finding is a single flagged change.
+type Finding struct {
+	Address string
+	Type    string
+	Reason  string
+	Tier    Tier
+}
+
+// Report is the outcome of scoring a plan.
+type Report struct {
+	Findings []Finding
+}
+
+// HighestTier returns the most severe tier across all findings, or TierLow
+// if there are none.
+func (r Report) HighestTier() Tier {
+	highest := TierLow
+	for _, f := range r.Findings {
+		if f.Tier > highest {
+			highest = f.Tier
+		}
+	}
+	return highest
+}
+
+// Score analyzes plan and returns a Report of every risky change found.
+func Score(plan planparser.Plan) Report {
+	var report Report
+
+	for _, rc := range plan.ResourceChanges {
+		switch {
+		case rc.IsReplace() && statefulTypes[rc.Type]:
+			report.Findings = append(report.Findings, Finding{
+				Address: rc.Address, Type: rc.Type, Tier: TierHigh,
+				Reason: "replacement of a stateful resource destroys running workloads or data",
+			})
+		case rc.IsDelete() && deleteSensitiveTypes[rc.Type]:
+			report.Findings = append(report.Findings, Finding{
+				Address: rc.Address, Type: rc.Type, Tier: TierHigh,
+				Reason: "deletion of a DNS zone or Key Vault is rarely intentional and hard to reverse",
+			})
+		case identityChanged(rc):
+			report.Findings = append(report.Findings, Finding{
+				Address: rc.Address, Type: rc.Type, Tier: TierMedium,
+				Reason: "identity or role assignment change can affect workload access",
+			})
+		}
+	}
+	return report
+}
+
+func identityChanged(rc planparser.ResourceChange) bool {
+	switch rc.Type {
+	case "azurerm_user_assigned_identity", "azurerm_role_assignment":
+		return actuallyChanges(rc)
+	default:
+		return false
+	}
+}
+
+// actuallyChanges reports whether rc's actions represent a real change to
+// the resource, as opposed to a no-op or a plain data-source read.
+func actuallyChanges(rc planparser.ResourceChange) bool {
+	for _, a := range rc.Actions {
+		if a != planparser.ActionNoOp && a != planparser.ActionRead {
+			return true
+		}
+	}
+	return false
+}
+
+// OverrideLabel is the PR label that permits a high-risk plan to proceed.
+const OverrideLabel = "risk-accepted"
+
+// Gate fails with a descriptive error if report contains a High tier
+// finding and hasOverride is false.
+func Gate(report Report, hasOverride bool) error {
+	if report.HighestTier() != TierHigh || hasOverride {
+		return nil
+	}
+	msg := "riskscore: high-risk changes require the \"" + OverrideLabel + "\" label:"
+	for _, f := range report.Findings {
+		if f.Tier == TierHigh {
+			msg += fmt.Sprintf("\n  [%s] %s: %s", f.Tier, f.Address, f.Reason)
+		}
+	}
+	return fmt.Errorf("%s", msg)
+}
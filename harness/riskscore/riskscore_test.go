@@ -0,0 +1,63 @@
+package riskscore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/planparser"
+)
+
+func TestScoreFlagsStatefulReplacementAsHigh(t *testing.T) {
+	plan, err := planparser.Parse([]byte(`{
+      "resource_changes": [
+        {"address": "azurerm_kubernetes_cluster.this", "type": "azurerm_kubernetes_cluster", "name": "this",
+         "change": {"actions": ["delete", "create"]}}
+      ]
+    }`))
+	require.NoError(t, err)
+
+	report := Score(plan)
+	require.Len(t, report.Findings, 1)
+	assert.Equal(t, TierHigh, report.Findings[0].Tier)
+	assert.Equal(t, TierHigh, report.HighestTier())
+}
+
+func TestScoreIgnoresUnchangedIdentityResources(t *testing.T) {
+	plan, err := planparser.Parse([]byte(`{
+      "resource_changes": [
+        {"address": "azurerm_role_assignment.aks_acr_pull", "type": "azurerm_role_assignment", "name": "aks_acr_pull",
+         "change": {"actions": ["no-op"]}}
+      ]
+    }`))
+	require.NoError(t, err)
+
+	report := Score(plan)
+	assert.Empty(t, report.Findings)
+}
+
+func TestScoreFlagsChangedIdentityResourceAsMedium(t *testing.T) {
+	plan, err := planparser.Parse([]byte(`{
+      "resource_changes": [
+        {"address": "azurerm_user_assigned_identity.aks", "type": "azurerm_user_assigned_identity", "name": "aks",
+         "change": {"actions": ["update"]}}
+      ]
+    }`))
+	require.NoError(t, err)
+
+	report := Score(plan)
+	require.Len(t, report.Findings, 1)
+	assert.Equal(t, TierMedium, report.Findings[0].Tier)
+}
+
+func TestGateBlocksHighRiskWithoutOverride(t *testing.T) {
+	report := Report{Findings: []Finding{{Tier: TierHigh, Address: "x", Reason: "r"}}}
+
+	assert.Error(t, Gate(report, false))
+	assert.NoError(t, Gate(report, true))
+}
+
+func TestGateAllowsLowRiskPlans(t *testing.T) {
+	report := Report{Findings: []Finding{{Tier: TierMedium, Address: "x", Reason: "r"}}}
+	assert.NoError(t, Gate(report, false))
+}
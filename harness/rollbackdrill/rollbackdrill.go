@@ -0,0 +1,87 @@
+// Package rollbackdrill rehearses the actual rollback procedure in
+// automation: apply a controlled bad change, revert to the prior
+// configuration, and assert the environment converges back to an empty
+// plan with passing probes. It's opt-in — the drill mutates a real
+// environment's state twice — so callers gate it behind a test tier or
+// explicit flag rather than running it on every commit.
+package rollbackdrill
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/planparser"
+)
+
+// Applier applies the currently configured tfvars/overrides for stack,
+// e.g. runner.Runner.Up.
+type Applier interface {
+	Apply(stack string) error
+}
+
+// Planner returns the pending plan for stack without applying it.
+type Planner interface {
+	Plan(stack string) (planparser.Plan, error)
+}
+
+// Prober exercises the environment after a rollback to confirm it's
+// actually healthy, not just quiescent in Terraform's eyes.
+type Prober interface {
+	Attempt(ctx context.Context) error
+}
+
+// Result records the outcome of every step of the drill.
+type Result struct {
+	BadApplyErr    error
+	RevertApplyErr error
+	PlanErr        error
+	Plan           planparser.Plan
+	ProbeErr       error
+	Converged      bool // the post-revert plan had zero resource changes
+}
+
+// Passed reports whether the whole drill succeeded: the bad change
+// applied, the revert applied, the environment converged to an empty
+// plan, and the probe passed.
+func (r Result) Passed() bool {
+	return r.BadApplyErr == nil && r.RevertApplyErr == nil && r.PlanErr == nil &&
+		r.Converged && r.ProbeErr == nil
+}
+
+// Run applies the bad change already staged for stack via applier, calls
+// revert to restore the prior configuration (typically tfoverride
+// Cleanup, or checking out the previous commit's tfvars), applies again to
+// converge the environment back, and asserts the resulting plan is empty
+// and prober passes.
+func Run(ctx context.Context, applier Applier, planner Planner, prober Prober, stack string, revert func() error) Result {
+	var result Result
+
+	if err := applier.Apply(stack); err != nil {
+		result.BadApplyErr = fmt.Errorf("rollbackdrill: apply bad change to %s: %w", stack, err)
+		return result
+	}
+
+	if err := revert(); err != nil {
+		result.RevertApplyErr = fmt.Errorf("rollbackdrill: revert configuration for %s: %w", stack, err)
+		return result
+	}
+
+	if err := applier.Apply(stack); err != nil {
+		result.RevertApplyErr = fmt.Errorf("rollbackdrill: apply reverted configuration to %s: %w", stack, err)
+		return result
+	}
+
+	plan, err := planner.Plan(stack)
+	if err != nil {
+		result.PlanErr = fmt.Errorf("rollbackdrill: plan %s after revert: %w", stack, err)
+		return result
+	}
+	result.Plan = plan
+	result.Converged = len(plan.ResourceChanges) == 0
+
+	if err := prober.Attempt(ctx); err != nil {
+		result.ProbeErr = fmt.Errorf("rollbackdrill: post-revert probe against %s: %w", stack, err)
+	}
+
+	return result
+}
@@ -0,0 +1,94 @@
+package rollbackdrill
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/planparser"
+)
+
+type fakeApplier struct {
+	errs []error
+	call int
+}
+
+func (f *fakeApplier) Apply(stack string) error {
+	var err error
+	if f.call < len(f.errs) {
+		err = f.errs[f.call]
+	}
+	f.call++
+	return err
+}
+
+type fakePlanner struct {
+	plan planparser.Plan
+	err  error
+}
+
+func (f *fakePlanner) Plan(stack string) (planparser.Plan, error) { return f.plan, f.err }
+
+type fakeProber struct{ err error }
+
+func (f *fakeProber) Attempt(ctx context.Context) error { return f.err }
+
+func TestRunPassesWhenEverythingConverges(t *testing.T) {
+	applier := &fakeApplier{}
+	planner := &fakePlanner{}
+	prober := &fakeProber{}
+
+	result := Run(context.Background(), applier, planner, prober, "aks", func() error { return nil })
+
+	assert.True(t, result.Passed())
+	assert.True(t, result.Converged)
+	assert.Equal(t, 2, applier.call)
+}
+
+func TestRunFailsWhenBadChangeApplyFails(t *testing.T) {
+	applier := &fakeApplier{errs: []error{errors.New("apply failed")}}
+	planner := &fakePlanner{}
+	prober := &fakeProber{}
+
+	result := Run(context.Background(), applier, planner, prober, "aks", func() error { return nil })
+
+	assert.False(t, result.Passed())
+	assert.Error(t, result.BadApplyErr)
+	assert.Equal(t, 1, applier.call)
+}
+
+func TestRunFailsWhenRevertFuncFails(t *testing.T) {
+	applier := &fakeApplier{}
+	planner := &fakePlanner{}
+	prober := &fakeProber{}
+
+	result := Run(context.Background(), applier, planner, prober, "aks", func() error { return errors.New("cleanup failed") })
+
+	assert.False(t, result.Passed())
+	assert.Error(t, result.RevertApplyErr)
+}
+
+func TestRunFailsWhenPostRevertPlanIsNotEmpty(t *testing.T) {
+	applier := &fakeApplier{}
+	planner := &fakePlanner{plan: planparser.Plan{ResourceChanges: []planparser.ResourceChange{{Address: "azurerm_network_security_rule.bad"}}}}
+	prober := &fakeProber{}
+
+	result := Run(context.Background(), applier, planner, prober, "aks", func() error { return nil })
+
+	assert.False(t, result.Passed())
+	assert.False(t, result.Converged)
+}
+
+func TestRunFailsWhenProbeFailsAfterRevert(t *testing.T) {
+	applier := &fakeApplier{}
+	planner := &fakePlanner{}
+	prober := &fakeProber{err: errors.New("still unhealthy")}
+
+	result := Run(context.Background(), applier, planner, prober, "aks", func() error { return nil })
+
+	assert.False(t, result.Passed())
+	assert.Error(t, result.ProbeErr)
+	assert.True(t, result.Converged)
+}
@@ -0,0 +1,99 @@
+package runner
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"fmt"
+	"os"
+	"time"
+)
+
+const prodEnv = "prod"
+
+// tokenWindow is how long a confirmation token stays valid. It bounds the
+// blast radius of a leaked token to at most two windows (validateProdToken
+// also accepts the immediately preceding window for clock skew), but the
+// token is not consumed on use — the same token validates repeatedly for
+// its entire validity period, so treat it like any other short-lived
+// credential rather than a one-time code.
+const tokenWindow = time.Hour
+
+// NewConfirmationToken derives a short-lived confirmation token for env
+// from secret, valid for one tokenWindow around at. Operators fetch this
+// out-of-band (e.g. from a signed CI secret or a break-glass tool) and pass
+// it as Runner.ConfirmationToken to authorize a prod-targeting operation.
+func NewConfirmationToken(secret, env string, at time.Time) string {
+	return signWindow(secret, env, at.Truncate(tokenWindow))
+}
+
+func signWindow(secret, env string, window time.Time) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s|%d", env, window.Unix())
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(mac.Sum(nil))[:16]
+}
+
+// validateProdToken accepts a token signed for the current or immediately
+// preceding window, to tolerate clock skew between where the token was
+// minted and where it's redeemed.
+func validateProdToken(secret, env, token string, now time.Time) bool {
+	if token == "" {
+		return false
+	}
+	current := signWindow(secret, env, now.Truncate(tokenWindow))
+	previous := signWindow(secret, env, now.Add(-tokenWindow).Truncate(tokenWindow))
+	return subtle.ConstantTimeCompare([]byte(token), []byte(current)) == 1 ||
+		subtle.ConstantTimeCompare([]byte(token), []byte(previous)) == 1
+}
+
+// checkProdConfirmation refuses the operation unless r.Env is not "prod",
+// or r.ConfirmationSecret/ConfirmationToken validate. Every attempt against
+// prod, successful or not, is appended to auditLogPath as a durable trail.
+func (r *Runner) checkProdConfirmation(action, stack string) error {
+	if r.Env != prodEnv {
+		return nil
+	}
+
+	valid := validateProdToken(r.ConfirmationSecret, r.Env, r.ConfirmationToken, time.Now())
+	auditErr := appendAudit(r.AuditLogPath, auditEntry{
+		Time:      time.Now(),
+		Env:       r.Env,
+		Action:    action,
+		Stack:     stack,
+		Confirmed: valid,
+	})
+	if auditErr != nil {
+		// Auditing failures must not silently let a prod mutation through.
+		return fmt.Errorf("runner: write audit entry: %w", auditErr)
+	}
+
+	if !valid {
+		return fmt.Errorf("runner: %s against prod requires a valid ConfirmationToken", action)
+	}
+	return nil
+}
+
+type auditEntry struct {
+	Time      time.Time
+	Env       string
+	Action    string
+	Stack     string
+	Confirmed bool
+}
+
+func appendAudit(path string, entry auditEntry) error {
+	if path == "" {
+		path = "test/prod_audit.log"
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	line := fmt.Sprintf("%s action=%s env=%s stack=%s confirmed=%v\n",
+		entry.Time.Format(time.RFC3339), entry.Action, entry.Env, entry.Stack, entry.Confirmed)
+	_, err = f.WriteString(line)
+	return err
+}
@@ -0,0 +1,38 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpRequiresConfirmationTokenForProd(t *testing.T) {
+	auditPath := filepath.Join(t.TempDir(), "audit.log")
+	r := &Runner{Env: "prod", ConfirmationSecret: "s3cr3t", AuditLogPath: auditPath}
+
+	_, err := r.Up("aks-cluster")
+	assert.ErrorContains(t, err, "requires a valid ConfirmationToken")
+
+	audit, readErr := os.ReadFile(auditPath)
+	require.NoError(t, readErr)
+	assert.Contains(t, string(audit), "confirmed=false")
+}
+
+func TestValidateProdTokenAcceptsCurrentAndPreviousWindow(t *testing.T) {
+	now := time.Now()
+	token := NewConfirmationToken("s3cr3t", "prod", now)
+
+	assert.True(t, validateProdToken("s3cr3t", "prod", token, now))
+	assert.True(t, validateProdToken("s3cr3t", "prod", token, now.Add(tokenWindow)))
+	assert.False(t, validateProdToken("s3cr3t", "prod", token, now.Add(3*tokenWindow)))
+	assert.False(t, validateProdToken("wrong-secret", "prod", token, now))
+}
+
+func TestNonProdEnvsNeedNoConfirmation(t *testing.T) {
+	r := &Runner{Env: "pr-123"}
+	assert.NoError(t, r.checkProdConfirmation("up", "aks-cluster"))
+}
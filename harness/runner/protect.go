@@ -0,0 +1,33 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+)
+
+// PersistentEnvs are the environments that must never be torn down by an
+// automated run unless explicitly overridden. dev and staging are
+// long-lived and shared; deleting them out from under other engineers is a
+// production incident, not a test failure.
+var PersistentEnvs = map[string]bool{
+	"dev":     true,
+	"staging": true,
+}
+
+// allowDestroyEnvVar is set to the exact environment name being destroyed
+// to prove the override was intentional, not a leftover from a previous
+// run.
+const allowDestroyEnvVar = "ALLOW_DESTROY_PERSISTENT"
+
+// checkTeardownAllowed refuses to proceed if r.Env is a persistent
+// environment, unless ALLOW_DESTROY_PERSISTENT is set to that exact
+// environment name.
+func (r *Runner) checkTeardownAllowed() error {
+	if !PersistentEnvs[r.Env] {
+		return nil
+	}
+	if os.Getenv(allowDestroyEnvVar) == r.Env {
+		return nil
+	}
+	return fmt.Errorf("runner: refusing to destroy persistent environment %q; set %s=%s to override", r.Env, allowDestroyEnvVar, r.Env)
+}
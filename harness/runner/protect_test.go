@@ -0,0 +1,25 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownRefusesPersistentEnvWithoutOverride(t *testing.T) {
+	r := New("staging")
+	_, err := r.Down("aks-cluster")
+	assert.ErrorContains(t, err, "persistent environment")
+}
+
+func TestDownAllowsPersistentEnvWithOverride(t *testing.T) {
+	t.Setenv("ALLOW_DESTROY_PERSISTENT", "staging")
+	r := New("staging")
+	err := r.checkTeardownAllowed()
+	assert.NoError(t, err)
+}
+
+func TestDownAllowsNonPersistentEnvByDefault(t *testing.T) {
+	r := New("pr-123")
+	assert.NoError(t, r.checkTeardownAllowed())
+}
@@ -0,0 +1,201 @@
+// Package runner wraps Terraspace CLI invocations (`terraspace up`,
+// `terraspace down`) used to drive stacks from Go tests and tooling.
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Runner drives Terraspace against a single environment.
+type Runner struct {
+	// Env is the Terraspace environment (TS_ENV) this Runner targets.
+	Env string
+	// Dir is the working directory to run terraspace from, typically the
+	// repository root. Defaults to the current directory when empty.
+	Dir string
+
+	// ConfirmationToken and ConfirmationSecret authorize operations
+	// against the prod environment; see prodguard.go.
+	ConfirmationToken  string
+	ConfirmationSecret string
+	// AuditLogPath overrides where prod-targeting attempts are recorded.
+	AuditLogPath string
+
+	// Tuning overrides Terraform's apply concurrency and provider retry
+	// behavior for this Runner's mutating operations (Up, Down). Read-only
+	// operations (Plan, Init, Validate, Output, StateList) ignore it,
+	// since parallelism only affects walking the graph to make changes.
+	Tuning Tuning
+}
+
+// Tuning controls Terraform apply concurrency and azurerm provider retry
+// behavior. Large stacks with hundreds of resources are both slow and
+// throttle-prone under Terraform's default settings against a
+// rate-limited subscription; tune these per stack once
+// harness/applytuning has found the sweet spot rather than guessing.
+type Tuning struct {
+	// Parallelism is passed as `-parallelism=N`. Zero uses Terraform's
+	// default (10).
+	Parallelism int
+	// PartnerID is set as ARM_PARTNER_ID so Azure can attribute
+	// throttling and support cases back to this pipeline.
+	PartnerID string
+	// ClientRetriesMax is set as ARM_CLIENT_RETRIES_MAX, the azurerm
+	// provider's cap on automatic retries of throttled (429) requests.
+	// Zero uses the provider's default.
+	ClientRetriesMax int
+}
+
+func (t Tuning) args() []string {
+	if t.Parallelism <= 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("-parallelism=%d", t.Parallelism)}
+}
+
+func (t Tuning) env() []string {
+	var env []string
+	if t.PartnerID != "" {
+		env = append(env, "ARM_PARTNER_ID="+t.PartnerID)
+	}
+	if t.ClientRetriesMax > 0 {
+		env = append(env, fmt.Sprintf("ARM_CLIENT_RETRIES_MAX=%d", t.ClientRetriesMax))
+	}
+	return env
+}
+
+// New returns a Runner targeting env.
+func New(env string) *Runner {
+	return &Runner{Env: env}
+}
+
+// Result records the outcome of a single terraspace invocation.
+type Result struct {
+	Stack    string
+	Duration time.Duration
+	Output   string
+}
+
+// Up runs `terraspace up <stack> --auto-approve --yes` against r.Env. If
+// r.Env is "prod" this also requires a valid ConfirmationToken (see
+// prodguard.go).
+func (r *Runner) Up(stack string) (Result, error) {
+	if err := r.checkProdConfirmation("up", stack); err != nil {
+		return Result{Stack: stack}, err
+	}
+	return r.run("up", stack)
+}
+
+// ApplyWithTuning runs Up against stack with r.Tuning temporarily
+// replaced by tuning, restoring r.Tuning before returning. It exists so
+// callers benchmarking parallelism settings (harness/applytuning) don't
+// need a fresh Runner per trial.
+func (r *Runner) ApplyWithTuning(stack string, tuning Tuning) (Result, error) {
+	prev := r.Tuning
+	r.Tuning = tuning
+	defer func() { r.Tuning = prev }()
+	return r.Up(stack)
+}
+
+// Down runs `terraspace down <stack> --auto-approve --yes` against r.Env,
+// refusing outright if r.Env is a persistent environment (see protect.go)
+// or, for prod, without a valid ConfirmationToken (see prodguard.go).
+func (r *Runner) Down(stack string) (Result, error) {
+	if err := r.checkTeardownAllowed(); err != nil {
+		return Result{Stack: stack}, err
+	}
+	if err := r.checkProdConfirmation("down", stack); err != nil {
+		return Result{Stack: stack}, err
+	}
+	return r.run("down", stack)
+}
+
+// Plan runs `terraspace plan <stack>` against r.Env and returns its
+// output for the caller to inspect (e.g. with harness/tsoutput) — a
+// read-only operation, so it isn't gated by prodguard or protect.
+func (r *Runner) Plan(stack string) (Result, error) {
+	return r.readOnly("plan", stack)
+}
+
+// Init runs `terraspace init <stack>` against r.Env, downloading provider
+// plugins and modules. A read-only operation, so it isn't gated by
+// prodguard or protect.
+func (r *Runner) Init(stack string) (Result, error) {
+	return r.readOnly("init", stack)
+}
+
+// Validate runs `terraspace validate <stack>` against r.Env, checking the
+// stack's configuration is syntactically valid and internally consistent
+// without touching any real infrastructure.
+func (r *Runner) Validate(stack string) (Result, error) {
+	return r.readOnly("validate", stack)
+}
+
+// Output runs `terraspace output <stack> --format json` against r.Env and
+// decodes the result into a map of output name to value.
+func (r *Runner) Output(stack string) (map[string]any, error) {
+	result, err := r.readOnly("output", stack, "--format", "json")
+	if err != nil {
+		return nil, err
+	}
+	var outputs map[string]any
+	if err := json.Unmarshal([]byte(result.Output), &outputs); err != nil {
+		return nil, fmt.Errorf("runner: parse terraspace output %s (env=%s): %w\n%s", stack, r.Env, err, result.Output)
+	}
+	return outputs, nil
+}
+
+// StateList runs `terraspace state list <stack>` against r.Env and
+// returns the resource addresses in Terraform state, one per line of
+// output.
+func (r *Runner) StateList(stack string) ([]string, error) {
+	result, err := r.readOnly("state", stack, "list")
+	if err != nil {
+		return nil, err
+	}
+	var addresses []string
+	for _, line := range strings.Split(result.Output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			addresses = append(addresses, line)
+		}
+	}
+	return addresses, nil
+}
+
+// readOnly runs `terraspace <action> <stack> <extraArgs...>` against
+// r.Env. It's the Init/Validate/Plan/Output/StateList counterpart to run:
+// none of these mutate infrastructure, so none are gated by prodguard or
+// protect.
+func (r *Runner) readOnly(action, stack string, extraArgs ...string) (Result, error) {
+	start := time.Now()
+	cmd := exec.Command("terraspace", append([]string{action, stack}, extraArgs...)...)
+	cmd.Dir = r.Dir
+	cmd.Env = append(os.Environ(), "TS_ENV="+r.Env)
+
+	out, err := cmd.CombinedOutput()
+	result := Result{Stack: stack, Duration: time.Since(start), Output: string(out)}
+	if err != nil {
+		return result, fmt.Errorf("runner: terraspace %s %s (env=%s): %w\n%s", action, stack, r.Env, err, out)
+	}
+	return result, nil
+}
+
+func (r *Runner) run(action, stack string) (Result, error) {
+	start := time.Now()
+	args := append([]string{action, stack, "--auto-approve", "--yes"}, r.Tuning.args()...)
+	cmd := exec.Command("terraspace", args...)
+	cmd.Dir = r.Dir
+	cmd.Env = append(append(os.Environ(), "TS_ENV="+r.Env), r.Tuning.env()...)
+
+	out, err := cmd.CombinedOutput()
+	result := Result{Stack: stack, Duration: time.Since(start), Output: string(out)}
+	if err != nil {
+		return result, fmt.Errorf("runner: terraspace %s %s (env=%s): %w\n%s", action, stack, r.Env, err, out)
+	}
+	return result, nil
+}
@@ -0,0 +1,137 @@
+// Package sandbox provisions a personal, cost-capped, TTL-tagged copy of
+// the core+aks stacks for ad hoc developer testing. It reuses the same
+// config/envs/<env>/<stack>.tfvars convention harness/scaffold writes for
+// real environments, so a sandbox is driven by harness/runner exactly like
+// dev or staging.
+package sandbox
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// CoreStacks are the stacks a sandbox provisions, in dependency order.
+var CoreStacks = []string{"core", "aks-cluster"}
+
+// DefaultTTL is how long a sandbox lives before it's expected to be torn
+// down if the owner doesn't specify one.
+const DefaultTTL = 8 * time.Hour
+
+// Options configures a personal sandbox environment.
+type Options struct {
+	Owner string // developer alias; required
+	TTL   time.Duration
+	Now   func() time.Time
+}
+
+// Config is the resolved environment name, resource prefix and tags for a
+// sandbox.
+type Config struct {
+	Env       string
+	Prefix    string
+	ExpiresAt time.Time
+	Tags      map[string]string
+}
+
+// Resolve derives a Config from opts, defaulting TTL to DefaultTTL and Now
+// to time.Now.
+func Resolve(opts Options) (Config, error) {
+	if opts.Owner == "" {
+		return Config{}, fmt.Errorf("sandbox: owner is required")
+	}
+	ttl := opts.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	now := opts.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	owner := sanitize(opts.Owner)
+	if owner == "" {
+		return Config{}, fmt.Errorf("sandbox: owner %q has no valid characters after sanitizing", opts.Owner)
+	}
+	expires := now().Add(ttl)
+
+	return Config{
+		Env:       "sandbox-" + owner,
+		Prefix:    "sbx" + owner,
+		ExpiresAt: expires,
+		Tags: map[string]string{
+			"owner":      owner,
+			"purpose":    "developer-sandbox",
+			"expires-at": expires.UTC().Format(time.RFC3339),
+		},
+	}, nil
+}
+
+// WriteTFVars writes minimal-SKU, prefixed, TTL-tagged tfvars for every
+// stack in CoreStacks under config/envs/<cfg.Env>/ so `terraspace up`
+// against cfg.Env behaves like any other environment.
+func WriteTFVars(root string, cfg Config) ([]string, error) {
+	var written []string
+	for _, stack := range CoreStacks {
+		path := filepath.Join(root, "config", "envs", cfg.Env, stack+".tfvars")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return written, fmt.Errorf("sandbox: create env dir for %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, []byte(renderTFVars(cfg)), 0o644); err != nil {
+			return written, fmt.Errorf("sandbox: write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// Teardown removes the sandbox's generated tfvars directory. Callers must
+// tear down the provisioned infrastructure first (see cmd/sandbox); this
+// only cleans up the local config that pointed at it.
+func Teardown(root string, cfg Config) error {
+	dir := filepath.Join(root, "config", "envs", cfg.Env)
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("sandbox: remove %s: %w", dir, err)
+	}
+	return nil
+}
+
+func renderTFVars(cfg Config) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "env         = %q\n", cfg.Env)
+	fmt.Fprintf(&b, "region      = \"eastus2\"\n")
+	fmt.Fprintf(&b, "name_prefix = %q\n", cfg.Prefix)
+	fmt.Fprintf(&b, "sku_tier    = \"Free\"\n")
+	fmt.Fprintf(&b, "node_count  = 1\n")
+	fmt.Fprintf(&b, "vm_size     = \"Standard_B2s\"\n")
+
+	keys := make([]string, 0, len(cfg.Tags))
+	for k := range cfg.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	b.WriteString("tags = {\n")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "  %s = %q\n", k, cfg.Tags[k])
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// sanitize lowercases owner and strips everything but letters and digits,
+// so it's safe to use as part of a Terraspace environment name and Azure
+// resource prefix.
+func sanitize(owner string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(owner) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
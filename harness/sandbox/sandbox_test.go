@@ -0,0 +1,66 @@
+package sandbox
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSanitizesOwnerAndSetsTTL(t *testing.T) {
+	fixedNow := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cfg, err := Resolve(Options{
+		Owner: "Jane.Doe",
+		TTL:   2 * time.Hour,
+		Now:   func() time.Time { return fixedNow },
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "sandbox-janedoe", cfg.Env)
+	assert.Equal(t, "sbxjanedoe", cfg.Prefix)
+	assert.Equal(t, fixedNow.Add(2*time.Hour), cfg.ExpiresAt)
+	assert.Equal(t, "janedoe", cfg.Tags["owner"])
+}
+
+func TestResolveDefaultsTTL(t *testing.T) {
+	fixedNow := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg, err := Resolve(Options{Owner: "dev", Now: func() time.Time { return fixedNow }})
+	require.NoError(t, err)
+	assert.Equal(t, fixedNow.Add(DefaultTTL), cfg.ExpiresAt)
+}
+
+func TestResolveRejectsEmptyOwner(t *testing.T) {
+	_, err := Resolve(Options{})
+	assert.Error(t, err)
+}
+
+func TestResolveRejectsOwnerWithNoValidCharacters(t *testing.T) {
+	_, err := Resolve(Options{Owner: "!!!"})
+	assert.Error(t, err)
+}
+
+func TestWriteTFVarsAndTeardown(t *testing.T) {
+	root := t.TempDir()
+	cfg, err := Resolve(Options{Owner: "dev"})
+	require.NoError(t, err)
+
+	written, err := WriteTFVars(root, cfg)
+	require.NoError(t, err)
+	require.Len(t, written, len(CoreStacks))
+
+	for _, stack := range CoreStacks {
+		path := filepath.Join(root, "config", "envs", cfg.Env, stack+".tfvars")
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), cfg.Prefix)
+		assert.Contains(t, string(data), "Standard_B2s")
+	}
+
+	require.NoError(t, Teardown(root, cfg))
+	_, err = os.Stat(filepath.Join(root, "config", "envs", cfg.Env))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,147 @@
+// Package scaffold generates a new Terraspace stack together with its
+// matching Go test, per-environment tfvars, and expectations fixture, and
+// registers it in the stack dependency graph, so every new stack starts
+// with tests instead of having them bolted on later.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+// DefaultEnvs are the environments every new stack gets a tfvars file for.
+var DefaultEnvs = []string{"dev", "staging", "prod"}
+
+// Options describes the stack to scaffold.
+type Options struct {
+	// Root is the repository root.
+	Root string
+	// Name is the stack name, e.g. "aks-cluster". Used as the directory
+	// name under app/stacks and the Terraspace stack identifier.
+	Name string
+	// Envs overrides DefaultEnvs when non-empty.
+	Envs []string
+}
+
+// New writes the stack's Terraform files, per-env tfvars, expectations
+// fixture, Go test, and a dependency graph entry under opts.Root. It
+// returns the list of files created.
+func New(opts Options) ([]string, error) {
+	if opts.Name == "" {
+		return nil, fmt.Errorf("scaffold: stack name is required")
+	}
+	envs := opts.Envs
+	if len(envs) == 0 {
+		envs = DefaultEnvs
+	}
+
+	data := struct {
+		Name      string
+		Env       string
+		TitleName string
+	}{Name: opts.Name, TitleName: titleCase(opts.Name)}
+
+	var created []string
+
+	stackDir := filepath.Join(opts.Root, "app", "stacks", opts.Name)
+	for _, tmplName := range []string{"main.tf", "variables.tf", "outputs.tf"} {
+		path := filepath.Join(stackDir, tmplName)
+		if err := renderTemplate(tmplName+".tmpl", path, data); err != nil {
+			return created, err
+		}
+		created = append(created, path)
+	}
+
+	for _, env := range envs {
+		envData := data
+		envData.Env = env
+		path := filepath.Join(opts.Root, "config", "envs", env, opts.Name+".tfvars")
+		if err := renderTemplate("env.tfvars.tmpl", path, envData); err != nil {
+			return created, err
+		}
+		created = append(created, path)
+	}
+
+	expectPath := filepath.Join(opts.Root, "test", "expectations", opts.Name+".yaml")
+	if err := renderTemplate("expectations.yaml.tmpl", expectPath, data); err != nil {
+		return created, err
+	}
+	created = append(created, expectPath)
+
+	testPath := filepath.Join(opts.Root, "test", "stacks", opts.Name+"_test.go")
+	if err := renderTemplate("stack_test.go.tmpl", testPath, data); err != nil {
+		return created, err
+	}
+	created = append(created, testPath)
+
+	graphPath := filepath.Join(opts.Root, "config", "stacks_graph.yml")
+	if err := registerInGraph(graphPath, opts.Name); err != nil {
+		return created, err
+	}
+	created = append(created, graphPath)
+
+	return created, nil
+}
+
+func renderTemplate(tmplName, destPath string, data any) error {
+	tmpl, err := template.ParseFS(templatesFS, "templates/"+tmplName)
+	if err != nil {
+		return fmt.Errorf("scaffold: parse template %s: %w", tmplName, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("scaffold: render template %s: %w", tmplName, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+		return fmt.Errorf("scaffold: create dir for %s: %w", destPath, err)
+	}
+	if err := os.WriteFile(destPath, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("scaffold: write %s: %w", destPath, err)
+	}
+	return nil
+}
+
+// registerInGraph appends a no-dependency entry for name to the stack
+// dependency graph file, creating it if it doesn't yet exist.
+func registerInGraph(graphPath, name string) error {
+	existing, err := os.ReadFile(graphPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("scaffold: read %s: %w", graphPath, err)
+	}
+	if strings.Contains(string(existing), name+":") {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(graphPath), 0o755); err != nil {
+		return err
+	}
+	entry := fmt.Sprintf("%s:\n  depends_on: []\n", name)
+	f, err := os.OpenFile(graphPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("scaffold: open %s: %w", graphPath, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(entry); err != nil {
+		return fmt.Errorf("scaffold: append to %s: %w", graphPath, err)
+	}
+	return nil
+}
+
+func titleCase(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' })
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+		parts[i] = strings.ToUpper(p[:1]) + p[1:]
+	}
+	return strings.Join(parts, "")
+}
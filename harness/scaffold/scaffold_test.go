@@ -0,0 +1,46 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCreatesStackFilesAndRegistersDependency(t *testing.T) {
+	root := t.TempDir()
+
+	created, err := New(Options{Root: root, Name: "demo-pool", Envs: []string{"dev"}})
+	require.NoError(t, err)
+	require.NotEmpty(t, created)
+
+	assert.FileExists(t, filepath.Join(root, "app", "stacks", "demo-pool", "main.tf"))
+	assert.FileExists(t, filepath.Join(root, "app", "stacks", "demo-pool", "variables.tf"))
+	assert.FileExists(t, filepath.Join(root, "config", "envs", "dev", "demo-pool.tfvars"))
+	assert.FileExists(t, filepath.Join(root, "test", "expectations", "demo-pool.yaml"))
+	assert.FileExists(t, filepath.Join(root, "test", "stacks", "demo-pool_test.go"))
+
+	graph, err := os.ReadFile(filepath.Join(root, "config", "stacks_graph.yml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(graph), "demo-pool:")
+
+	testSrc, err := os.ReadFile(filepath.Join(root, "test", "stacks", "demo-pool_test.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(testSrc), "TestStackDemoPoolDeploys")
+}
+
+func TestNewIsIdempotentForGraphRegistration(t *testing.T) {
+	root := t.TempDir()
+
+	_, err := New(Options{Root: root, Name: "demo", Envs: []string{"dev"}})
+	require.NoError(t, err)
+	_, err = New(Options{Root: root, Name: "demo", Envs: []string{"dev"}})
+	require.NoError(t, err)
+
+	graph, err := os.ReadFile(filepath.Join(root, "config", "stacks_graph.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(graph), "demo:"))
+}
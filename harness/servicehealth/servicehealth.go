@@ -0,0 +1,93 @@
+// Package servicehealth preflights Azure Service Health for the target
+// region and services before a deploy test runs. If an active advisory
+// affects AKS/networking there, the caller should skip with a reason
+// instead of producing a noisy failure that gets blamed on the module.
+package servicehealth
+
+import (
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resourcehealth/armresourcehealth"
+)
+
+// WatchedServices are the Azure services whose active advisories should
+// gate a deploy test, since an outage in any of them can masquerade as a
+// module bug.
+var WatchedServices = []string{
+	"Azure Kubernetes Service (AKS)",
+	"Virtual Network",
+	"Azure Front Door",
+	"Traffic Manager",
+}
+
+// Advisory is a single active Service Health event relevant to this
+// module's deploy tests.
+type Advisory struct {
+	Title   string
+	Service string
+	Region  string
+	Reason  string
+}
+
+// Preflight inspects events for any active event affecting region and one
+// of WatchedServices, returning an Advisory per match. An empty result
+// means it's safe to proceed with a normal deploy test.
+func Preflight(events []*armresourcehealth.Event, region string) []Advisory {
+	watched := make(map[string]bool, len(WatchedServices))
+	for _, s := range WatchedServices {
+		watched[s] = true
+	}
+
+	var advisories []Advisory
+	for _, event := range events {
+		props := event.Properties
+		if props == nil || props.Status == nil || *props.Status != armresourcehealth.EventStatusValuesActive {
+			continue
+		}
+		for _, impact := range props.Impact {
+			if impact.ImpactedService == nil || !watched[*impact.ImpactedService] {
+				continue
+			}
+			if !impactsRegion(impact, region) {
+				continue
+			}
+			advisories = append(advisories, Advisory{
+				Title:   derefStr(props.Title),
+				Service: *impact.ImpactedService,
+				Region:  region,
+				Reason:  derefStr(props.Summary),
+			})
+		}
+	}
+	return advisories
+}
+
+// SkipReason formats advisories into a single message suitable for
+// t.Skip(), or returns "" if there's nothing to skip for.
+func SkipReason(advisories []Advisory) string {
+	if len(advisories) == 0 {
+		return ""
+	}
+	reason := "active Azure Service Health advisories affect this deploy: "
+	for i, a := range advisories {
+		if i > 0 {
+			reason += "; "
+		}
+		reason += a.Service + " in " + a.Region + ": " + a.Title
+	}
+	return reason
+}
+
+func impactsRegion(impact *armresourcehealth.Impact, region string) bool {
+	for _, r := range impact.ImpactedRegions {
+		if r.ImpactedRegion != nil && *r.ImpactedRegion == region {
+			return true
+		}
+	}
+	return false
+}
+
+func derefStr(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
@@ -0,0 +1,60 @@
+package servicehealth
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resourcehealth/armresourcehealth"
+	"github.com/stretchr/testify/assert"
+)
+
+func strPtr(s string) *string { return &s }
+
+func activeEvent(title, service, region string) *armresourcehealth.Event {
+	status := armresourcehealth.EventStatusValuesActive
+	return &armresourcehealth.Event{
+		Properties: &armresourcehealth.EventProperties{
+			Title:   strPtr(title),
+			Summary: strPtr("regional network degradation"),
+			Status:  &status,
+			Impact: []*armresourcehealth.Impact{
+				{
+					ImpactedService: strPtr(service),
+					ImpactedRegions: []*armresourcehealth.ImpactedServiceRegion{
+						{ImpactedRegion: strPtr(region)},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestPreflightFlagsActiveAdvisoryForWatchedServiceInRegion(t *testing.T) {
+	events := []*armresourcehealth.Event{activeEvent("AKS control plane degradation", "Azure Kubernetes Service (AKS)", "eastus2")}
+
+	advisories := Preflight(events, "eastus2")
+	assert.Len(t, advisories, 1)
+	assert.Equal(t, "Azure Kubernetes Service (AKS)", advisories[0].Service)
+	assert.NotEmpty(t, SkipReason(advisories))
+}
+
+func TestPreflightIgnoresOtherRegions(t *testing.T) {
+	events := []*armresourcehealth.Event{activeEvent("AKS control plane degradation", "Azure Kubernetes Service (AKS)", "westus2")}
+	assert.Empty(t, Preflight(events, "eastus2"))
+}
+
+func TestPreflightIgnoresUnwatchedServices(t *testing.T) {
+	events := []*armresourcehealth.Event{activeEvent("Storage blip", "Azure Storage", "eastus2")}
+	assert.Empty(t, Preflight(events, "eastus2"))
+}
+
+func TestPreflightIgnoresResolvedEvents(t *testing.T) {
+	resolved := armresourcehealth.EventStatusValuesResolved
+	event := activeEvent("AKS incident (resolved)", "Azure Kubernetes Service (AKS)", "eastus2")
+	event.Properties.Status = &resolved
+
+	assert.Empty(t, Preflight([]*armresourcehealth.Event{event}, "eastus2"))
+}
+
+func TestSkipReasonEmptyForNoAdvisories(t *testing.T) {
+	assert.Empty(t, SkipReason(nil))
+}
@@ -0,0 +1,121 @@
+package siemforward
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/eventhub/armeventhub"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+func boolPtr(b bool) *bool    { return &b }
+
+func namespace(provisioningState string, approved bool) armeventhub.EHNamespace {
+	status := armeventhub.PrivateLinkConnectionStatusPending
+	if approved {
+		status = armeventhub.PrivateLinkConnectionStatusApproved
+	}
+	return armeventhub.EHNamespace{
+		Properties: &armeventhub.EHNamespaceProperties{
+			ProvisioningState: strPtr(provisioningState),
+			PrivateEndpointConnections: []*armeventhub.PrivateEndpointConnection{
+				{Properties: &armeventhub.PrivateEndpointConnectionProperties{
+					PrivateLinkServiceConnectionState: &armeventhub.ConnectionState{Status: &status},
+				}},
+			},
+		},
+	}
+}
+
+func TestVerifyNamespacePassesWhenProvisionedAndApproved(t *testing.T) {
+	expected := ExpectedForwarding{RequirePrivateLink: true}
+	assert.Empty(t, VerifyNamespace(namespace("Succeeded", true), expected))
+}
+
+func TestVerifyNamespaceFlagsMissingApprovedPrivateEndpoint(t *testing.T) {
+	expected := ExpectedForwarding{RequirePrivateLink: true}
+	violations := VerifyNamespace(namespace("Succeeded", false), expected)
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyDiagnosticSettingFlagsMissingCategory(t *testing.T) {
+	setting := armmonitor.DiagnosticSettingsResource{
+		Properties: &armmonitor.DiagnosticSettings{
+			EventHubAuthorizationRuleID: strPtr("/subscriptions/x/authrule"),
+			Logs: []*armmonitor.LogSettings{
+				{Category: strPtr("kube-audit"), Enabled: boolPtr(true)},
+			},
+		},
+	}
+	expected := ExpectedForwarding{
+		EventHubNamespaceID: "/subscriptions/x/authrule",
+		Categories:          []string{"kube-audit", "kube-audit-admin"},
+	}
+	violations := VerifyDiagnosticSetting(setting, expected)
+	assert.Len(t, violations, 1)
+}
+
+func TestVerifyDiagnosticSettingPassesOnMatch(t *testing.T) {
+	setting := armmonitor.DiagnosticSettingsResource{
+		Properties: &armmonitor.DiagnosticSettings{
+			EventHubAuthorizationRuleID: strPtr("/subscriptions/x/authrule"),
+			Logs: []*armmonitor.LogSettings{
+				{Category: strPtr("kube-audit-admin"), Enabled: boolPtr(true)},
+			},
+		},
+	}
+	expected := ExpectedForwarding{
+		EventHubNamespaceID: "/subscriptions/x/authrule",
+		Categories:          []string{"kube-audit-admin"},
+	}
+	assert.Empty(t, VerifyDiagnosticSetting(setting, expected))
+}
+
+type fakeConsumer struct {
+	eventsAt time.Duration
+	elapsed  time.Duration
+}
+
+func (f *fakeConsumer) EventsSince(ctx context.Context, since time.Time) ([]Event, error) {
+	if f.elapsed >= f.eventsAt {
+		return []Event{{EnqueuedTime: since.Add(f.elapsed)}}, nil
+	}
+	return nil, nil
+}
+
+func TestAwaitRecentEventReturnsOnceEventArrives(t *testing.T) {
+	consumer := &fakeConsumer{eventsAt: 10 * time.Second}
+	elapsed := time.Duration(0)
+	fakeNow := func() time.Time { return time.Unix(0, 0).Add(elapsed) }
+	fakeSleep := func(d time.Duration) {
+		elapsed += d
+		consumer.elapsed = elapsed
+	}
+
+	err := AwaitRecentEvent(context.Background(), consumer, time.Unix(0, 0), AwaitOptions{
+		Timeout:      time.Minute,
+		PollInterval: 5 * time.Second,
+		Now:          fakeNow,
+		Sleep:        fakeSleep,
+	})
+	require.NoError(t, err)
+}
+
+func TestAwaitRecentEventTimesOutWithoutEvent(t *testing.T) {
+	consumer := &fakeConsumer{eventsAt: time.Hour}
+	elapsed := time.Duration(0)
+	fakeNow := func() time.Time { return time.Unix(0, 0).Add(elapsed) }
+	fakeSleep := func(d time.Duration) { elapsed += d }
+
+	err := AwaitRecentEvent(context.Background(), consumer, time.Unix(0, 0), AwaitOptions{
+		Timeout:      30 * time.Second,
+		PollInterval: 10 * time.Second,
+		Now:          fakeNow,
+		Sleep:        fakeSleep,
+	})
+	assert.Error(t, err)
+}
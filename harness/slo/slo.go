@@ -0,0 +1,75 @@
+// Package slo defines per-stack SLOs (max apply time, max verify time,
+// max flake retries) and checks a run against them, so a stack's
+// deployment profile degrading shows up as a distinct SLO breach in
+// reporting rather than getting lost among ordinary functional failures.
+package slo
+
+import (
+	"fmt"
+	"time"
+)
+
+// Definition is the SLO budget for a single stack.
+type Definition struct {
+	Stack           string
+	MaxApplyTime    time.Duration
+	MaxVerifyTime   time.Duration
+	MaxFlakeRetries int
+}
+
+// Observation is what actually happened in one run of a stack.
+type Observation struct {
+	Stack        string
+	ApplyTime    time.Duration
+	VerifyTime   time.Duration
+	FlakeRetries int
+}
+
+// Breach is a single SLO dimension a run exceeded.
+type Breach struct {
+	Stack   string
+	Message string
+}
+
+// Check compares obs against def and returns a Breach for every dimension
+// obs exceeded. A breach is a degraded deployment profile, not a
+// functional failure — the run may otherwise have passed.
+func Check(def Definition, obs Observation) []Breach {
+	var breaches []Breach
+
+	if def.MaxApplyTime > 0 && obs.ApplyTime > def.MaxApplyTime {
+		breaches = append(breaches, Breach{Stack: def.Stack, Message: fmt.Sprintf(
+			"apply took %s, exceeding the %s SLO", obs.ApplyTime.Round(time.Second), def.MaxApplyTime.Round(time.Second))})
+	}
+	if def.MaxVerifyTime > 0 && obs.VerifyTime > def.MaxVerifyTime {
+		breaches = append(breaches, Breach{Stack: def.Stack, Message: fmt.Sprintf(
+			"verify took %s, exceeding the %s SLO", obs.VerifyTime.Round(time.Second), def.MaxVerifyTime.Round(time.Second))})
+	}
+	if def.MaxFlakeRetries > 0 && obs.FlakeRetries > def.MaxFlakeRetries {
+		breaches = append(breaches, Breach{Stack: def.Stack, Message: fmt.Sprintf(
+			"needed %d flake retries, exceeding the %d retry SLO", obs.FlakeRetries, def.MaxFlakeRetries)})
+	}
+
+	return breaches
+}
+
+// CheckAll runs Check for every stack present in both defs and obs,
+// keyed by Definition.Stack / Observation.Stack. Stacks with no matching
+// definition are skipped rather than treated as a breach, since SLOs are
+// opt-in per stack.
+func CheckAll(defs []Definition, obs []Observation) []Breach {
+	byStack := make(map[string]Definition, len(defs))
+	for _, d := range defs {
+		byStack[d.Stack] = d
+	}
+
+	var breaches []Breach
+	for _, o := range obs {
+		def, ok := byStack[o.Stack]
+		if !ok {
+			continue
+		}
+		breaches = append(breaches, Check(def, o)...)
+	}
+	return breaches
+}
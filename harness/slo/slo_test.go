@@ -0,0 +1,64 @@
+package slo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckFlagsSlowApply(t *testing.T) {
+	def := Definition{Stack: "aks", MaxApplyTime: 10 * time.Minute}
+	obs := Observation{Stack: "aks", ApplyTime: 15 * time.Minute}
+
+	breaches := Check(def, obs)
+	assert.Len(t, breaches, 1)
+	assert.Contains(t, breaches[0].Message, "apply took")
+}
+
+func TestCheckFlagsSlowVerify(t *testing.T) {
+	def := Definition{Stack: "aks", MaxVerifyTime: 5 * time.Minute}
+	obs := Observation{Stack: "aks", VerifyTime: 8 * time.Minute}
+
+	breaches := Check(def, obs)
+	assert.Len(t, breaches, 1)
+	assert.Contains(t, breaches[0].Message, "verify took")
+}
+
+func TestCheckFlagsExcessiveFlakeRetries(t *testing.T) {
+	def := Definition{Stack: "aks", MaxFlakeRetries: 2}
+	obs := Observation{Stack: "aks", FlakeRetries: 5}
+
+	breaches := Check(def, obs)
+	assert.Len(t, breaches, 1)
+	assert.Contains(t, breaches[0].Message, "flake retries")
+}
+
+func TestCheckPassesWithinBudget(t *testing.T) {
+	def := Definition{Stack: "aks", MaxApplyTime: 10 * time.Minute, MaxVerifyTime: 5 * time.Minute, MaxFlakeRetries: 2}
+	obs := Observation{Stack: "aks", ApplyTime: 9 * time.Minute, VerifyTime: 4 * time.Minute, FlakeRetries: 1}
+
+	assert.Empty(t, Check(def, obs))
+}
+
+func TestCheckAllSkipsStacksWithoutADefinition(t *testing.T) {
+	defs := []Definition{{Stack: "aks", MaxApplyTime: time.Minute}}
+	obs := []Observation{{Stack: "frontdoor", ApplyTime: time.Hour}}
+
+	assert.Empty(t, CheckAll(defs, obs))
+}
+
+func TestCheckAllAggregatesBreachesAcrossStacks(t *testing.T) {
+	defs := []Definition{
+		{Stack: "aks", MaxApplyTime: time.Minute},
+		{Stack: "frontdoor", MaxApplyTime: time.Minute},
+	}
+	obs := []Observation{
+		{Stack: "aks", ApplyTime: 2 * time.Minute},
+		{Stack: "frontdoor", ApplyTime: 30 * time.Second},
+	}
+
+	breaches := CheckAll(defs, obs)
+	assert.Len(t, breaches, 1)
+	assert.Equal(t, "aks", breaches[0].Stack)
+}
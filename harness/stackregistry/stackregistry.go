@@ -0,0 +1,77 @@
+// Package stackregistry parses the stack dependency graph
+// (config/stacks_graph.yml) and exposes which stacks are marked
+// experimental. Experimental stacks run plan-only, never gate merges, and
+// have their results reported separately, so new stacks (monitoring,
+// mesh, backup) can be added incrementally without destabilizing the main
+// pipeline.
+package stackregistry
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StackConfig is a single stack's entry in the dependency graph.
+type StackConfig struct {
+	DependsOn    []string `yaml:"depends_on"`
+	Experimental bool     `yaml:"experimental,omitempty"`
+}
+
+// Registry maps stack name to its configuration.
+type Registry map[string]StackConfig
+
+// Load parses the stack dependency graph at path. A missing file yields
+// an empty Registry, since not every environment has stacks registered
+// yet.
+func Load(path string) (Registry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Registry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("stackregistry: read %s: %w", path, err)
+	}
+
+	var reg Registry
+	if err := yaml.Unmarshal(data, &reg); err != nil {
+		return nil, fmt.Errorf("stackregistry: parse %s: %w", path, err)
+	}
+	if reg == nil {
+		reg = Registry{}
+	}
+	return reg, nil
+}
+
+// Experimental reports whether name is marked experimental. An unknown
+// stack is treated as non-experimental.
+func (r Registry) Experimental(name string) bool {
+	return r[name].Experimental
+}
+
+// PlanOnlyStacks returns experimental stack names in sorted order.
+func (r Registry) PlanOnlyStacks() []string {
+	var names []string
+	for name, cfg := range r {
+		if cfg.Experimental {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GatingStacks returns non-experimental stack names in sorted order;
+// these are the only stacks whose results can fail a merge.
+func (r Registry) GatingStacks() []string {
+	var names []string
+	for name, cfg := range r {
+		if !cfg.Experimental {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
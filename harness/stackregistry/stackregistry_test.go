@@ -0,0 +1,46 @@
+package stackregistry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeGraph(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "stacks_graph.yml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+	return path
+}
+
+func TestLoadReturnsEmptyRegistryForMissingFile(t *testing.T) {
+	reg, err := Load(filepath.Join(t.TempDir(), "missing.yml"))
+	require.NoError(t, err)
+	assert.Empty(t, reg)
+}
+
+func TestLoadParsesExperimentalFlag(t *testing.T) {
+	path := writeGraph(t, "core:\n  depends_on: []\naks-cluster:\n  depends_on:\n    - core\nmesh:\n  depends_on:\n    - aks-cluster\n  experimental: true\n")
+	reg, err := Load(path)
+	require.NoError(t, err)
+
+	assert.False(t, reg.Experimental("core"))
+	assert.True(t, reg.Experimental("mesh"))
+}
+
+func TestPlanOnlyAndGatingStacksAreSortedAndDisjoint(t *testing.T) {
+	path := writeGraph(t, "core:\n  depends_on: []\nmonitoring:\n  depends_on: []\n  experimental: true\nbackup:\n  depends_on: []\n  experimental: true\n")
+	reg, err := Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"backup", "monitoring"}, reg.PlanOnlyStacks())
+	assert.Equal(t, []string{"core"}, reg.GatingStacks())
+}
+
+func TestExperimentalIsFalseForUnknownStack(t *testing.T) {
+	reg := Registry{}
+	assert.False(t, reg.Experimental("nonexistent"))
+}
@@ -0,0 +1,102 @@
+// Package statesecrets scans a stack's Terraform state for attribute
+// values that look like plaintext secrets — connection strings,
+// passwords, keys — stored directly in state instead of generated
+// out-of-band or marked sensitive and pulled from Key Vault. Terraform
+// encrypts state at rest in this repo's backend, but state is still
+// readable by anyone with plan/apply access to the stack, and a raw
+// secret in state survives long after the resource that produced it is
+// gone (old plan/state archives, local caches). Finding these here
+// pushes module authors toward ephemeral values and external secret
+// generation instead.
+package statesecrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// rawState mirrors the parts of `terraform show -json <state>` this
+// package reads.
+type rawState struct {
+	Values struct {
+		RootModule rawModule `json:"root_module"`
+	} `json:"values"`
+}
+
+type rawModule struct {
+	Resources    []rawResource `json:"resources"`
+	ChildModules []rawModule   `json:"child_modules"`
+}
+
+type rawResource struct {
+	Address string                     `json:"address"`
+	Type    string                     `json:"type"`
+	Name    string                     `json:"name"`
+	Values  map[string]json.RawMessage `json:"values"`
+}
+
+// Resource is one resource instance's attribute values, as recorded in
+// state.
+type Resource struct {
+	Address string
+	Type    string
+	Name    string
+	Values  map[string]json.RawMessage
+}
+
+// Parse parses `terraform show -json` output for a state file.
+func Parse(data []byte) ([]Resource, error) {
+	var state rawState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("statesecrets: parse state JSON: %w", err)
+	}
+
+	var resources []Resource
+	var walk func(m rawModule)
+	walk = func(m rawModule) {
+		for _, r := range m.Resources {
+			resources = append(resources, Resource{Address: r.Address, Type: r.Type, Name: r.Name, Values: r.Values})
+		}
+		for _, child := range m.ChildModules {
+			walk(child)
+		}
+	}
+	walk(state.Values.RootModule)
+	return resources, nil
+}
+
+// suspiciousKey matches attribute names that commonly hold secrets.
+var suspiciousKey = regexp.MustCompile(`(?i)(password|secret|connection_string|primary_key|secondary_key|access_key|client_secret|private_key)`)
+
+// Finding is a single attribute whose name and non-empty value suggest a
+// plaintext secret landed in state.
+type Finding struct {
+	Address string
+	Attr    string
+}
+
+// Scan reports every attribute across resources whose name matches a
+// known secret-like pattern and whose value is a non-empty string,
+// skipping the boolean/enum-ish "generate_secret"-style attributes that
+// merely reference a secret rather than holding one.
+func Scan(resources []Resource) []Finding {
+	var findings []Finding
+	for _, r := range resources {
+		for attr, raw := range r.Values {
+			if !suspiciousKey.MatchString(attr) {
+				continue
+			}
+			var value string
+			if err := json.Unmarshal(raw, &value); err != nil {
+				continue // not a string attribute; booleans/enums aren't the concern here
+			}
+			if strings.TrimSpace(value) == "" {
+				continue
+			}
+			findings = append(findings, Finding{Address: r.Address, Attr: attr})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,78 @@
+package statesecrets
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rawString(s string) json.RawMessage {
+	b, _ := json.Marshal(s)
+	return b
+}
+
+const stateFixture = `{
+  "values": {
+    "root_module": {
+      "resources": [
+        {
+          "address": "azurerm_storage_account.aks",
+          "type": "azurerm_storage_account",
+          "name": "aks",
+          "values": {
+            "name": "devaksstore",
+            "primary_access_key": "abcd1234efgh5678"
+          }
+        }
+      ],
+      "child_modules": [
+        {
+          "resources": [
+            {
+              "address": "module.db.azurerm_sql_server.this",
+              "type": "azurerm_sql_server",
+              "name": "this",
+              "values": {
+                "administrator_login_password": "S3cr3tPassw0rd",
+                "fully_qualified_domain_name": "devaks.database.windows.net"
+              }
+            }
+          ]
+        }
+      ]
+    }
+  }
+}`
+
+func TestParseWalksChildModules(t *testing.T) {
+	resources, err := Parse([]byte(stateFixture))
+	require.NoError(t, err)
+	assert.Len(t, resources, 2)
+}
+
+func TestScanFlagsSuspiciousAttributes(t *testing.T) {
+	resources, err := Parse([]byte(stateFixture))
+	require.NoError(t, err)
+
+	findings := Scan(resources)
+	require.Len(t, findings, 2)
+
+	var addresses []string
+	for _, f := range findings {
+		addresses = append(addresses, f.Address)
+	}
+	assert.Contains(t, addresses, "azurerm_storage_account.aks")
+	assert.Contains(t, addresses, "module.db.azurerm_sql_server.this")
+}
+
+func TestScanIgnoresNonSuspiciousAttributes(t *testing.T) {
+	resources := []Resource{{Address: "a", Values: map[string]json.RawMessage{"name": rawString("devaks")}}}
+	assert.Empty(t, Scan(resources))
+}
+
+func TestScanIgnoresEmptySecretValues(t *testing.T) {
+	resources := []Resource{{Address: "a", Values: map[string]json.RawMessage{"password": rawString("")}}}
+	assert.Empty(t, Scan(resources))
+}
@@ -0,0 +1,109 @@
+// Package statetrend tracks Terraform state file size and resource count
+// per stack across runs, flagging unusual growth (e.g. a for_each
+// explosion) before the state becomes unmanageable.
+package statetrend
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Snapshot is a single observation of a stack's state, ordered oldest-first
+// when passed to DetectAnomalies.
+type Snapshot struct {
+	Commit        string
+	StateBytes    int64
+	ResourceCount int
+}
+
+// Thresholds gates how much growth between two consecutive snapshots is
+// considered normal.
+type Thresholds struct {
+	// MaxBytesGrowthRatio is the fraction of growth in state size allowed
+	// between snapshots, e.g. 0.5 flags anything over a 50% increase.
+	MaxBytesGrowthRatio float64
+	// MaxResourceCountGrowth is the absolute increase in resource count
+	// allowed between snapshots.
+	MaxResourceCountGrowth int
+}
+
+// Finding is a detected anomaly at a given commit relative to the prior
+// snapshot.
+type Finding struct {
+	Commit  string
+	Message string
+}
+
+// DetectAnomalies compares each snapshot in history against the one before
+// it and returns a Finding for every growth that exceeds thresholds.
+func DetectAnomalies(history []Snapshot, thresholds Thresholds) []Finding {
+	var findings []Finding
+	for i := 1; i < len(history); i++ {
+		prev, cur := history[i-1], history[i]
+
+		if prev.StateBytes > 0 {
+			growth := float64(cur.StateBytes-prev.StateBytes) / float64(prev.StateBytes)
+			if growth > thresholds.MaxBytesGrowthRatio {
+				findings = append(findings, Finding{
+					Commit:  cur.Commit,
+					Message: fmt.Sprintf("state size grew %.0f%% (%d -> %d bytes)", growth*100, prev.StateBytes, cur.StateBytes),
+				})
+			}
+		}
+
+		if resourceGrowth := cur.ResourceCount - prev.ResourceCount; resourceGrowth > thresholds.MaxResourceCountGrowth {
+			findings = append(findings, Finding{
+				Commit:  cur.Commit,
+				Message: fmt.Sprintf("resource count jumped by %d (%d -> %d), possible for_each explosion", resourceGrowth, prev.ResourceCount, cur.ResourceCount),
+			})
+		}
+	}
+	return findings
+}
+
+// Gate returns an error describing every anomaly DetectAnomalies finds, or
+// nil if history shows no unusual growth. Intended to be wired directly
+// into a Go test as a threshold gate.
+func Gate(history []Snapshot, thresholds Thresholds) error {
+	findings := DetectAnomalies(history, thresholds)
+	if len(findings) == 0 {
+		return nil
+	}
+	lines := make([]string, len(findings))
+	for i, f := range findings {
+		lines[i] = fmt.Sprintf("%s: %s", f.Commit, f.Message)
+	}
+	return fmt.Errorf("statetrend: %d anomaly(ies) detected:\n%s", len(findings), strings.Join(lines, "\n"))
+}
+
+// rawState mirrors the subset of `terraform show -json <statefile>` output
+// this package needs to count resources.
+type rawState struct {
+	Values struct {
+		RootModule rawModule `json:"root_module"`
+	} `json:"values"`
+}
+
+type rawModule struct {
+	Resources    []json.RawMessage `json:"resources"`
+	ChildModules []rawModule       `json:"child_modules"`
+}
+
+// CountResources returns the total resource count in a `terraform show
+// -json <statefile>` document, including resources in nested modules.
+func CountResources(stateJSON []byte) (int, error) {
+	var state rawState
+	if err := json.Unmarshal(stateJSON, &state); err != nil {
+		return 0, fmt.Errorf("statetrend: parse state JSON: %w", err)
+	}
+	return countModuleResources(state.Values.RootModule), nil
+}
+
+func countModuleResources(m rawModule) int {
+	count := len(m.Resources)
+	for _, child := range m.ChildModules {
+		count += countModuleResources(child)
+	}
+	return count
+}
@@ -0,0 +1,63 @@
+package statetrend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectAnomaliesFlagsStateSizeSpike(t *testing.T) {
+	history := []Snapshot{
+		{Commit: "c1", StateBytes: 1000, ResourceCount: 20},
+		{Commit: "c2", StateBytes: 2000, ResourceCount: 21},
+	}
+	findings := DetectAnomalies(history, Thresholds{MaxBytesGrowthRatio: 0.5, MaxResourceCountGrowth: 5})
+	require.Len(t, findings, 1)
+	assert.Equal(t, "c2", findings[0].Commit)
+	assert.Contains(t, findings[0].Message, "state size grew")
+}
+
+func TestDetectAnomaliesFlagsResourceCountExplosion(t *testing.T) {
+	history := []Snapshot{
+		{Commit: "c1", StateBytes: 1000, ResourceCount: 20},
+		{Commit: "c2", StateBytes: 1050, ResourceCount: 220},
+	}
+	findings := DetectAnomalies(history, Thresholds{MaxBytesGrowthRatio: 0.5, MaxResourceCountGrowth: 10})
+	require.Len(t, findings, 1)
+	assert.Contains(t, findings[0].Message, "for_each explosion")
+}
+
+func TestDetectAnomaliesEmptyForSteadyGrowth(t *testing.T) {
+	history := []Snapshot{
+		{Commit: "c1", StateBytes: 1000, ResourceCount: 20},
+		{Commit: "c2", StateBytes: 1050, ResourceCount: 21},
+	}
+	assert.Empty(t, DetectAnomalies(history, Thresholds{MaxBytesGrowthRatio: 0.5, MaxResourceCountGrowth: 10}))
+}
+
+func TestGateReturnsErrorSummarizingFindings(t *testing.T) {
+	history := []Snapshot{
+		{Commit: "c1", StateBytes: 1000, ResourceCount: 20},
+		{Commit: "c2", StateBytes: 5000, ResourceCount: 20},
+	}
+	err := Gate(history, Thresholds{MaxBytesGrowthRatio: 0.5, MaxResourceCountGrowth: 10})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "c2")
+}
+
+func TestCountResourcesIncludesChildModules(t *testing.T) {
+	stateJSON := []byte(`{
+      "values": {
+        "root_module": {
+          "resources": [{}, {}],
+          "child_modules": [
+            {"resources": [{}], "child_modules": []}
+          ]
+        }
+      }
+    }`)
+	count, err := CountResources(stateJSON)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
@@ -0,0 +1,87 @@
+// Package subnetassert verifies that each subnet has exactly the
+// delegations and service endpoints the network design calls for — no
+// more, no less. An unexpected delegation or service endpoint is treated
+// as a failure the same as a missing one: a delegation nobody asked for
+// (e.g. left over from a since-abandoned ANF trial) can silently change
+// which resources are allowed to use a subnet just as much as a missing
+// one can.
+package subnetassert
+
+import "fmt"
+
+// Expected is the delegations and service endpoints the design calls for
+// on one subnet.
+type Expected struct {
+	Name             string
+	Delegations      []string
+	ServiceEndpoints []string
+}
+
+// Actual is what's actually configured on a subnet.
+type Actual struct {
+	Name             string
+	Delegations      []string
+	ServiceEndpoints []string
+}
+
+// Violation is a single subnet delegation or service endpoint mismatch.
+type Violation struct {
+	Message string
+}
+
+// Verify compares actual against expected for a single subnet, returning
+// every mismatch.
+func Verify(expected Expected, actual Actual) []Violation {
+	var violations []Violation
+	violations = append(violations, diffSets(expected.Name, "delegation", toSet(actual.Delegations), toSet(expected.Delegations))...)
+	violations = append(violations, diffSets(expected.Name, "service endpoint", toSet(actual.ServiceEndpoints), toSet(expected.ServiceEndpoints))...)
+	return violations
+}
+
+// VerifyAll compares a set of expected subnet configs against the actual
+// subnets found, matching by name. A subnet named in expected but missing
+// from actual is a violation; a subnet found in actual but not named in
+// expected is left alone, since actual may legitimately contain subnets
+// (e.g. AzureBastionSubnet) this design doesn't otherwise constrain.
+func VerifyAll(expected []Expected, actual []Actual) []Violation {
+	byName := make(map[string]Actual, len(actual))
+	for _, a := range actual {
+		byName[a.Name] = a
+	}
+
+	var violations []Violation
+	for _, exp := range expected {
+		act, ok := byName[exp.Name]
+		if !ok {
+			violations = append(violations, Violation{Message: fmt.Sprintf("subnet %s is expected but was not found", exp.Name)})
+			continue
+		}
+		violations = append(violations, Verify(exp, act)...)
+	}
+	return violations
+}
+
+func diffSets(subnet, kind string, got, want map[string]bool) []Violation {
+	var violations []Violation
+	for v := range got {
+		if !want[v] {
+			violations = append(violations, Violation{Message: fmt.Sprintf(
+				"subnet %s has unexpected %s %q", subnet, kind, v)})
+		}
+	}
+	for v := range want {
+		if !got[v] {
+			violations = append(violations, Violation{Message: fmt.Sprintf(
+				"subnet %s is missing expected %s %q", subnet, kind, v)})
+		}
+	}
+	return violations
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,67 @@
+package subnetassert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyPassesWhenDelegationsAndEndpointsMatchExactly(t *testing.T) {
+	expected := Expected{Name: "snet-anf", Delegations: []string{"Microsoft.NetApp/volumes"}}
+	actual := Actual{Name: "snet-anf", Delegations: []string{"Microsoft.NetApp/volumes"}}
+
+	assert.Empty(t, Verify(expected, actual))
+}
+
+func TestVerifyFlagsUnexpectedDelegation(t *testing.T) {
+	expected := Expected{Name: "snet-aks"}
+	actual := Actual{Name: "snet-aks", Delegations: []string{"Microsoft.NetApp/volumes"}}
+
+	violations := Verify(expected, actual)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "unexpected delegation")
+}
+
+func TestVerifyFlagsMissingDelegation(t *testing.T) {
+	expected := Expected{Name: "snet-anf", Delegations: []string{"Microsoft.NetApp/volumes"}}
+	actual := Actual{Name: "snet-anf"}
+
+	violations := Verify(expected, actual)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "missing expected delegation")
+}
+
+func TestVerifyFlagsUnexpectedServiceEndpointWhenPrivateEndpointExpected(t *testing.T) {
+	expected := Expected{Name: "snet-data"}
+	actual := Actual{Name: "snet-data", ServiceEndpoints: []string{"Microsoft.KeyVault"}}
+
+	violations := Verify(expected, actual)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "unexpected service endpoint")
+}
+
+func TestVerifyFlagsMissingExpectedServiceEndpoint(t *testing.T) {
+	expected := Expected{Name: "snet-data", ServiceEndpoints: []string{"Microsoft.KeyVault"}}
+	actual := Actual{Name: "snet-data"}
+
+	violations := Verify(expected, actual)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "missing expected service endpoint")
+}
+
+func TestVerifyAllFlagsMissingSubnet(t *testing.T) {
+	expected := []Expected{{Name: "snet-aks"}, {Name: "snet-anf", Delegations: []string{"Microsoft.NetApp/volumes"}}}
+	actual := []Actual{{Name: "snet-aks"}}
+
+	violations := VerifyAll(expected, actual)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "snet-anf is expected but was not found")
+}
+
+func TestVerifyAllIgnoresActualSubnetsNotInExpectedSet(t *testing.T) {
+	expected := []Expected{{Name: "snet-aks"}}
+	actual := []Actual{{Name: "snet-aks"}, {Name: "AzureBastionSubnet"}}
+
+	assert.Empty(t, VerifyAll(expected, actual))
+}
@@ -0,0 +1,159 @@
+// Package subnetlayering statically cross-checks a stack's network config
+// against its firewall/NSG rule specs: every subnet CIDR referenced in a
+// rule's source or destination fields must actually exist in the network
+// config, and every subnet in the network config that rules depend on must
+// stay listed there. This is the classic "changed the subnet, forgot the
+// firewall rule" drift, and it's cheap to catch by parsing both sides
+// rather than waiting for a live plan to surface it.
+//
+// This repo's checked-in Terraspace scaffold doesn't yet have per-stack
+// `.tf`/`.tfvars` files for network config or firewall/NSG rules — only
+// the global config/terraform/{backend,provider}.tf. ParseNetworkConfig
+// and ParseRuleSpec below parse the HCL shape those files are expected to
+// take once a stack defines them, following the same hclparse/hclsyntax
+// approach as harness/lockfileconsistency, so VerifyLayering is ready to
+// run the moment that Terraform is added.
+package subnetlayering
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// Subnet is one `subnet "name" { cidr = "..." }` block from a network
+// config file.
+type Subnet struct {
+	Name string
+	CIDR string
+}
+
+// NetworkConfig is every subnet declared in one stack's network config.
+type NetworkConfig struct {
+	Stack   string
+	Subnets []Subnet
+}
+
+// ParseNetworkConfig parses a network config file's `subnet` blocks.
+func ParseNetworkConfig(stack string, data []byte) (NetworkConfig, error) {
+	body, err := parseBody(data, stack+"/network.tf")
+	if err != nil {
+		return NetworkConfig{}, fmt.Errorf("subnetlayering: parse %s network config: %w", stack, err)
+	}
+
+	cfg := NetworkConfig{Stack: stack}
+	for _, block := range body.Blocks {
+		if block.Type != "subnet" || len(block.Labels) != 1 {
+			continue
+		}
+		s := Subnet{Name: block.Labels[0]}
+		if attr, ok := block.Body.Attributes["cidr"]; ok {
+			s.CIDR = evalString(attr)
+		}
+		cfg.Subnets = append(cfg.Subnets, s)
+	}
+	return cfg, nil
+}
+
+// Rule is one `rule "name" { source = "..." destination = "..." }` block
+// from a firewall/NSG rule spec file. Source and Destination hold CIDRs,
+// as opposed to service tags or "*", which VerifyLayering ignores.
+type Rule struct {
+	Name        string
+	Source      string
+	Destination string
+}
+
+// RuleSpec is every rule declared in one stack's firewall/NSG rule file.
+type RuleSpec struct {
+	Stack string
+	Rules []Rule
+}
+
+// ParseRuleSpec parses a rule spec file's `rule` blocks.
+func ParseRuleSpec(stack string, data []byte) (RuleSpec, error) {
+	body, err := parseBody(data, stack+"/rules.tf")
+	if err != nil {
+		return RuleSpec{}, fmt.Errorf("subnetlayering: parse %s rule spec: %w", stack, err)
+	}
+
+	spec := RuleSpec{Stack: stack}
+	for _, block := range body.Blocks {
+		if block.Type != "rule" || len(block.Labels) != 1 {
+			continue
+		}
+		r := Rule{Name: block.Labels[0]}
+		if attr, ok := block.Body.Attributes["source"]; ok {
+			r.Source = evalString(attr)
+		}
+		if attr, ok := block.Body.Attributes["destination"]; ok {
+			r.Destination = evalString(attr)
+		}
+		spec.Rules = append(spec.Rules, r)
+	}
+	return spec, nil
+}
+
+// Violation is a single rule referencing a CIDR that no subnet in the
+// network config declares.
+type Violation struct {
+	Message string
+}
+
+// VerifyLayering checks that every CIDR a rule uses as its source or
+// destination matches a subnet CIDR in cfg. Rules using non-CIDR values
+// ("*", service tags, other rules' names) are ignored — this only catches
+// drift between subnet CIDRs and rules that reference them directly.
+func VerifyLayering(cfg NetworkConfig, spec RuleSpec) []Violation {
+	known := make(map[string]bool, len(cfg.Subnets))
+	for _, s := range cfg.Subnets {
+		known[s.CIDR] = true
+	}
+
+	var violations []Violation
+	for _, r := range spec.Rules {
+		if isCIDR(r.Source) && !known[r.Source] {
+			violations = append(violations, Violation{Message: fmt.Sprintf(
+				"rule %q in stack %s references source CIDR %s, which no subnet in the network config declares", r.Name, spec.Stack, r.Source)})
+		}
+		if isCIDR(r.Destination) && !known[r.Destination] {
+			violations = append(violations, Violation{Message: fmt.Sprintf(
+				"rule %q in stack %s references destination CIDR %s, which no subnet in the network config declares", r.Name, spec.Stack, r.Destination)})
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool { return violations[i].Message < violations[j].Message })
+	return violations
+}
+
+// isCIDR reports whether v is parseable as a CIDR block, as opposed to a
+// service tag, "*", or another rule's name.
+func isCIDR(v string) bool {
+	if v == "" {
+		return false
+	}
+	_, _, err := net.ParseCIDR(v)
+	return err == nil
+}
+
+func parseBody(data []byte, filename string) (*hclsyntax.Body, error) {
+	file, diags := hclparse.NewParser().ParseHCL(data, filename)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("%s", diags.Error())
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("unexpected body type for %s", filename)
+	}
+	return body, nil
+}
+
+func evalString(attr *hclsyntax.Attribute) string {
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || val.IsNull() {
+		return ""
+	}
+	return val.AsString()
+}
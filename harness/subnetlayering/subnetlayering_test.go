@@ -0,0 +1,90 @@
+package subnetlayering
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const networkFixture = `
+subnet "aks" {
+  cidr = "10.0.1.0/24"
+}
+
+subnet "appgw" {
+  cidr = "10.0.2.0/24"
+}
+`
+
+const rulesFixtureInSync = `
+rule "allow-aks-egress" {
+  source      = "10.0.1.0/24"
+  destination = "10.0.2.0/24"
+}
+`
+
+const rulesFixtureDrifted = `
+rule "allow-aks-egress" {
+  source      = "10.0.1.0/24"
+  destination = "10.0.3.0/24"
+}
+`
+
+func TestParseNetworkConfigExtractsSubnets(t *testing.T) {
+	cfg, err := ParseNetworkConfig("aks", []byte(networkFixture))
+	require.NoError(t, err)
+	assert.Equal(t, []Subnet{
+		{Name: "aks", CIDR: "10.0.1.0/24"},
+		{Name: "appgw", CIDR: "10.0.2.0/24"},
+	}, cfg.Subnets)
+}
+
+func TestParseRuleSpecExtractsRules(t *testing.T) {
+	spec, err := ParseRuleSpec("aks", []byte(rulesFixtureInSync))
+	require.NoError(t, err)
+	require.Len(t, spec.Rules, 1)
+	assert.Equal(t, Rule{Name: "allow-aks-egress", Source: "10.0.1.0/24", Destination: "10.0.2.0/24"}, spec.Rules[0])
+}
+
+func TestParseNetworkConfigRejectsInvalidHCL(t *testing.T) {
+	_, err := ParseNetworkConfig("aks", []byte("subnet \"aks\" {"))
+	assert.Error(t, err)
+}
+
+func TestVerifyLayeringPassesWhenAllCIDRsKnown(t *testing.T) {
+	cfg, err := ParseNetworkConfig("aks", []byte(networkFixture))
+	require.NoError(t, err)
+	spec, err := ParseRuleSpec("aks", []byte(rulesFixtureInSync))
+	require.NoError(t, err)
+
+	assert.Empty(t, VerifyLayering(cfg, spec))
+}
+
+func TestVerifyLayeringFlagsDriftedDestinationCIDR(t *testing.T) {
+	cfg, err := ParseNetworkConfig("aks", []byte(networkFixture))
+	require.NoError(t, err)
+	spec, err := ParseRuleSpec("aks", []byte(rulesFixtureDrifted))
+	require.NoError(t, err)
+
+	violations := VerifyLayering(cfg, spec)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "10.0.3.0/24")
+}
+
+func TestVerifyLayeringIgnoresNonCIDRRuleValues(t *testing.T) {
+	cfg := NetworkConfig{Subnets: []Subnet{{Name: "aks", CIDR: "10.0.1.0/24"}}}
+	spec := RuleSpec{Rules: []Rule{{Name: "allow-internet", Source: "10.0.1.0/24", Destination: "*"}}}
+
+	assert.Empty(t, VerifyLayering(cfg, spec))
+}
+
+func TestVerifyLayeringFlagsUnknownSourceCIDR(t *testing.T) {
+	cfg := NetworkConfig{Subnets: []Subnet{{Name: "aks", CIDR: "10.0.1.0/24"}}}
+	spec := RuleSpec{Stack: "aks", Rules: []Rule{{Name: "stale-rule", Source: "10.0.9.0/24", Destination: "10.0.1.0/24"}}}
+
+	violations := VerifyLayering(cfg, spec)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].Message, "stale-rule")
+	assert.Contains(t, violations[0].Message, "10.0.9.0/24")
+}
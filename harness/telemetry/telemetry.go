@@ -0,0 +1,129 @@
+// Package telemetry instruments the harness itself: a span per stage
+// (init/plan/apply/verify/destroy) and counters for SDK calls and retries.
+// Spans are exported over OTLP/HTTP to Application Insights so pipeline
+// performance and flakiness can be analyzed with the same tooling used for
+// the platform; the counters are recorded on a meter provider that has no
+// exporter attached, so they are not sent anywhere.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Stage identifies a phase of a single harness run.
+type Stage string
+
+const (
+	StageInit    Stage = "init"
+	StagePlan    Stage = "plan"
+	StageApply   Stage = "apply"
+	StageVerify  Stage = "verify"
+	StageDestroy Stage = "destroy"
+)
+
+// Harness wraps the OTel tracer and meter the rest of the harness uses to
+// record its own execution.
+type Harness struct {
+	tp *sdktrace.TracerProvider
+	mp *sdkmetric.MeterProvider
+
+	tracer     trace.Tracer
+	sdkCalls   metric.Int64Counter
+	sdkRetries metric.Int64Counter
+}
+
+// New configures an OTLP/HTTP trace exporter pointed at endpoint (the
+// Application Insights OTLP ingestion endpoint) and returns a Harness ready
+// to record stage spans and SDK call counters. Only spans go to endpoint;
+// the counters have no exporter. Call Shutdown when the run completes to
+// flush pending spans.
+func New(ctx context.Context, endpoint string) (*Harness, error) {
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName("private-aks-tf-cluster-harness"),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: build resource: %w", err)
+	}
+
+	traceExp, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpoint(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: build trace exporter: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(traceExp),
+		sdktrace.WithResource(res),
+	)
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithResource(res))
+
+	meter := mp.Meter("harness")
+	sdkCalls, err := meter.Int64Counter("harness.sdk_calls")
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: create sdk_calls counter: %w", err)
+	}
+	sdkRetries, err := meter.Int64Counter("harness.sdk_retries")
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: create sdk_retries counter: %w", err)
+	}
+
+	return &Harness{
+		tp:         tp,
+		mp:         mp,
+		tracer:     tp.Tracer("harness"),
+		sdkCalls:   sdkCalls,
+		sdkRetries: sdkRetries,
+	}, nil
+}
+
+// StartStage opens a span for stage, scoped to a single run identified by
+// runID.
+func (h *Harness) StartStage(ctx context.Context, runID string, stage Stage) (context.Context, trace.Span) {
+	return h.tracer.Start(ctx, string(stage), trace.WithAttributes(
+		attribute.String("harness.run_id", runID),
+		attribute.String("harness.stage", string(stage)),
+	))
+}
+
+// RecordSDKCall increments the SDK call counter, tagged by the resource
+// provider the call targeted.
+func (h *Harness) RecordSDKCall(ctx context.Context, provider string) {
+	h.sdkCalls.Add(ctx, 1, metric.WithAttributes(attribute.String("provider", provider)))
+}
+
+// RecordSDKRetry increments the SDK retry counter, tagged by provider.
+func (h *Harness) RecordSDKRetry(ctx context.Context, provider string) {
+	h.sdkRetries.Add(ctx, 1, metric.WithAttributes(attribute.String("provider", provider)))
+}
+
+// Shutdown flushes and stops both the tracer and meter providers,
+// unconditionally attempting both even if one fails, so a stuck trace
+// exporter doesn't leak the meter provider or drop buffered metrics.
+func (h *Harness) Shutdown(ctx context.Context) error {
+	tpErr := h.tp.Shutdown(ctx)
+	if tpErr != nil {
+		tpErr = fmt.Errorf("telemetry: shutdown tracer provider: %w", tpErr)
+	}
+	mpErr := h.mp.Shutdown(ctx)
+	if mpErr != nil {
+		mpErr = fmt.Errorf("telemetry: shutdown meter provider: %w", mpErr)
+	}
+	return errors.Join(tpErr, mpErr)
+}
+
+// SetGlobal installs h's providers as the process-wide OTel default, so
+// libraries instrumented against the global otel API are captured too.
+func SetGlobal(h *Harness) {
+	otel.SetTracerProvider(h.tp)
+	otel.SetMeterProvider(h.mp)
+}
@@ -0,0 +1,36 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfiguresTracerAndCounters(t *testing.T) {
+	h, err := New(context.Background(), "127.0.0.1:0")
+	require.NoError(t, err)
+	defer h.Shutdown(context.Background())
+
+	ctx, span := h.StartStage(context.Background(), "run-123", StageApply)
+	span.End()
+
+	h.RecordSDKCall(ctx, "containerservice")
+	h.RecordSDKRetry(ctx, "containerservice")
+}
+
+func TestShutdownStopsMeterProviderEvenAfterTracerProviderIsAlreadyShutdown(t *testing.T) {
+	h, err := New(context.Background(), "127.0.0.1:0")
+	require.NoError(t, err)
+
+	require.NoError(t, h.Shutdown(context.Background()))
+
+	// The tracer provider tolerates a second Shutdown call, but the meter
+	// provider doesn't: a repeat Shutdown must still reach it rather than
+	// return early once the tracer provider is already down, otherwise
+	// this error would never surface.
+	err = h.Shutdown(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "meter provider")
+}
@@ -0,0 +1,104 @@
+// Package tempmodule copies the Terraform config a test needs into an
+// isolated temp directory, so a test that needs a provider/backend
+// override file never writes one into the repo's own config/terraform/
+// tree. Writing and then deleting a file in-place there races with any
+// other test running in parallel and can leave the working tree dirty if
+// the test fails before its cleanup runs.
+//
+// ForRunner extends the same idea to a whole runner.Runner invocation:
+// point Runner.Dir at its returned directory so parallel stack/module
+// tests never share a .terraform dir, a lock file, or a generated file.
+//
+// Note: this repo has no app/modules/core/core_test.go and nothing here
+// writes provider.tf into config/terraform/ during tests today - grep of
+// harness/ and cmd/ turns up no such write. This package exists so that
+// if a future test needs a provider/backend override, the isolated-copy
+// path is the one that's available, not the in-place one.
+package tempmodule
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// CopyToTemp copies each of paths (relative to root) into a fresh temp
+// directory mirroring root's layout, and returns that directory along
+// with a cleanup function that removes it. Callers write any
+// test-specific override files into the returned directory, never into
+// root.
+func CopyToTemp(root string, paths []string) (dir string, cleanup func() error, err error) {
+	dir, err = os.MkdirTemp("", "tempmodule-")
+	if err != nil {
+		return "", nil, fmt.Errorf("tempmodule: create temp dir: %w", err)
+	}
+	cleanup = func() error { return os.RemoveAll(dir) }
+
+	for _, p := range paths {
+		src := filepath.Join(root, p)
+		dst := filepath.Join(dir, p)
+		if err := copyTree(src, dst); err != nil {
+			cleanup()
+			return "", nil, fmt.Errorf("tempmodule: copy %s: %w", p, err)
+		}
+	}
+	return dir, cleanup, nil
+}
+
+// runnerRoots are the paths every Terraspace invocation needs: the
+// Ruby/Terraspace project files plus the full config tree (stacks,
+// envs, terraform/, stacks_graph.yml).
+var runnerRoots = []string{"Gemfile", "Terrafile", "config"}
+
+// ForRunner copies everything a runner.Runner needs to drive Terraspace
+// into a fresh temp directory and returns it for use as Runner.Dir, so
+// parallel stack/module tests each get their own .terraform dirs, lock
+// files, and generated files instead of sharing root's.
+func ForRunner(root string) (dir string, cleanup func() error, err error) {
+	return CopyToTemp(root, runnerRoots)
+}
+
+func copyTree(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return copyFile(src, dst, info.Mode())
+	}
+
+	if err := os.MkdirAll(dst, 0o755); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := copyTree(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
@@ -0,0 +1,92 @@
+package tempmodule
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyToTempMirrorsFiles(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "config", "terraform"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "config", "terraform", "provider.tf"), []byte(`provider "azurerm" {}`+"\n"), 0o644))
+
+	dir, cleanup, err := CopyToTemp(root, []string{"config/terraform"})
+	require.NoError(t, err)
+	defer cleanup()
+
+	data, err := os.ReadFile(filepath.Join(dir, "config", "terraform", "provider.tf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "azurerm")
+}
+
+func TestCopyToTempWritesDoNotAffectRoot(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "config", "terraform"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "config", "terraform", "provider.tf"), []byte(`provider "azurerm" {}`+"\n"), 0o644))
+
+	dir, cleanup, err := CopyToTemp(root, []string{"config/terraform"})
+	require.NoError(t, err)
+	defer cleanup()
+
+	overridePath := filepath.Join(dir, "config", "terraform", "provider_override.tf")
+	require.NoError(t, os.WriteFile(overridePath, []byte(`provider "azurerm" { subscription_id = "test" }`+"\n"), 0o644))
+
+	_, err = os.Stat(filepath.Join(root, "config", "terraform", "provider_override.tf"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCleanupRemovesTempDir(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "config", "terraform"), 0o755))
+
+	dir, cleanup, err := CopyToTemp(root, []string{"config/terraform"})
+	require.NoError(t, err)
+	require.NoError(t, cleanup())
+
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCopyToTempErrorsForMissingPath(t *testing.T) {
+	root := t.TempDir()
+	_, _, err := CopyToTemp(root, []string{"does/not/exist"})
+	assert.Error(t, err)
+}
+
+func TestForRunnerCopiesTerraspaceProjectFiles(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "Gemfile"), []byte("gem \"terraspace\"\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "Terrafile"), []byte("{}\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "config", "envs", "dev"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "config", "envs", "dev", "core.tfvars"), []byte("region = \"eastus2\"\n"), 0o644))
+
+	dir, cleanup, err := ForRunner(root)
+	require.NoError(t, err)
+	defer cleanup()
+
+	for _, p := range []string{"Gemfile", "Terrafile", filepath.Join("config", "envs", "dev", "core.tfvars")} {
+		_, err := os.Stat(filepath.Join(dir, p))
+		assert.NoError(t, err, "expected %s to be copied", p)
+	}
+}
+
+func TestForRunnerCopiesAreIndependentAcrossCalls(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "Gemfile"), []byte("gem \"terraspace\"\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "Terrafile"), []byte("{}\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "config"), 0o755))
+
+	dirA, cleanupA, err := ForRunner(root)
+	require.NoError(t, err)
+	defer cleanupA()
+
+	dirB, cleanupB, err := ForRunner(root)
+	require.NoError(t, err)
+	defer cleanupB()
+
+	assert.NotEqual(t, dirA, dirB)
+}
@@ -0,0 +1,73 @@
+// Package testtier lets reviewers request heavier CI validation via a PR
+// label (e.g. "test:full-deploy") instead of editing workflow files. The
+// workflow copies the label into the TEST_TIER environment variable; this
+// package turns that into a Tier the test entrypoint can branch on.
+package testtier
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tier is how much validation a test run performs.
+type Tier string
+
+const (
+	// TierPlanOnly runs `terraform plan` and static checks only.
+	TierPlanOnly Tier = "plan-only"
+	// TierStandard additionally applies to a shared ephemeral stack and
+	// runs the fast verification suite. This is the default.
+	TierStandard Tier = "standard"
+	// TierFullDeploy provisions a dedicated stack per PR and runs the
+	// full verification and conformance suites against it.
+	TierFullDeploy Tier = "full-deploy"
+)
+
+// Default is the tier used when TEST_TIER is unset.
+const Default = TierStandard
+
+// EnvVar is the environment variable the CI workflow sets from the PR's
+// "test:*" label before invoking the test entrypoint.
+const EnvVar = "TEST_TIER"
+
+// LabelPrefix is stripped from the raw label value, so both "full-deploy"
+// and "test:full-deploy" resolve to TierFullDeploy.
+const LabelPrefix = "test:"
+
+var validTiers = map[Tier]bool{
+	TierPlanOnly:   true,
+	TierStandard:   true,
+	TierFullDeploy: true,
+}
+
+// Valid reports whether t is a recognized tier.
+func Valid(t Tier) bool {
+	return validTiers[t]
+}
+
+// FromEnv resolves the test tier from the environment, using getenv (pass
+// os.Getenv in production; tests supply a fake). Returns Default if the
+// variable is unset or empty, and an error if it's set to an unrecognized
+// value.
+func FromEnv(getenv func(string) string) (Tier, error) {
+	raw := getenv(EnvVar)
+	if raw == "" {
+		return Default, nil
+	}
+	tier := Tier(strings.TrimPrefix(raw, LabelPrefix))
+	if !Valid(tier) {
+		return "", fmt.Errorf("testtier: unrecognized %s value %q", EnvVar, raw)
+	}
+	return tier, nil
+}
+
+// IncludesDeploy reports whether t requires provisioning a dedicated stack.
+func (t Tier) IncludesDeploy() bool {
+	return t == TierFullDeploy
+}
+
+// IncludesConformance reports whether t requires running the full
+// conformance suite against a live cluster.
+func (t Tier) IncludesConformance() bool {
+	return t == TierFullDeploy
+}
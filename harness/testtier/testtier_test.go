@@ -0,0 +1,38 @@
+package testtier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeGetenv(values map[string]string) func(string) string {
+	return func(key string) string { return values[key] }
+}
+
+func TestFromEnvDefaultsWhenUnset(t *testing.T) {
+	tier, err := FromEnv(fakeGetenv(nil))
+	require.NoError(t, err)
+	assert.Equal(t, Default, tier)
+}
+
+func TestFromEnvStripsLabelPrefix(t *testing.T) {
+	tier, err := FromEnv(fakeGetenv(map[string]string{EnvVar: "test:full-deploy"}))
+	require.NoError(t, err)
+	assert.Equal(t, TierFullDeploy, tier)
+	assert.True(t, tier.IncludesDeploy())
+}
+
+func TestFromEnvAcceptsBareTierValue(t *testing.T) {
+	tier, err := FromEnv(fakeGetenv(map[string]string{EnvVar: "plan-only"}))
+	require.NoError(t, err)
+	assert.Equal(t, TierPlanOnly, tier)
+	assert.False(t, tier.IncludesDeploy())
+}
+
+func TestFromEnvRejectsUnknownTier(t *testing.T) {
+	_, err := FromEnv(fakeGetenv(map[string]string{EnvVar: "test:bogus"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bogus")
+}
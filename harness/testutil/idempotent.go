@@ -0,0 +1,33 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/planparser"
+)
+
+// Planner returns the pending plan for stack without applying it, e.g.
+// runner.Runner.Plan combined with `terraform show -json` and
+// planparser.Parse.
+type Planner interface {
+	Plan(stack string) (planparser.Plan, error)
+}
+
+// AssertIdempotent plans stack via planner and fails t if the plan
+// contains any resource changes. Callers run this immediately after a
+// successful `terraspace up` for stack, to catch module code that never
+// actually converges (e.g. an attribute recomputed on every apply).
+func AssertIdempotent(t *testing.T, planner Planner, stack string) {
+	t.Helper()
+	plan, err := planner.Plan(stack)
+	if err != nil {
+		t.Fatalf("testutil: plan %s: %v", stack, err)
+	}
+	if len(plan.ResourceChanges) == 0 {
+		return
+	}
+	t.Errorf("testutil: second plan for %s is not empty, found %d pending change(s):", stack, len(plan.ResourceChanges))
+	for _, c := range plan.ResourceChanges {
+		t.Errorf("  %s: %v", c.Address, c.Actions)
+	}
+}
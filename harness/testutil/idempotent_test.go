@@ -0,0 +1,45 @@
+package testutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/planparser"
+)
+
+type fakePlanner struct {
+	plan planparser.Plan
+	err  error
+}
+
+func (f fakePlanner) Plan(stack string) (planparser.Plan, error) {
+	return f.plan, f.err
+}
+
+func TestAssertIdempotentPassesOnEmptyPlan(t *testing.T) {
+	sub := &testing.T{}
+	AssertIdempotent(sub, fakePlanner{}, "core")
+	assert.False(t, sub.Failed())
+}
+
+func TestAssertIdempotentFailsOnPendingChanges(t *testing.T) {
+	planner := fakePlanner{plan: planparser.Plan{ResourceChanges: []planparser.ResourceChange{
+		{Address: "azurerm_kubernetes_cluster.this", Actions: []planparser.Action{planparser.ActionUpdate}},
+	}}}
+	sub := &testing.T{}
+	AssertIdempotent(sub, planner, "aks-cluster")
+	assert.True(t, sub.Failed())
+}
+
+func TestAssertIdempotentFailsOnPlanError(t *testing.T) {
+	sub := &testing.T{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		AssertIdempotent(sub, fakePlanner{err: errors.New("terraspace: no such stack")}, "core")
+	}()
+	<-done
+	assert.True(t, sub.Failed())
+}
@@ -0,0 +1,60 @@
+package testutil
+
+import "fmt"
+
+// Mode controls how much of a stack test actually touches Azure. Pull
+// request pipelines run plan-level assertions only, so they don't
+// provision real resources on every push; nightly runs do a full
+// apply/destroy cycle.
+type Mode string
+
+const (
+	// ModePlan runs `terraspace plan` and static checks only.
+	ModePlan Mode = "plan"
+	// ModeApply additionally applies the stack and runs its verification
+	// suite. This is the default.
+	ModeApply Mode = "apply"
+	// ModeDestroyOnly skips plan/apply and tears down a stack left over
+	// from a prior run, e.g. to clean up after an interrupted nightly job.
+	ModeDestroyOnly Mode = "destroy-only"
+)
+
+// ModeEnvVar is the environment variable CI sets to select the Mode: PR
+// pipelines set it to "plan", nightly pipelines leave it unset (or set it
+// to "apply") for a full run.
+const ModeEnvVar = "TEST_MODE"
+
+// DefaultMode is the Mode used when ModeEnvVar is unset.
+const DefaultMode = ModeApply
+
+var validModes = map[Mode]bool{
+	ModePlan:        true,
+	ModeApply:       true,
+	ModeDestroyOnly: true,
+}
+
+// FromEnv resolves the test mode from the environment, using getenv (pass
+// os.Getenv in production; tests supply a fake). Returns DefaultMode if
+// ModeEnvVar is unset or empty, and an error if it's set to an
+// unrecognized value.
+func FromEnv(getenv func(string) string) (Mode, error) {
+	raw := getenv(ModeEnvVar)
+	if raw == "" {
+		return DefaultMode, nil
+	}
+	mode := Mode(raw)
+	if !validModes[mode] {
+		return "", fmt.Errorf("testutil: unrecognized %s value %q", ModeEnvVar, raw)
+	}
+	return mode, nil
+}
+
+// IncludesApply reports whether m requires applying the stack.
+func (m Mode) IncludesApply() bool {
+	return m == ModeApply
+}
+
+// IncludesDestroy reports whether m requires tearing the stack down.
+func (m Mode) IncludesDestroy() bool {
+	return m == ModeApply || m == ModeDestroyOnly
+}
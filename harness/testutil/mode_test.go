@@ -0,0 +1,44 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeModeGetenv(values map[string]string) func(string) string {
+	return func(key string) string { return values[key] }
+}
+
+func TestFromEnvDefaultsWhenUnset(t *testing.T) {
+	mode, err := FromEnv(fakeModeGetenv(nil))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultMode, mode)
+}
+
+func TestFromEnvAcceptsPlanMode(t *testing.T) {
+	mode, err := FromEnv(fakeModeGetenv(map[string]string{ModeEnvVar: "plan"}))
+	require.NoError(t, err)
+	assert.Equal(t, ModePlan, mode)
+	assert.False(t, mode.IncludesApply())
+	assert.False(t, mode.IncludesDestroy())
+}
+
+func TestFromEnvAcceptsDestroyOnlyMode(t *testing.T) {
+	mode, err := FromEnv(fakeModeGetenv(map[string]string{ModeEnvVar: "destroy-only"}))
+	require.NoError(t, err)
+	assert.True(t, mode.IncludesDestroy())
+	assert.False(t, mode.IncludesApply())
+}
+
+func TestFromEnvRejectsUnknownMode(t *testing.T) {
+	_, err := FromEnv(fakeModeGetenv(map[string]string{ModeEnvVar: "bogus"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bogus")
+}
+
+func TestApplyModeIncludesApplyAndDestroy(t *testing.T) {
+	assert.True(t, ModeApply.IncludesApply())
+	assert.True(t, ModeApply.IncludesDestroy())
+}
@@ -0,0 +1,46 @@
+// Package testutil holds small helpers shared across this repo's stack
+// tests: UniquePrefix for parallel-safe resource naming, AssertIdempotent
+// for catching modules that never converge, and Mode/FromEnv for gating
+// how much of a test actually touches Azure. As of this snapshot no
+// app/stacks/*/*_test.go files exist yet to call them, but they're
+// written the way TestCoreModule and its AKS-stack counterpart would use
+// them once those land.
+package testutil
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+// basePrefix is the fixed part every generated prefix starts with, kept
+// short so the random suffix still fits Azure Container Registry (5-50
+// alphanumeric) and Key Vault (3-24 alphanumeric) naming rules once a
+// resource-type suffix like "acr" or "kv" is appended by the caller.
+const basePrefix = "devaks"
+
+// suffixLen is how many random lowercase-alphanumeric characters are
+// appended to basePrefix.
+const suffixLen = 6
+
+var suffixAlphabet = []rune("abcdefghijklmnopqrstuvwxyz0123456789")
+
+// UniquePrefix returns a resource-name prefix unique to this test run,
+// so parallel CI runs of the same stack test don't collide on resource
+// group, VNet, ACR or Key Vault names. It's lowercase alphanumeric only,
+// matching the strictest of those naming rules.
+func UniquePrefix(t *testing.T) string {
+	t.Helper()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	var b strings.Builder
+	for i := 0; i < suffixLen; i++ {
+		b.WriteRune(suffixAlphabet[r.Intn(len(suffixAlphabet))])
+	}
+	prefix := fmt.Sprintf("%s%s", basePrefix, b.String())
+	if len(prefix) > 24 {
+		t.Fatalf("testutil: generated prefix %q exceeds the 24-character Key Vault name limit", prefix)
+	}
+	return prefix
+}
@@ -0,0 +1,31 @@
+package testutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniquePrefixStartsWithBasePrefix(t *testing.T) {
+	prefix := UniquePrefix(t)
+	assert.True(t, strings.HasPrefix(prefix, basePrefix))
+}
+
+func TestUniquePrefixFitsKeyVaultNameLimit(t *testing.T) {
+	prefix := UniquePrefix(t)
+	assert.LessOrEqual(t, len(prefix), 24)
+}
+
+func TestUniquePrefixIsLowercaseAlphanumeric(t *testing.T) {
+	prefix := UniquePrefix(t)
+	for _, r := range prefix {
+		assert.True(t, (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'), "unexpected rune %q in prefix %q", r, prefix)
+	}
+}
+
+func TestUniquePrefixDiffersAcrossCalls(t *testing.T) {
+	a := UniquePrefix(t)
+	b := UniquePrefix(t)
+	assert.NotEqual(t, a, b)
+}
@@ -0,0 +1,67 @@
+// Package tfoverride layers test-specific variable overrides on top of an
+// environment's tracked tfvars without touching them: each override is
+// written as a `*.auto.tfvars.json` file, which Terraform loads
+// automatically alongside `<stack>.tfvars`, and Cleanup removes exactly
+// those generated files afterward. Callers should never edit the
+// environment's own tfvars or provider config on disk to inject a
+// test-only value.
+package tfoverride
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Override is a set of variables to layer on top of a single stack's
+// tracked tfvars for the duration of one test run.
+type Override struct {
+	Stack string
+	Vars  map[string]any
+}
+
+// Applied is the set of override files written for one Apply call, and
+// how to remove them.
+type Applied struct {
+	paths []string
+}
+
+// Apply writes each override as config/envs/<env>/<stack>.auto.tfvars.json,
+// which Terraform merges over <stack>.tfvars automatically. It fails
+// without writing partial state if any stack directory can't be created.
+func Apply(root, env string, overrides []Override) (*Applied, error) {
+	applied := &Applied{}
+	for _, o := range overrides {
+		dir := filepath.Join(root, "config", "envs", env)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			applied.Cleanup()
+			return nil, fmt.Errorf("tfoverride: create env dir for stack %s: %w", o.Stack, err)
+		}
+
+		data, err := json.MarshalIndent(o.Vars, "", "  ")
+		if err != nil {
+			applied.Cleanup()
+			return nil, fmt.Errorf("tfoverride: marshal overrides for stack %s: %w", o.Stack, err)
+		}
+
+		path := filepath.Join(dir, o.Stack+".auto.tfvars.json")
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			applied.Cleanup()
+			return nil, fmt.Errorf("tfoverride: write %s: %w", path, err)
+		}
+		applied.paths = append(applied.paths, path)
+	}
+	return applied, nil
+}
+
+// Cleanup removes every override file this Applied wrote. It's safe to
+// call more than once and tolerates files already removed.
+func (a *Applied) Cleanup() error {
+	for _, path := range a.paths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("tfoverride: remove %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package tfoverride
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyWritesAutoTFVarsJSON(t *testing.T) {
+	root := t.TempDir()
+	applied, err := Apply(root, "dev", []Override{{Stack: "aks-cluster", Vars: map[string]any{"node_count": 5}}})
+	require.NoError(t, err)
+
+	path := filepath.Join(root, "config", "envs", "dev", "aks-cluster.auto.tfvars.json")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var vars map[string]any
+	require.NoError(t, json.Unmarshal(data, &vars))
+	assert.Equal(t, float64(5), vars["node_count"])
+
+	require.NoError(t, applied.Cleanup())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestApplyWritesOneFilePerStack(t *testing.T) {
+	root := t.TempDir()
+	applied, err := Apply(root, "dev", []Override{
+		{Stack: "core", Vars: map[string]any{"tags": map[string]any{"team": "platform"}}},
+		{Stack: "aks-cluster", Vars: map[string]any{"vm_size": "Standard_D4s_v5"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, applied.paths, 2)
+
+	for _, path := range applied.paths {
+		_, err := os.Stat(path)
+		assert.NoError(t, err)
+	}
+	require.NoError(t, applied.Cleanup())
+}
+
+func TestCleanupIsIdempotent(t *testing.T) {
+	root := t.TempDir()
+	applied, err := Apply(root, "dev", []Override{{Stack: "core", Vars: map[string]any{"region": "eastus2"}}})
+	require.NoError(t, err)
+
+	require.NoError(t, applied.Cleanup())
+	assert.NoError(t, applied.Cleanup())
+}
+
+func TestApplyDoesNotTouchTrackedTFVars(t *testing.T) {
+	root := t.TempDir()
+	trackedPath := filepath.Join(root, "config", "envs", "dev", "core.tfvars")
+	require.NoError(t, os.MkdirAll(filepath.Dir(trackedPath), 0o755))
+	require.NoError(t, os.WriteFile(trackedPath, []byte(`region = "eastus2"`+"\n"), 0o644))
+
+	applied, err := Apply(root, "dev", []Override{{Stack: "core", Vars: map[string]any{"node_count": 2}}})
+	require.NoError(t, err)
+	defer applied.Cleanup()
+
+	data, err := os.ReadFile(trackedPath)
+	require.NoError(t, err)
+	assert.Equal(t, "region = \"eastus2\"\n", string(data))
+}
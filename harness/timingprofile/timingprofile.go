@@ -0,0 +1,185 @@
+// Package timingprofile parses `terraform apply -json` log lines to record
+// how long each resource type takes to create, aggregates that data
+// across runs, and flags anomalies (e.g. Key Vault suddenly taking 20
+// minutes) so per-stack timeouts can be set from observed reality instead
+// of guesswork.
+package timingprofile
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// applyLogLine is the subset of `terraform apply -json` structured log
+// fields this package needs.
+type applyLogLine struct {
+	Timestamp string          `json:"@timestamp"`
+	Type      string          `json:"type"`
+	Hook      json.RawMessage `json:"hook"`
+}
+
+type applyHook struct {
+	Resource struct {
+		Addr         string `json:"addr"`
+		ResourceType string `json:"resource_type"`
+	} `json:"resource"`
+}
+
+// Timing is how long a single resource took to create in one apply run.
+type Timing struct {
+	ResourceType string        `json:"resource_type"`
+	Address      string        `json:"address"`
+	Duration     time.Duration `json:"duration_ns"`
+}
+
+// ParseApplyLog reads `terraform apply -json` output and returns the
+// creation duration of every resource whose apply_start/apply_complete
+// pair it can match. Lines that aren't valid apply-progress JSON (plain
+// text, unrelated hook types) are skipped rather than treated as errors,
+// since -json output is interleaved with other line types.
+func ParseApplyLog(r io.Reader) ([]Timing, error) {
+	starts := make(map[string]time.Time)
+	var timings []Timing
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(strings.TrimSpace(line), "{") {
+			continue
+		}
+		var entry applyLogLine
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			continue
+		}
+		if entry.Type != "apply_start" && entry.Type != "apply_complete" {
+			continue
+		}
+		var hook applyHook
+		if err := json.Unmarshal(entry.Hook, &hook); err != nil {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, entry.Timestamp)
+		if err != nil {
+			continue
+		}
+
+		switch entry.Type {
+		case "apply_start":
+			starts[hook.Resource.Addr] = ts
+		case "apply_complete":
+			start, ok := starts[hook.Resource.Addr]
+			if !ok {
+				continue
+			}
+			timings = append(timings, Timing{
+				ResourceType: hook.Resource.ResourceType,
+				Address:      hook.Resource.Addr,
+				Duration:     ts.Sub(start),
+			})
+			delete(starts, hook.Resource.Addr)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return timings, fmt.Errorf("timingprofile: scan apply log: %w", err)
+	}
+	return timings, nil
+}
+
+// Aggregate summarizes observed durations for one resource type across
+// runs.
+type Aggregate struct {
+	ResourceType string
+	Count        int
+	Min          time.Duration
+	Max          time.Duration
+	Mean         time.Duration
+}
+
+// AggregateByType groups timings (potentially from many runs) by resource
+// type and computes min/max/mean creation duration for each.
+func AggregateByType(timings []Timing) []Aggregate {
+	type acc struct {
+		count int
+		min   time.Duration
+		max   time.Duration
+		total time.Duration
+	}
+	byType := make(map[string]*acc)
+	var order []string
+	for _, t := range timings {
+		a, ok := byType[t.ResourceType]
+		if !ok {
+			a = &acc{min: t.Duration, max: t.Duration}
+			byType[t.ResourceType] = a
+			order = append(order, t.ResourceType)
+		}
+		a.count++
+		a.total += t.Duration
+		if t.Duration < a.min {
+			a.min = t.Duration
+		}
+		if t.Duration > a.max {
+			a.max = t.Duration
+		}
+	}
+
+	aggregates := make([]Aggregate, 0, len(order))
+	for _, resourceType := range order {
+		a := byType[resourceType]
+		aggregates = append(aggregates, Aggregate{
+			ResourceType: resourceType,
+			Count:        a.count,
+			Min:          a.min,
+			Max:          a.max,
+			Mean:         a.total / time.Duration(a.count),
+		})
+	}
+	return aggregates
+}
+
+// SuggestTimeout returns a recommended per-resource-type timeout: the
+// observed max duration scaled by margin, so a stack's overall timeout
+// can be set from evidence rather than a guess.
+func SuggestTimeout(agg Aggregate, margin float64) time.Duration {
+	return time.Duration(float64(agg.Max) * margin)
+}
+
+// Anomaly flags a resource type whose latest observed duration exceeds
+// its historical baseline by more than a threshold multiple.
+type Anomaly struct {
+	ResourceType string
+	Baseline     time.Duration
+	Observed     time.Duration
+}
+
+// DetectAnomalies compares the latest Timing for each resource type
+// against the baseline Aggregate (computed from prior runs) and returns
+// an Anomaly for any whose duration exceeds baseline.Mean by more than
+// thresholdMultiple.
+func DetectAnomalies(baseline []Aggregate, latest []Timing, thresholdMultiple float64) []Anomaly {
+	baselineByType := make(map[string]Aggregate, len(baseline))
+	for _, b := range baseline {
+		baselineByType[b.ResourceType] = b
+	}
+
+	var anomalies []Anomaly
+	for _, t := range latest {
+		b, ok := baselineByType[t.ResourceType]
+		if !ok || b.Mean == 0 {
+			continue
+		}
+		if float64(t.Duration) > float64(b.Mean)*thresholdMultiple {
+			anomalies = append(anomalies, Anomaly{
+				ResourceType: t.ResourceType,
+				Baseline:     b.Mean,
+				Observed:     t.Duration,
+			})
+		}
+	}
+	return anomalies
+}
@@ -0,0 +1,72 @@
+package timingprofile
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleLog = `{"@timestamp":"2026-08-09T10:00:00Z","type":"apply_start","hook":{"resource":{"addr":"azurerm_kubernetes_cluster.this","resource_type":"azurerm_kubernetes_cluster"}}}
+plain text noise that terraform also prints
+{"@timestamp":"2026-08-09T10:10:00Z","type":"apply_complete","hook":{"resource":{"addr":"azurerm_kubernetes_cluster.this","resource_type":"azurerm_kubernetes_cluster"}}}
+{"@timestamp":"2026-08-09T10:00:00Z","type":"apply_start","hook":{"resource":{"addr":"azurerm_firewall.this","resource_type":"azurerm_firewall"}}}
+{"@timestamp":"2026-08-09T10:08:00Z","type":"apply_complete","hook":{"resource":{"addr":"azurerm_firewall.this","resource_type":"azurerm_firewall"}}}
+`
+
+func TestParseApplyLogMatchesStartAndComplete(t *testing.T) {
+	timings, err := ParseApplyLog(strings.NewReader(sampleLog))
+	require.NoError(t, err)
+	require.Len(t, timings, 2)
+
+	assert.Equal(t, "azurerm_kubernetes_cluster", timings[0].ResourceType)
+	assert.Equal(t, 10*time.Minute, timings[0].Duration)
+	assert.Equal(t, "azurerm_firewall", timings[1].ResourceType)
+	assert.Equal(t, 8*time.Minute, timings[1].Duration)
+}
+
+func TestParseApplyLogIgnoresUnmatchedComplete(t *testing.T) {
+	log := `{"@timestamp":"2026-08-09T10:10:00Z","type":"apply_complete","hook":{"resource":{"addr":"orphan.this","resource_type":"orphan_type"}}}`
+	timings, err := ParseApplyLog(strings.NewReader(log))
+	require.NoError(t, err)
+	assert.Empty(t, timings)
+}
+
+func TestAggregateByType(t *testing.T) {
+	timings := []Timing{
+		{ResourceType: "azurerm_kubernetes_cluster", Duration: 10 * time.Minute},
+		{ResourceType: "azurerm_kubernetes_cluster", Duration: 12 * time.Minute},
+		{ResourceType: "azurerm_firewall", Duration: 8 * time.Minute},
+	}
+	aggregates := AggregateByType(timings)
+	require.Len(t, aggregates, 2)
+
+	assert.Equal(t, "azurerm_kubernetes_cluster", aggregates[0].ResourceType)
+	assert.Equal(t, 2, aggregates[0].Count)
+	assert.Equal(t, 10*time.Minute, aggregates[0].Min)
+	assert.Equal(t, 12*time.Minute, aggregates[0].Max)
+	assert.Equal(t, 11*time.Minute, aggregates[0].Mean)
+}
+
+func TestSuggestTimeoutScalesMaxByMargin(t *testing.T) {
+	agg := Aggregate{Max: 10 * time.Minute}
+	assert.Equal(t, 15*time.Minute, SuggestTimeout(agg, 1.5))
+}
+
+func TestDetectAnomaliesFlagsOutlier(t *testing.T) {
+	baseline := []Aggregate{{ResourceType: "azurerm_key_vault", Mean: 2 * time.Minute}}
+	latest := []Timing{{ResourceType: "azurerm_key_vault", Duration: 20 * time.Minute}}
+
+	anomalies := DetectAnomalies(baseline, latest, 3.0)
+	require.Len(t, anomalies, 1)
+	assert.Equal(t, "azurerm_key_vault", anomalies[0].ResourceType)
+}
+
+func TestDetectAnomaliesIgnoresNormalVariance(t *testing.T) {
+	baseline := []Aggregate{{ResourceType: "azurerm_key_vault", Mean: 2 * time.Minute}}
+	latest := []Timing{{ResourceType: "azurerm_key_vault", Duration: 3 * time.Minute}}
+
+	assert.Empty(t, DetectAnomalies(baseline, latest, 3.0))
+}
@@ -0,0 +1,77 @@
+// Package tokenprobe verifies workload identity from inside the cluster
+// can actually acquire tokens for the audiences the platform depends on
+// (ARM, Key Vault, ACR), codifying exactly which scopes our identity
+// wiring supports and catching misconfigured audience/issuer regressions
+// before an application does.
+package tokenprobe
+
+import (
+	"context"
+	"fmt"
+)
+
+// Scope is an OAuth resource scope a workload is expected to acquire a
+// token for via workload identity federation.
+type Scope string
+
+const (
+	ScopeARM      Scope = "https://management.azure.com/.default"
+	ScopeKeyVault Scope = "https://vault.azure.net/.default"
+	ScopeACR      Scope = "https://containerregistry.azure.net/.default"
+)
+
+// DefaultScopes are the audiences every AKS workload identity is expected
+// to be able to acquire a token for.
+var DefaultScopes = []Scope{ScopeARM, ScopeKeyVault, ScopeACR}
+
+// Acquirer requests a token for scope, typically azidentity's
+// WorkloadIdentityCredential invoked from a probe pod running in-cluster
+// under the workload's federated identity.
+type Acquirer interface {
+	AcquireToken(ctx context.Context, scope Scope) (Token, error)
+}
+
+// Token is the minimal shape of an acquired token this package inspects.
+type Token struct {
+	Audience string
+}
+
+// Result is the outcome of probing a single scope.
+type Result struct {
+	Scope     Scope
+	Succeeded bool
+	Detail    string
+}
+
+// Probe attempts to acquire a token for every scope and reports which
+// succeeded.
+func Probe(ctx context.Context, acquirer Acquirer, scopes []Scope) []Result {
+	results := make([]Result, 0, len(scopes))
+	for _, scope := range scopes {
+		token, err := acquirer.AcquireToken(ctx, scope)
+		if err != nil {
+			results = append(results, Result{Scope: scope, Succeeded: false, Detail: err.Error()})
+			continue
+		}
+		if token.Audience != "" && token.Audience != string(scope) {
+			results = append(results, Result{
+				Scope: scope, Succeeded: false,
+				Detail: fmt.Sprintf("token audience %q does not match requested scope %q", token.Audience, scope),
+			})
+			continue
+		}
+		results = append(results, Result{Scope: scope, Succeeded: true})
+	}
+	return results
+}
+
+// Failures filters results down to the scopes that failed.
+func Failures(results []Result) []Result {
+	var failures []Result
+	for _, r := range results {
+		if !r.Succeeded {
+			failures = append(failures, r)
+		}
+	}
+	return failures
+}
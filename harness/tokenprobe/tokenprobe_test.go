@@ -0,0 +1,58 @@
+package tokenprobe
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAcquirer struct {
+	fail     map[Scope]error
+	audience map[Scope]string
+}
+
+func (f *fakeAcquirer) AcquireToken(ctx context.Context, scope Scope) (Token, error) {
+	if err, ok := f.fail[scope]; ok {
+		return Token{}, err
+	}
+	if aud, ok := f.audience[scope]; ok {
+		return Token{Audience: aud}, nil
+	}
+	return Token{Audience: string(scope)}, nil
+}
+
+func TestProbeSucceedsForAllScopes(t *testing.T) {
+	acquirer := &fakeAcquirer{}
+	results := Probe(context.Background(), acquirer, DefaultScopes)
+	require.Len(t, results, 3)
+	for _, r := range results {
+		assert.True(t, r.Succeeded, "scope %s should succeed", r.Scope)
+	}
+}
+
+func TestProbeFlagsAcquisitionFailure(t *testing.T) {
+	acquirer := &fakeAcquirer{fail: map[Scope]error{ScopeKeyVault: errors.New("AADSTS70021: no matching federated identity record found")}}
+	results := Probe(context.Background(), acquirer, DefaultScopes)
+
+	failures := Failures(results)
+	require.Len(t, failures, 1)
+	assert.Equal(t, ScopeKeyVault, failures[0].Scope)
+	assert.Contains(t, failures[0].Detail, "federated identity")
+}
+
+func TestProbeFlagsAudienceMismatch(t *testing.T) {
+	acquirer := &fakeAcquirer{audience: map[Scope]string{ScopeACR: "https://management.azure.com/.default"}}
+	results := Probe(context.Background(), acquirer, []Scope{ScopeACR})
+
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Succeeded)
+	assert.Contains(t, results[0].Detail, "does not match requested scope")
+}
+
+func TestFailuresEmptyWhenAllSucceed(t *testing.T) {
+	results := []Result{{Scope: ScopeARM, Succeeded: true}, {Scope: ScopeACR, Succeeded: true}}
+	assert.Empty(t, Failures(results))
+}
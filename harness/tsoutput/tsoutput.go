@@ -0,0 +1,163 @@
+// Package tsoutput tolerantly parses the human-readable output of
+// `terraspace up`/`terraspace plan` (resource action lines, summary
+// counts, error blocks) rather than depending on any one Terraspace/
+// Terraform version's exact formatting. Both the classic plain "Error:"
+// block and the newer box-drawing ("╷ │ ╵") error format are recognized,
+// and unrecognized lines are skipped instead of failing the parse, so a
+// cosmetic wording change in a future Terraspace release degrades this
+// parser gracefully instead of breaking CI outright.
+package tsoutput
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Format identifies which error-block rendering a captured log used.
+type Format string
+
+const (
+	FormatUnknown Format = "unknown"
+	FormatClassic Format = "classic"
+	FormatBoxed   Format = "boxed"
+)
+
+// ActionLine is one "# <address> will be <verb>" line from a plan.
+type ActionLine struct {
+	Address string
+	Action  string
+}
+
+// Summary is the resource-count line at the end of a plan or apply.
+type Summary struct {
+	Add     int
+	Change  int
+	Destroy int
+}
+
+// ErrorBlock is one "Error: ..." block, classic or boxed.
+type ErrorBlock struct {
+	Summary string
+	Detail  string
+}
+
+// Result is everything tsoutput could extract from one captured log.
+type Result struct {
+	Format       Format
+	Actions      []ActionLine
+	PlanSummary  *Summary
+	ApplySummary *Summary
+	NoChanges    bool
+	Errors       []ErrorBlock
+}
+
+var (
+	actionLinePattern  = regexp.MustCompile(`^\s*#\s+(\S+)\s+will be\s+(\S+)`)
+	planSummaryPattern = regexp.MustCompile(
+		`^Plan:\s+(\d+)\s+to add,\s+(\d+)\s+to change,\s+(\d+)\s+to destroy\.`)
+	applySummaryPattern = regexp.MustCompile(
+		`^Apply complete!\s+Resources:\s+(\d+)\s+added,\s+(\d+)\s+changed,\s+(\d+)\s+destroyed\.`)
+	classicErrorPattern = regexp.MustCompile(`^Error:\s*(.*)$`)
+	boxedErrorPattern   = regexp.MustCompile(`^│\s*Error:\s*(.*)$`)
+)
+
+const boxTop = "╷"
+const boxBottom = "╵"
+
+// Parse reads captured `terraspace up`/`terraspace plan` output and
+// extracts action lines, the plan or apply summary, and any error blocks.
+// Lines that match none of the recognized shapes are ignored.
+func Parse(r io.Reader) (Result, error) {
+	result := Result{Format: FormatUnknown}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var inBoxedError bool
+	var boxedLines []string
+
+	flushBoxed := func() {
+		if len(boxedLines) == 0 {
+			return
+		}
+		result.Format = FormatBoxed
+		result.Errors = append(result.Errors, splitErrorBlock(strings.Join(boxedLines, "\n")))
+		boxedLines = nil
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == boxTop:
+			inBoxedError = true
+			continue
+		case trimmed == boxBottom:
+			inBoxedError = false
+			flushBoxed()
+			continue
+		case inBoxedError:
+			if m := boxedErrorPattern.FindStringSubmatch(line); m != nil {
+				boxedLines = append(boxedLines, m[1])
+			} else if content, ok := strings.CutPrefix(line, "│"); ok {
+				boxedLines = append(boxedLines, strings.TrimPrefix(content, " "))
+			}
+			continue
+		}
+
+		if m := actionLinePattern.FindStringSubmatch(line); m != nil {
+			result.Actions = append(result.Actions, ActionLine{Address: m[1], Action: m[2]})
+			continue
+		}
+		if m := planSummaryPattern.FindStringSubmatch(line); m != nil {
+			result.PlanSummary = &Summary{Add: atoi(m[1]), Change: atoi(m[2]), Destroy: atoi(m[3])}
+			continue
+		}
+		if m := applySummaryPattern.FindStringSubmatch(line); m != nil {
+			result.ApplySummary = &Summary{Add: atoi(m[1]), Change: atoi(m[2]), Destroy: atoi(m[3])}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "No changes.") {
+			result.NoChanges = true
+			continue
+		}
+		if m := classicErrorPattern.FindStringSubmatch(line); m != nil {
+			if result.Format == FormatUnknown {
+				result.Format = FormatClassic
+			}
+			result.Errors = append(result.Errors, ErrorBlock{Summary: strings.TrimSpace(m[1])})
+			continue
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// splitErrorBlock takes the joined body lines of one boxed error block and
+// separates the summary (first non-empty line) from the remaining detail.
+func splitErrorBlock(body string) ErrorBlock {
+	lines := strings.Split(body, "\n")
+	var summary string
+	var detailLines []string
+	for _, l := range lines {
+		if summary == "" && strings.TrimSpace(l) != "" {
+			summary = strings.TrimSpace(l)
+			continue
+		}
+		if summary != "" {
+			detailLines = append(detailLines, l)
+		}
+	}
+	return ErrorBlock{Summary: summary, Detail: strings.TrimSpace(strings.Join(detailLines, "\n"))}
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,100 @@
+package tsoutput
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const planFixtureV1 = `
+Terraform will perform the following actions:
+
+  # azurerm_resource_group.this will be created
+  + resource "azurerm_resource_group" "this" {
+
+  # azurerm_kubernetes_cluster.this will be updated in-place
+  ~ resource "azurerm_kubernetes_cluster" "this" {
+
+Plan: 1 to add, 1 to change, 0 to destroy.
+`
+
+const applyFixtureV1 = `
+azurerm_resource_group.this: Creating...
+azurerm_resource_group.this: Creation complete after 2s
+
+Apply complete! Resources: 1 added, 0 changed, 0 destroyed.
+`
+
+const noChangesFixture = `
+No changes. Your infrastructure matches the configuration.
+`
+
+const classicErrorFixture = `
+Error: Invalid resource type
+
+  on main.tf line 5, in resource "azurerm_foo" "this":
+   5: resource "azurerm_foo" "this" {
+`
+
+const boxedErrorFixture = "╷\n" +
+	"│ Error: Insufficient feature blocks\n" +
+	"│ \n" +
+	"│   on main.tf line 12, in resource \"azurerm_kubernetes_cluster\" \"this\":\n" +
+	"│   12: resource \"azurerm_kubernetes_cluster\" \"this\" {\n" +
+	"│ \n" +
+	"│ At least one \"default_node_pool\" block is required.\n" +
+	"╵\n"
+
+func TestParseExtractsActionLinesAndPlanSummary(t *testing.T) {
+	result, err := Parse(strings.NewReader(planFixtureV1))
+	require.NoError(t, err)
+
+	require.Len(t, result.Actions, 2)
+	assert.Equal(t, ActionLine{Address: "azurerm_resource_group.this", Action: "created"}, result.Actions[0])
+	assert.Equal(t, ActionLine{Address: "azurerm_kubernetes_cluster.this", Action: "updated"}, result.Actions[1])
+
+	require.NotNil(t, result.PlanSummary)
+	assert.Equal(t, Summary{Add: 1, Change: 1, Destroy: 0}, *result.PlanSummary)
+}
+
+func TestParseExtractsApplySummary(t *testing.T) {
+	result, err := Parse(strings.NewReader(applyFixtureV1))
+	require.NoError(t, err)
+
+	require.NotNil(t, result.ApplySummary)
+	assert.Equal(t, Summary{Add: 1, Change: 0, Destroy: 0}, *result.ApplySummary)
+}
+
+func TestParseFlagsNoChanges(t *testing.T) {
+	result, err := Parse(strings.NewReader(noChangesFixture))
+	require.NoError(t, err)
+	assert.True(t, result.NoChanges)
+}
+
+func TestParseExtractsClassicErrorBlock(t *testing.T) {
+	result, err := Parse(strings.NewReader(classicErrorFixture))
+	require.NoError(t, err)
+
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, FormatClassic, result.Format)
+	assert.Equal(t, "Invalid resource type", result.Errors[0].Summary)
+}
+
+func TestParseExtractsBoxedErrorBlock(t *testing.T) {
+	result, err := Parse(strings.NewReader(boxedErrorFixture))
+	require.NoError(t, err)
+
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, FormatBoxed, result.Format)
+	assert.Equal(t, "Insufficient feature blocks", result.Errors[0].Summary)
+	assert.Contains(t, result.Errors[0].Detail, "default_node_pool")
+}
+
+func TestParseIgnoresUnrecognizedLinesWithoutError(t *testing.T) {
+	result, err := Parse(strings.NewReader("some future terraspace banner nobody has seen yet\n"))
+	require.NoError(t, err)
+	assert.Equal(t, FormatUnknown, result.Format)
+	assert.Empty(t, result.Actions)
+}
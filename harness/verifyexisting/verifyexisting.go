@@ -0,0 +1,96 @@
+// Package verifyexisting runs this repo's read-only assertion suite
+// against an already-deployed environment identified by resource group,
+// without ever calling Terraspace apply or destroy. It exists for
+// environments Terraform doesn't currently own the state for — a
+// manually-repaired environment, or legacy infra imported by hand — where
+// the only thing that matters is "does this match what the suite expects
+// right now," not "does this match a plan."
+//
+// It composes harness/auditmode's Report/Check machinery with
+// harness/conformance's CIS AKS benchmark, following the same
+// cluster-config extraction cmd/conformance already does against a live
+// ManagedCluster.
+package verifyexisting
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v5"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/auditmode"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/conformance"
+)
+
+// Target identifies the live environment to verify.
+type Target struct {
+	SubscriptionID string
+	ResourceGroup  string
+	ClusterName    string
+	Env            string
+}
+
+// Checks builds the read-only auditmode.Check set for target: currently
+// the CIS AKS benchmark subset, run against the live ManagedCluster.
+// Callers with additional read-only checks (e.g. harness/subnetassert,
+// harness/dualstack) can append their own Check values to the result
+// before calling auditmode.Run.
+func Checks(ctx context.Context, cred azcore.TokenCredential, target Target) ([]auditmode.Check, error) {
+	client, err := armcontainerservice.NewManagedClustersClient(target.SubscriptionID, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("verifyexisting: build managed clusters client: %w", err)
+	}
+
+	return []auditmode.Check{
+		{
+			Name: "cis-aks-benchmark",
+			Run: func(ctx context.Context) ([]string, error) {
+				resp, err := client.Get(ctx, target.ResourceGroup, target.ClusterName, nil)
+				if err != nil {
+					return nil, fmt.Errorf("fetch cluster %s/%s: %w", target.ResourceGroup, target.ClusterName, err)
+				}
+
+				report := conformance.Run(fromManagedCluster(resp.ManagedCluster), conformance.DefaultProfile())
+				return report.Failures(), nil
+			},
+		},
+	}, nil
+}
+
+// RunSuite builds the default Check set for target and runs it through
+// auditmode.Run.
+func RunSuite(ctx context.Context, cred azcore.TokenCredential, target Target) (auditmode.Report, error) {
+	checks, err := Checks(ctx, cred, target)
+	if err != nil {
+		return auditmode.Report{}, err
+	}
+	return auditmode.Run(ctx, target.Env, nil, checks), nil
+}
+
+func fromManagedCluster(mc armcontainerservice.ManagedCluster) conformance.ClusterConfig {
+	cfg := conformance.ClusterConfig{}
+	p := mc.Properties
+	if p == nil {
+		return cfg
+	}
+	cfg.RBACEnabled = boolValue(p.EnableRBAC)
+	cfg.LocalAccountsDisabled = boolValue(p.DisableLocalAccounts)
+	if apa := p.APIServerAccessProfile; apa != nil {
+		cfg.PrivateClusterEnabled = boolValue(apa.EnablePrivateCluster)
+		for _, ip := range apa.AuthorizedIPRanges {
+			if ip != nil {
+				cfg.APIServerAuthorizedIPs = append(cfg.APIServerAuthorizedIPs, *ip)
+			}
+		}
+	}
+	if addons := p.AddonProfiles; addons != nil {
+		if policy, ok := addons["azurepolicy"]; ok && policy != nil {
+			cfg.AzurePolicyAddonEnabled = boolValue(policy.Enabled)
+		}
+	}
+	return cfg
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
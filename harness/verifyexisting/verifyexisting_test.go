@@ -0,0 +1,59 @@
+package verifyexisting
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+)
+
+// These flags make `go test -run TestVerifyExisting -args -rg <name> -env
+// dev` work: nothing in this package runs Terraspace, so pointing
+// TestVerifyExisting at a resource group is enough to run the full
+// read-only assertion suite against it.
+var (
+	rgFlag         = flag.String("rg", "", "resource group of an already-deployed environment to verify (required to run this test)")
+	envFlag        = flag.String("env", "dev", "environment name the resource group belongs to")
+	subscriptionID = flag.String("subscription", os.Getenv("AZURE_SUBSCRIPTION_ID"), "Azure subscription ID")
+	clusterFlag    = flag.String("cluster", "", "AKS cluster name within -rg (defaults to \"<env>-aks\")")
+)
+
+func TestVerifyExisting(t *testing.T) {
+	if *rgFlag == "" {
+		t.Skip("verifyexisting: -rg not set; run as `go test -run TestVerifyExisting -args -rg <name> -env dev` against a live environment")
+	}
+	if *subscriptionID == "" {
+		t.Fatal("verifyexisting: -subscription (or $AZURE_SUBSCRIPTION_ID) is required")
+	}
+
+	cluster := *clusterFlag
+	if cluster == "" {
+		cluster = fmt.Sprintf("%s-aks", *envFlag)
+	}
+
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		t.Fatalf("verifyexisting: build credential: %v", err)
+	}
+
+	target := Target{
+		SubscriptionID: *subscriptionID,
+		ResourceGroup:  *rgFlag,
+		ClusterName:    cluster,
+		Env:            *envFlag,
+	}
+
+	report, err := RunSuite(context.Background(), cred, target)
+	if err != nil {
+		t.Fatalf("verifyexisting: run suite: %v", err)
+	}
+	for _, failure := range report.Failures() {
+		t.Errorf("%s: %v", failure.Name, failure.Violations)
+		if failure.Err != nil {
+			t.Errorf("%s: %v", failure.Name, failure.Err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Package versionskew encodes the Kubernetes version promotion policy as
+// an automated check across environment tfvars: prod must never be more
+// than one minor version behind dev/stage, and never run a version that
+// hasn't first been exercised in a lower environment.
+package versionskew
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed "major.minor.patch" Kubernetes version. Patch may be
+// omitted (treated as 0) since AKS often lists versions as "1.30".
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// Parse parses a Kubernetes version string like "1.30" or "1.30.2".
+func Parse(s string) (Version, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 {
+		return Version{}, fmt.Errorf("versionskew: invalid version %q", s)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return Version{}, fmt.Errorf("versionskew: invalid major version in %q: %w", s, err)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return Version{}, fmt.Errorf("versionskew: invalid minor version in %q: %w", s, err)
+	}
+	patch := 0
+	if len(parts) > 2 {
+		patch, err = strconv.Atoi(parts[2])
+		if err != nil {
+			return Version{}, fmt.Errorf("versionskew: invalid patch version in %q: %w", s, err)
+		}
+	}
+	return Version{Major: major, Minor: minor, Patch: patch}, nil
+}
+
+// MinorBehind returns how many minor versions v is behind other. Negative
+// means v is ahead of other.
+func (v Version) MinorBehind(other Version) int {
+	return (other.Major*1000 + other.Minor - (v.Major*1000 + v.Minor))
+}
+
+// EnvVersions maps environment name to its configured Kubernetes version.
+type EnvVersions map[string]Version
+
+// MaxMinorsBehind is the promotion policy's tolerance: prod may lag the
+// lowest lower environment by at most this many minor versions.
+const MaxMinorsBehind = 1
+
+// LowerEnvs are the environments prod's version is checked against. A
+// version must have been exercised in at least one of these before prod
+// can run it.
+var LowerEnvs = []string{"dev", "staging"}
+
+// Check enforces that prod is never more than MaxMinorsBehind minor
+// versions behind the oldest lower environment, and never ahead of every
+// lower environment (i.e. it must have been tested somewhere first).
+// Returns nil if the policy is satisfied.
+func Check(envs EnvVersions) error {
+	prod, ok := envs["prod"]
+	if !ok {
+		return fmt.Errorf("versionskew: no prod version configured")
+	}
+
+	var oldestEnv string
+	var oldest Version
+	var haveLower bool
+	for _, envName := range LowerEnvs {
+		lower, ok := envs[envName]
+		if !ok {
+			continue
+		}
+		// lower.MinorBehind(oldest) > 0 means oldest is ahead of lower,
+		// i.e. lower is the more-behind ("older") of the two.
+		if !haveLower || lower.MinorBehind(oldest) > 0 {
+			oldest, oldestEnv, haveLower = lower, envName, true
+		}
+	}
+	if !haveLower {
+		return fmt.Errorf("versionskew: no lower environment versions configured")
+	}
+
+	// minorsAhead is how many minor versions the oldest lower environment
+	// is ahead of prod; negative means prod is ahead of it.
+	minorsAhead := prod.MinorBehind(oldest)
+	if minorsAhead > MaxMinorsBehind {
+		return fmt.Errorf("versionskew: prod version %d.%d is %d minor version(s) behind %s (max allowed is %d)",
+			prod.Major, prod.Minor, minorsAhead, oldestEnv, MaxMinorsBehind)
+	}
+
+	var testedAtOrAbove bool
+	for _, envName := range LowerEnvs {
+		lower, ok := envs[envName]
+		if !ok {
+			continue
+		}
+		if prod.MinorBehind(lower) >= 0 {
+			testedAtOrAbove = true
+		}
+	}
+	if !testedAtOrAbove {
+		return fmt.Errorf("versionskew: prod version %d.%d has not been exercised in dev or staging yet", prod.Major, prod.Minor)
+	}
+	return nil
+}
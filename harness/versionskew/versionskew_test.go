@@ -0,0 +1,85 @@
+package versionskew
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	v, err := Parse("1.30.2")
+	require.NoError(t, err)
+	assert.Equal(t, Version{Major: 1, Minor: 30, Patch: 2}, v)
+
+	v, err = Parse("1.29")
+	require.NoError(t, err)
+	assert.Equal(t, Version{Major: 1, Minor: 29, Patch: 0}, v)
+
+	_, err = Parse("1")
+	assert.Error(t, err)
+}
+
+func TestMinorBehind(t *testing.T) {
+	v129 := Version{Major: 1, Minor: 29}
+	v130 := Version{Major: 1, Minor: 30}
+
+	assert.Equal(t, 1, v129.MinorBehind(v130))
+	assert.Equal(t, -1, v130.MinorBehind(v129))
+	assert.Equal(t, 0, v129.MinorBehind(v129))
+}
+
+func TestCheckPassesWhenProdWithinPolicy(t *testing.T) {
+	err := Check(EnvVersions{
+		"dev":     mustParse(t, "1.30"),
+		"staging": mustParse(t, "1.30"),
+		"prod":    mustParse(t, "1.29"),
+	})
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsWhenProdTooFarBehind(t *testing.T) {
+	err := Check(EnvVersions{
+		"dev":     mustParse(t, "1.31"),
+		"staging": mustParse(t, "1.31"),
+		"prod":    mustParse(t, "1.29"),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "behind")
+}
+
+func TestCheckComparesAgainstOldestLowerEnvWhenDevAndStagingDiffer(t *testing.T) {
+	// dev has already been bumped two minors ahead of staging, a normal
+	// transient rollout state. prod is only one minor behind staging (the
+	// actual oldest lower env), so it should pass despite being three
+	// minors behind dev.
+	err := Check(EnvVersions{
+		"dev":     mustParse(t, "1.32"),
+		"staging": mustParse(t, "1.30"),
+		"prod":    mustParse(t, "1.29"),
+	})
+	assert.NoError(t, err)
+}
+
+func TestCheckFailsWhenProdVersionNeverTested(t *testing.T) {
+	err := Check(EnvVersions{
+		"dev":     mustParse(t, "1.28"),
+		"staging": mustParse(t, "1.28"),
+		"prod":    mustParse(t, "1.29"),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has not been exercised")
+}
+
+func TestCheckFailsWhenNoProdConfigured(t *testing.T) {
+	err := Check(EnvVersions{"dev": mustParse(t, "1.29")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no prod version")
+}
+
+func mustParse(t *testing.T, s string) Version {
+	t.Helper()
+	v, err := Parse(s)
+	require.NoError(t, err)
+	return v
+}
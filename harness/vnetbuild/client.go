@@ -0,0 +1,25 @@
+package vnetbuild
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerregistry/armcontainerregistry"
+)
+
+// PollingQueuer adapts a real armcontainerregistry.RegistriesClient to the
+// Queuer interface by polling the run to completion before returning.
+type PollingQueuer struct {
+	Client *armcontainerregistry.RegistriesClient
+}
+
+func (p PollingQueuer) BeginScheduleRun(ctx context.Context, resourceGroupName, registryName string, runRequest armcontainerregistry.RunRequestClassification, options *armcontainerregistry.RegistriesClientBeginScheduleRunOptions) (*armcontainerregistry.RegistriesClientScheduleRunResponse, error) {
+	poller, err := p.Client.BeginScheduleRun(ctx, resourceGroupName, registryName, runRequest, options)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := poller.PollUntilDone(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,77 @@
+// Package vnetbuild proves the private ACR Tasks build path works: when a
+// registry builds images using a dedicated agent pool that runs inside the
+// VNet rather than ACR's shared cloud build infrastructure, this package
+// queues a minimal build run against that pool and asserts it both
+// succeeded and actually ran on the expected in-VNet pool, not silently
+// falling back to a public one.
+package vnetbuild
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerregistry/armcontainerregistry"
+)
+
+// Queuer schedules an ACR run and waits for it to reach a terminal state.
+type Queuer interface {
+	BeginScheduleRun(ctx context.Context, resourceGroupName, registryName string, runRequest armcontainerregistry.RunRequestClassification, options *armcontainerregistry.RegistriesClientBeginScheduleRunOptions) (*armcontainerregistry.RegistriesClientScheduleRunResponse, error)
+}
+
+// Result is the outcome of queuing and waiting on one build run.
+type Result struct {
+	Succeeded    bool
+	AgentPool    string
+	Status       string
+	usedExpected bool
+}
+
+// UsedExpectedPool reports whether the run actually built on the expected
+// in-VNet agent pool, the whole point of this check: a run that succeeds
+// but lands on the wrong pool proves nothing about the private path.
+func (r Result) UsedExpectedPool() bool {
+	return r.usedExpected
+}
+
+// Passed reports whether the run completed successfully on the expected
+// in-VNet agent pool.
+func (r Result) Passed() bool {
+	return r.Succeeded && r.usedExpected
+}
+
+// RunMinimalBuild queues runRequest against the registry, expecting it to
+// build on expectedAgentPool, and blocks until the run reaches a terminal
+// state.
+func RunMinimalBuild(ctx context.Context, queuer Queuer, resourceGroupName, registryName, expectedAgentPool string, runRequest armcontainerregistry.RunRequestClassification) (Result, error) {
+	resp, err := queuer.BeginScheduleRun(ctx, resourceGroupName, registryName, runRequest, nil)
+	if err != nil {
+		return Result{}, fmt.Errorf("vnetbuild: schedule run: %w", err)
+	}
+	if resp == nil || resp.Properties == nil {
+		return Result{}, fmt.Errorf("vnetbuild: schedule run: response had no run properties")
+	}
+
+	pool := derefStr(resp.Properties.AgentPoolName)
+	status := string(derefStatus(resp.Properties.Status))
+
+	return Result{
+		Succeeded:    status == string(armcontainerregistry.RunStatusSucceeded),
+		AgentPool:    pool,
+		Status:       status,
+		usedExpected: pool != "" && pool == expectedAgentPool,
+	}, nil
+}
+
+func derefStr(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func derefStatus(s *armcontainerregistry.RunStatus) armcontainerregistry.RunStatus {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
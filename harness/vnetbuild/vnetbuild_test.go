@@ -0,0 +1,82 @@
+package vnetbuild
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerregistry/armcontainerregistry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeQueuer struct {
+	resp *armcontainerregistry.RegistriesClientScheduleRunResponse
+	err  error
+}
+
+func (f *fakeQueuer) BeginScheduleRun(ctx context.Context, resourceGroupName, registryName string, runRequest armcontainerregistry.RunRequestClassification, options *armcontainerregistry.RegistriesClientBeginScheduleRunOptions) (*armcontainerregistry.RegistriesClientScheduleRunResponse, error) {
+	return f.resp, f.err
+}
+
+func runResponse(status armcontainerregistry.RunStatus, agentPool string) *armcontainerregistry.RegistriesClientScheduleRunResponse {
+	return &armcontainerregistry.RegistriesClientScheduleRunResponse{
+		Run: armcontainerregistry.Run{
+			Properties: &armcontainerregistry.RunProperties{
+				Status:        strPtrStatus(status),
+				AgentPoolName: strPtr(agentPool),
+			},
+		},
+	}
+}
+
+func strPtr(s string) *string                                                       { return &s }
+func strPtrStatus(s armcontainerregistry.RunStatus) *armcontainerregistry.RunStatus { return &s }
+
+func TestRunMinimalBuildPassesOnSuccessAndExpectedPool(t *testing.T) {
+	fake := &fakeQueuer{resp: runResponse(armcontainerregistry.RunStatusSucceeded, "vnet-build-pool")}
+
+	result, err := RunMinimalBuild(context.Background(), fake, "rg-test", "devaksacrtest", "vnet-build-pool", &armcontainerregistry.RunRequest{})
+	require.NoError(t, err)
+
+	assert.True(t, result.Passed())
+	assert.True(t, result.UsedExpectedPool())
+	assert.Equal(t, "vnet-build-pool", result.AgentPool)
+}
+
+func TestRunMinimalBuildFailsWhenRunDidNotSucceed(t *testing.T) {
+	fake := &fakeQueuer{resp: runResponse(armcontainerregistry.RunStatusFailed, "vnet-build-pool")}
+
+	result, err := RunMinimalBuild(context.Background(), fake, "rg-test", "devaksacrtest", "vnet-build-pool", &armcontainerregistry.RunRequest{})
+	require.NoError(t, err)
+
+	assert.False(t, result.Passed())
+	assert.Equal(t, "Failed", result.Status)
+}
+
+func TestRunMinimalBuildFailsWhenRunLandedOnWrongPool(t *testing.T) {
+	fake := &fakeQueuer{resp: runResponse(armcontainerregistry.RunStatusSucceeded, "shared-cloud-pool")}
+
+	result, err := RunMinimalBuild(context.Background(), fake, "rg-test", "devaksacrtest", "vnet-build-pool", &armcontainerregistry.RunRequest{})
+	require.NoError(t, err)
+
+	assert.True(t, result.Succeeded)
+	assert.False(t, result.Passed())
+	assert.False(t, result.UsedExpectedPool())
+}
+
+func TestRunMinimalBuildPropagatesScheduleError(t *testing.T) {
+	fake := &fakeQueuer{err: errors.New("registry throttled")}
+
+	_, err := RunMinimalBuild(context.Background(), fake, "rg-test", "devaksacrtest", "vnet-build-pool", &armcontainerregistry.RunRequest{})
+
+	assert.Error(t, err)
+}
+
+func TestRunMinimalBuildFailsWhenResponseHasNoProperties(t *testing.T) {
+	fake := &fakeQueuer{resp: &armcontainerregistry.RegistriesClientScheduleRunResponse{}}
+
+	_, err := RunMinimalBuild(context.Background(), fake, "rg-test", "devaksacrtest", "vnet-build-pool", &armcontainerregistry.RunRequest{})
+
+	assert.Error(t, err)
+}
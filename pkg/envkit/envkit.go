@@ -0,0 +1,93 @@
+// Package envkit is the public Go API for standing up and tearing down a
+// private AKS environment driven by this repo's Terraspace stacks. It
+// composes harness/runner, harness/stackregistry and harness/sandbox
+// behind a small, stable surface so sibling repos can drive an
+// environment from their own integration tests instead of copy-pasting
+// this repo's test code.
+//
+// Everything under harness/ remains free to change shape; envkit is the
+// compatibility boundary and should only grow additive changes.
+package envkit
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/runner"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/sandbox"
+	"github.com/subhashsurana/private-aks-tf-cluster/harness/stackregistry"
+)
+
+// Result is the outcome of driving a single stack, re-exported from
+// harness/runner so callers don't need to import it directly.
+type Result = runner.Result
+
+// Environment drives Terraspace stacks against a single named environment
+// (e.g. "dev", "staging", or a sandbox env from NewSandbox).
+type Environment struct {
+	Root string
+	run  *runner.Runner
+}
+
+// New returns an Environment targeting env, rooted at repoRoot (the
+// checkout of this repository the caller has available, e.g. via a Git
+// submodule or a pinned clone).
+func New(repoRoot, env string) *Environment {
+	r := runner.New(env)
+	r.Dir = repoRoot
+	return &Environment{Root: repoRoot, run: r}
+}
+
+// Up runs `terraspace up` for each stack in order, stopping at the first
+// failure.
+func (e *Environment) Up(stacks ...string) ([]Result, error) {
+	var results []Result
+	for _, stack := range stacks {
+		result, err := e.run.Up(stack)
+		results = append(results, result)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// Down runs `terraspace down` for each stack in reverse of the order
+// given, stopping at the first failure.
+func (e *Environment) Down(stacks ...string) ([]Result, error) {
+	var results []Result
+	for i := len(stacks) - 1; i >= 0; i-- {
+		result, err := e.run.Down(stacks[i])
+		results = append(results, result)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// GatingStacks loads this repo's stack dependency graph and returns the
+// stacks that gate merges, sorted alphabetically by name (the graph's
+// depends_on order is not applied) — experimental stacks (see
+// harness/stackregistry) are excluded.
+func GatingStacks(repoRoot string) ([]string, error) {
+	reg, err := stackregistry.Load(filepath.Join(repoRoot, "config", "stacks_graph.yml"))
+	if err != nil {
+		return nil, fmt.Errorf("envkit: load stack registry: %w", err)
+	}
+	return reg.GatingStacks(), nil
+}
+
+// NewSandbox provisions a personal, cost-capped, TTL-tagged environment
+// for owner and returns an Environment ready to drive it, along with the
+// resolved sandbox config (env name, resource prefix, expiry, tags).
+func NewSandbox(repoRoot, owner string) (*Environment, sandbox.Config, error) {
+	cfg, err := sandbox.Resolve(sandbox.Options{Owner: owner})
+	if err != nil {
+		return nil, sandbox.Config{}, fmt.Errorf("envkit: resolve sandbox config: %w", err)
+	}
+	if _, err := sandbox.WriteTFVars(repoRoot, cfg); err != nil {
+		return nil, sandbox.Config{}, fmt.Errorf("envkit: write sandbox tfvars: %w", err)
+	}
+	return New(repoRoot, cfg.Env), cfg, nil
+}
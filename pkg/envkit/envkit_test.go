@@ -0,0 +1,43 @@
+package envkit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSetsRoot(t *testing.T) {
+	env := New("/repo", "dev")
+	assert.Equal(t, "/repo", env.Root)
+}
+
+func TestGatingStacksExcludesExperimental(t *testing.T) {
+	root := t.TempDir()
+	graphDir := filepath.Join(root, "config")
+	require.NoError(t, os.MkdirAll(graphDir, 0o755))
+	graph := "core:\n  depends_on: []\nmesh:\n  depends_on:\n    - core\n  experimental: true\n"
+	require.NoError(t, os.WriteFile(filepath.Join(graphDir, "stacks_graph.yml"), []byte(graph), 0o644))
+
+	stacks, err := GatingStacks(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"core"}, stacks)
+}
+
+func TestGatingStacksEmptyForMissingGraph(t *testing.T) {
+	stacks, err := GatingStacks(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, stacks)
+}
+
+func TestNewSandboxWritesTFVarsAndReturnsEnvironment(t *testing.T) {
+	root := t.TempDir()
+	env, cfg, err := NewSandbox(root, "Jane.Doe")
+	require.NoError(t, err)
+
+	assert.Equal(t, "sandbox-janedoe", cfg.Env)
+	assert.Equal(t, root, env.Root)
+	assert.FileExists(t, filepath.Join(root, "config", "envs", cfg.Env, "core.tfvars"))
+}